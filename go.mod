@@ -9,6 +9,7 @@ require (
 	github.com/gorilla/websocket v1.5.0
 	go.mongodb.org/mongo-driver v1.12.1
 	golang.org/x/crypto v0.16.0
+	golang.org/x/sync v0.0.0-20220722155255-886fb9371eb4
 	gopkg.in/vansante/go-ffprobe.v2 v2.1.1
 )
 
@@ -23,7 +24,6 @@ require (
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20181117223130-1be2e3e5546d // indirect
-	golang.org/x/sync v0.0.0-20220722155255-886fb9371eb4 // indirect
 	golang.org/x/sys v0.15.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 )
@@ -21,6 +21,64 @@ type Config struct {
 	// because this will give you a performance gain (due to the server will not check of packages number and them ordering).
 	// Otherwise, if your data needs to be in safe, and you cannot afford to lose it, use the TCP.
 	StreamingTransport string `env:"STREAMING_SERVER_TRANSPORT_PROTOCOL" envDefault:"tcp" opts:"tcp,udp"`
+	// StreamingAllowedOrigins is a string with origins separated by comma which are allowed to open
+	// the websocket connection ('*' allows any origin). Requests without Origin header (non-browser clients)
+	// are accepted, because the origin check protects only against cross-site requests from browsers.
+	StreamingAllowedOrigins string `env:"STREAMING_ALLOWED_ORIGINS" envDefault:"http://0.0.0.0:8000,http://localhost:8000,http://127.0.0.1:8000"`
+	// StreamingLeadBuffer is a max. duration of the media which is sent ahead of the playhead of the player.
+	StreamingLeadBuffer string `env:"STREAMING_LEAD_BUFFER" envDefault:"30s"`
+	// StreamingRateFactor is a multiplier of the media bitrate which is used as the sending rate of the stream
+	// (the value more than 1 allows the player to refill its buffer after stalls).
+	StreamingRateFactor float64 `env:"STREAMING_RATE_FACTOR" envDefault:"1.5"`
+	// StreamingPingInterval is an interval of sending the ping messages to the client.
+	StreamingPingInterval string `env:"STREAMING_PING_INTERVAL" envDefault:"30s"`
+	// StreamingPongTimeout is a read deadline of the connection, it is extended by any received message
+	// (including pongs). Must be greater than StreamingPingInterval.
+	StreamingPongTimeout string `env:"STREAMING_PONG_TIMEOUT" envDefault:"60s"`
+	// StreamingWriteTimeout is a write deadline of each message.
+	StreamingWriteTimeout string `env:"STREAMING_WRITE_TIMEOUT" envDefault:"10s"`
+	// StreamingMaxMessageSize is a max. size of the message which is received from the client in bytes
+	// (the live broadcast data is the largest one), the connection which sends more is closed.
+	StreamingMaxMessageSize int64 `env:"STREAMING_MAX_MESSAGE_SIZE" envDefault:"4194304"`
+	// StreamingIdleTimeout is a time after which the connection without actions and active stream is closed.
+	StreamingIdleTimeout string `env:"STREAMING_IDLE_TIMEOUT" envDefault:"10m"`
+	// StreamingCompressionEnabled enables the permessage-deflate negotiation. Only the text (control) messages
+	// are compressed, the media chunks are already compressed by codecs.
+	StreamingCompressionEnabled bool `env:"STREAMING_COMPRESSION_ENABLED" envDefault:"true"`
+	// StreamingCompressionLevel is a flate compression level (from -2 to 9).
+	StreamingCompressionLevel int `env:"STREAMING_COMPRESSION_LEVEL" envDefault:"1"`
+	// StreamingDrainGracePeriod is a time which is given to the active streams for finish on shutdown. The remaining
+	// streams are interrupted, the clients receive the resume positions and the connections are closed.
+	StreamingDrainGracePeriod string `env:"STREAMING_DRAIN_GRACE_PERIOD" envDefault:"30s"`
+	// StreamingSessionTTL is a time after the last saved position when the playback session cannot be resumed.
+	StreamingSessionTTL string `env:"STREAMING_SESSION_TTL" envDefault:"24h"`
+	// StreamingSessionSaveInterval is an interval of saving the position of the playback session.
+	StreamingSessionSaveInterval string `env:"STREAMING_SESSION_SAVE_INTERVAL" envDefault:"5s"`
+	// StreamingCompletedThreshold is a part of the video duration (from 0 to 1) after which the video is considered
+	// as watched till the end (the credits are usually skipped).
+	StreamingCompletedThreshold float64 `env:"STREAMING_COMPLETED_THRESHOLD" envDefault:"0.95"`
+	// StreamingMaxConnections is a max. number of websocket connections of the instance (0 means unlimited).
+	StreamingMaxConnections int `env:"STREAMING_MAX_CONNECTIONS" envDefault:"10000"`
+	// StreamingMaxConnectionsPerIP is a max. number of websocket connections by one client address (0 means unlimited).
+	StreamingMaxConnectionsPerIP int `env:"STREAMING_MAX_CONNECTIONS_PER_IP" envDefault:"32"`
+	// StreamingMaxConnectionsPerUser is a max. number of websocket connections by one user (0 means unlimited).
+	StreamingMaxConnectionsPerUser int `env:"STREAMING_MAX_CONNECTIONS_PER_USER" envDefault:"8"`
+	// StreamingMaxStreamsPerUser is a max. number of concurrent streams by one user (0 means unlimited).
+	StreamingMaxStreamsPerUser int `env:"STREAMING_MAX_STREAMS_PER_USER" envDefault:"3"`
+	// StreamingLiveDVRWindow is a duration of the recent live data which is kept for the late joiners
+	// (they receive the init segment and the data of this window before the live data).
+	StreamingLiveDVRWindow string `env:"STREAMING_LIVE_DVR_WINDOW" envDefault:"30s"`
+	// StreamingLiveViewerBuffer is a max. number of live chunks which are queued for one viewer,
+	// the viewer which lags behind more is disconnected from the broadcast.
+	StreamingLiveViewerBuffer int `env:"STREAMING_LIVE_VIEWER_BUFFER" envDefault:"64"`
+	// StreamingPartyMaxMembers is a max. number of members of the watch party (including the host).
+	StreamingPartyMaxMembers int `env:"STREAMING_PARTY_MAX_MEMBERS" envDefault:"50"`
+	// StreamingPartySyncTolerance is a max. drift of the member playhead from the host one, the member
+	// which drifts more receives the sync message.
+	StreamingPartySyncTolerance string `env:"STREAMING_PARTY_SYNC_TOLERANCE" envDefault:"2s"`
+	// StreamingPartySyncCooldown is a min. interval between the sync messages of one member (the player needs
+	// time for seeking and buffering).
+	StreamingPartySyncCooldown string `env:"STREAMING_PARTY_SYNC_COOLDOWN" envDefault:"5s"`
 	// >>> DATABASE <<<
 	// MongoUri is a simple MongoDb DSN string for connect to database.
 	MongoUri string `env:"MONGO_URI" envDefault:"mongodb://mongodb:27017/streaming"`
@@ -28,6 +86,46 @@ type Config struct {
 	MongoDb string `env:"MONGO_DATABASE" envDefault:"streaming"`
 	// MongoTimeout is a mongo database requests timeout.
 	MongoTimeout string `env:"MONGO_TIMEOUT" envDefault:"10s"`
+	// MongoListFacet means that the page and the total of the lists are fetched by one $facet aggregation,
+	// thus they are consistent. Otherwise, they are fetched by two concurrent queries which may use the indexes.
+	MongoListFacet bool `env:"MONGO_LIST_FACET" envDefault:"false"`
+	// >>> CACHE <<<
+	// CacheMaxEntries is a max. number of items of the in-memory cache (0 means unlimited).
+	CacheMaxEntries int `env:"CACHE_MAX_ENTRIES" envDefault:"100000"`
+	// CacheMaxBytes is a max. estimated size of items of the in-memory cache in bytes (0 means unlimited).
+	// By default, it's 256mb.
+	CacheMaxBytes int64 `env:"CACHE_MAX_BYTES" envDefault:"268435456"`
+	// CacheShards is a number of shards of the in-memory cache (rounded up to the power of two). Each shard has
+	// own lock and the limits which are equal to the part of CacheMaxEntries and CacheMaxBytes, one shard means
+	// the plain map storage with one lock.
+	CacheShards int `env:"CACHE_SHARDS" envDefault:"32"`
+	// CacheEvictionPolicy is a policy which chooses the items for eviction when the cache is full.
+	// 	1. 'lru' evicts the least recently used item.
+	// 	2. 'lfu' evicts the least frequently used item.
+	// 	3. 'wtinylfu' keeps the recent items in the small window and admits them into the main space only when
+	//		they are used more frequently than the items which would be evicted (resistant to the scans of one-hit items).
+	CacheEvictionPolicy string `env:"CACHE_EVICTION_POLICY" envDefault:"wtinylfu" opts:"lru,lfu,wtinylfu"`
+	// CacheBackend is a storage of the cache.
+	// 	1. 'local' keeps the items in memory of the instance (the replicas are not aware of invalidations of each other).
+	// 	2. 'redis' keeps the items in Redis only (shared by all instances).
+	// 	3. 'tiered' keeps the items in memory of the instance (L1) and in Redis (L2), the invalidations are
+	//		broadcast to all instances through Redis pub/sub.
+	CacheBackend string `env:"CACHE_BACKEND" envDefault:"local" opts:"local,redis,tiered"`
+	// CacheRedisPrefix is a prefix of the cache keys in Redis.
+	CacheRedisPrefix string `env:"CACHE_REDIS_PREFIX" envDefault:"cache:"`
+	// CacheInvalidationChannel is a Redis pub/sub channel of the cache invalidations.
+	CacheInvalidationChannel string `env:"CACHE_INVALIDATION_CHANNEL" envDefault:"cache:invalidations"`
+	// >>> REDIS <<<
+	// RedisAddr is an address of the Redis server (host:port).
+	RedisAddr string `env:"REDIS_ADDR" envDefault:"redis:6379"`
+	// RedisPassword is a password of the Redis server (empty value means without authentication).
+	RedisPassword string `env:"REDIS_PASSWORD" envDefault:""`
+	// RedisDB is a number of the Redis database.
+	RedisDB int `env:"REDIS_DB" envDefault:"0"`
+	// RedisPoolSize is a max. number of connections to the Redis server.
+	RedisPoolSize int `env:"REDIS_POOL_SIZE" envDefault:"16"`
+	// RedisTimeout is a timeout of dialing and of each command of the Redis server.
+	RedisTimeout string `env:"REDIS_TIMEOUT" envDefault:"1s"`
 	// >>> APPLICATION <<<
 	PasswordHashCost int `env:"PASSWORD_HASH_COST" envDefault:"10"`
 	// JwtSecretSalt is a secret string which further will convert to slice of bytes and will be provided
@@ -47,6 +145,17 @@ type Config struct {
 	JwtTokenExpiresAfter int64 `env:"JWT_TOKEN_EXPIRES_AFTER" envDefault:"86400"`
 	// JwtTokenEncryptAlgo is a value which will be used as encrypt algo for encode the token.
 	JwtTokenEncryptAlgo string `env:"JWT_TOKEN_ENCRYPT_ALGO" envDefault:"HS256" opts:"HS256,HS384,HS512"`
+	// BlockedTokensFilterCapacity is an expected number of blocked tokens which are not expired yet. It's used for
+	// size the in-process bloom filter which allows to skip the storage lookup for tokens which are not blocked.
+	BlockedTokensFilterCapacity int `env:"BLOCKED_TOKENS_FILTER_CAPACITY" envDefault:"100000"`
+	// BlockedTokensFilterFalsePositiveRate is a probability of the storage lookup for token which is not blocked.
+	BlockedTokensFilterFalsePositiveRate float64 `env:"BLOCKED_TOKENS_FILTER_FALSE_POSITIVE_RATE" envDefault:"0.01"`
+	// BlockedTokensSyncInterval is an interval of loading the tokens which were blocked by other instances
+	// into the filter (a token blocked by other instance may be accepted during this interval).
+	BlockedTokensSyncInterval string `env:"BLOCKED_TOKENS_SYNC_INTERVAL" envDefault:"5s"`
+	// BlockedTokensFilterRebuildInterval is an interval of rebuilding the filter from scratch, thus expired tokens
+	// are dropped from it (a bloom filter cannot remove keys).
+	BlockedTokensFilterRebuildInterval string `env:"BLOCKED_TOKENS_FILTER_REBUILD_INTERVAL" envDefault:"1h"`
 	// ResourceUploadingStrategy is an uploading strategy which will be used for upload files on the server.
 	// 	1. 'muiltipart_form' is a strategy which used builtin sugar approach. It will be parsing a whole file into the
 	//		memory (if a file more than ResourceInMemoryFileSizeThreshold, it will be saved on the disk, otherwise, it will be
@@ -69,8 +178,62 @@ type Config struct {
 	// which may be loaded in the RAM. If file weight is more this value, than it will be loaded on the disk (slow op.).
 	// By default, it's 100mb per file.
 	ResourceInMemoryFileSizeThreshold int64 `env:"IN_MEMORY_FILE_SIZE_THRESHOLD" envDefault:"104857600"`
+	// TotpIssuer is an issuer name which will be shown in the authenticator apps near the account of user.
+	TotpIssuer string `env:"TOTP_ISSUER" envDefault:"streaming_service"`
+	// TotpAllowedSkewSteps is a number of 30 seconds steps before and after the current one which will be accepted
+	// while a one-time password verification (helps when the clocks of server and user's device are not in sync).
+	TotpAllowedSkewSteps int64 `env:"TOTP_ALLOWED_SKEW_STEPS" envDefault:"1"`
+	// TotpRecoveryCodesNumber is a number of single-use recovery codes which will be issued on the second factor
+	// enrolment confirmation (they may be used instead of one-time password when the device is lost).
+	TotpRecoveryCodesNumber int `env:"TOTP_RECOVERY_CODES_NUMBER" envDefault:"10"`
+	// SecondFactorMaxAge is a max. age of the second factor passing which is required by sensitive operations
+	// (for example, deletion of the user). When it's exceeded, the user must authorize with a one-time password again.
+	SecondFactorMaxAge string `env:"SECOND_FACTOR_MAX_AGE" envDefault:"5m"`
+	// AuthFreeAttempts is a number of failed authorization attempts (per account and per client address)
+	// which are not delayed. Each next failed attempt doubles the delay before the following one.
+	AuthFreeAttempts int `env:"AUTH_FREE_ATTEMPTS" envDefault:"3"`
+	// AuthBackoffBase is a delay after the first failed attempt which exceeds AuthFreeAttempts.
+	AuthBackoffBase string `env:"AUTH_BACKOFF_BASE" envDefault:"1s"`
+	// AuthBackoffMax is a max. delay between failed attempts.
+	AuthBackoffMax string `env:"AUTH_BACKOFF_MAX" envDefault:"15m"`
+	// AuthAttemptsWindow is a time after the last failed attempt when the counter of failed attempts is forgotten.
+	AuthAttemptsWindow string `env:"AUTH_ATTEMPTS_WINDOW" envDefault:"1h"`
+	// AuthAccountLockoutThreshold is a number of failed attempts after which the account is locked out.
+	// The unlock link is sent to the user by email, also an administrator can unlock the account.
+	AuthAccountLockoutThreshold int `env:"AUTH_ACCOUNT_LOCKOUT_THRESHOLD" envDefault:"10"`
+	// AuthIPLockoutThreshold is a number of failed attempts after which the client address is locked out.
+	AuthIPLockoutThreshold int `env:"AUTH_IP_LOCKOUT_THRESHOLD" envDefault:"50"`
+	// AuthLockoutDuration is a duration of the lockout (and the lifetime of the unlock link as well).
+	AuthLockoutDuration string `env:"AUTH_LOCKOUT_DURATION" envDefault:"1h"`
+	// AuthAuditTTL is a lifetime of the authorization audit entries.
+	AuthAuditTTL string `env:"AUTH_AUDIT_TTL" envDefault:"720h"`
+	// AdminUserIDs is a string with identifiers of users which have access to the administration endpoints
+	// separated by comma.
+	AdminUserIDs string `env:"ADMIN_USER_IDS" envDefault:""`
 	// AdminContactEmail is a target administrator contact email address for takes a users errors reports.
 	AdminContactEmail string `env:"ADMIN_CONTACT_EMAIL_ADDRESS" envDefault:"glazunov2142@gmail.com"`
+	// >>> MAILER <<<
+	// MailerType is a mailer which will be used for deliver letters to users.
+	// 	1. 'smtp' is a mailer which sends letters through the SMTP server (configured by Smtp* variables).
+	// 	2. 'outbox' is a mailer which writes letters as .eml files into the /var/mail/ directory of the application
+	//		instead of sending them (useful for local development and testing).
+	MailerType string `env:"MAILER_TYPE" envDefault:"outbox" opts:"outbox,smtp"`
+	// MailFromAddress is a sender address of letters.
+	MailFromAddress string `env:"MAIL_FROM_ADDRESS" envDefault:"noreply@streaming.local"`
+	// MailLinksBaseUrl is a base URL which will be used for build the links into letters.
+	MailLinksBaseUrl string `env:"MAIL_LINKS_BASE_URL" envDefault:"http://0.0.0.0:8000"`
+	// SmtpHost is a host of the SMTP server.
+	SmtpHost string `env:"SMTP_HOST" envDefault:"localhost"`
+	// SmtpPort is a port of the SMTP server.
+	SmtpPort string `env:"SMTP_PORT" envDefault:"587"`
+	// SmtpUsername is a username for the SMTP server authentication (empty value means without authentication).
+	SmtpUsername string `env:"SMTP_USERNAME" envDefault:""`
+	// SmtpPassword is a password for the SMTP server authentication.
+	SmtpPassword string `env:"SMTP_PASSWORD" envDefault:""`
+	// EmailVerificationTokenTTL is a lifetime of the token which is sent for verify the email after registration.
+	EmailVerificationTokenTTL string `env:"EMAIL_VERIFICATION_TOKEN_TTL" envDefault:"24h"`
+	// PasswordResetTokenTTL is a lifetime of the token which is sent for reset the forgotten password.
+	PasswordResetTokenTTL string `env:"PASSWORD_RESET_TOKEN_TTL" envDefault:"1h"`
 	// >>> API <<<
 	// ResourcesApiVersionPrefix is a value which will be used as your RestAPI controllers version prefix.
 	// For example: {{schema}}://{{host}}:{{port}}{{ResourcesApiVersionPrefix}}/{{additionalControllerPath}}
@@ -94,4 +257,17 @@ type Config struct {
 	// StreamingChunkSize is a value which means the size of one chunk while reading the file when streaming a resource.
 	// By default, it's 1mb.
 	StreamingChunkSize int `env:"FILE_READER_CHUNK_SIZE" envDefault:"1048576"`
+	// FileReaderMode is a way of reading the files when streaming through websocket: "pread" reads each chunk
+	// from the file, "mmap" maps the file into memory and copies the chunks from the mapping.
+	FileReaderMode string `env:"FILE_READER_MODE" envDefault:"pread" opts:"pread,mmap"`
+	// SegmentCacheMaxBytes is a max. size of the in-memory cache of the hot resources segments in bytes.
+	// Zero value disables the cache. By default, it's 512mb.
+	SegmentCacheMaxBytes int64 `env:"SEGMENT_CACHE_MAX_BYTES" envDefault:"536870912"`
+	// SegmentCacheAdmissionThreshold is a number of streams of a resource within the admission window
+	// after which the resource becomes hot and its segments are served from memory.
+	SegmentCacheAdmissionThreshold int `env:"SEGMENT_CACHE_ADMISSION_THRESHOLD" envDefault:"3"`
+	// SegmentCacheAdmissionWindow is a period during which the streams of a resource are counted for admission.
+	SegmentCacheAdmissionWindow string `env:"SEGMENT_CACHE_ADMISSION_WINDOW" envDefault:"10m"`
+	// SegmentCachePrefetchSegments is a number of segments of a hot resource which are read ahead of the stream.
+	SegmentCachePrefetchSegments int `env:"SEGMENT_CACHE_PREFETCH_SEGMENTS" envDefault:"4"`
 }
@@ -9,16 +9,29 @@ import (
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/accessor"
 	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	accountservice "github.com/Borislavv/video-streaming/internal/domain/service/account"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	apikeyservice "github.com/Borislavv/video-streaming/internal/domain/service/apikey"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	authservice "github.com/Borislavv/video-streaming/internal/domain/service/authenticator"
 	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	mailerinterface "github.com/Borislavv/video-streaming/internal/domain/service/mailer/interface"
+	playlistservice "github.com/Borislavv/video-streaming/internal/domain/service/playlist"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	progressservice "github.com/Borislavv/video-streaming/internal/domain/service/progress"
+	progressinterface "github.com/Borislavv/video-streaming/internal/domain/service/progress/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource"
 	resourceinterface "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityservice "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
 	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/throttler"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactorservice "github.com/Borislavv/video-streaming/internal/domain/service/twofactor"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	uploaderservice "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
@@ -28,9 +41,14 @@ import (
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/render"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/admin"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/apikey"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/audio"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/auth"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/playlist"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/progress"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/resource"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/twofactor"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/user"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/rest/video"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/controller/static"
@@ -42,7 +60,12 @@ import (
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/server/http"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/mailer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader"
+	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/security"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tokenizer"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader"
@@ -132,12 +155,24 @@ func (app *ResourcesApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// resource codecs detector service (videos duration)
+	if err = app.InitCodecsInfoService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// video services
 	if err = app.InitVideoServices(); err != nil {
 		loggerService.Critical(err)
 		return
 	}
 
+	// file reader service (video files delivery)
+	if err = app.InitFileReaderService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// password services
 	if err = app.InitPasswordService(); err != nil {
 		loggerService.Critical(err)
@@ -156,6 +191,48 @@ func (app *ResourcesApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// api key services
+	if err = app.InitApiKeyServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// playlist services
+	if err = app.InitPlaylistServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// watch progress services (continue watching)
+	if err = app.InitWatchProgressServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// mailer service
+	if err = app.InitMailerService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// auth throttler services (failed attempts counting and audit)
+	if err = app.InitAuthThrottlerServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// account services (email verification, password reset and unlock)
+	if err = app.InitAccountServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// second factor services
+	if err = app.InitSecondFactorServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// auth services
 	if err = app.InitAuthServices(); err != nil {
 		loggerService.Critical(err)
@@ -255,6 +332,40 @@ func (app *ResourcesApp) InitMongoDatabase() (deferFunc func(), err error) {
 	return deferFunc, nil
 }
 
+func (app *ResourcesApp) InitCodecsInfoService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	c, err := detector.NewResourceCodecs(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*detectorinterface.Codecs)(nil))).
+		Set(c, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitFileReaderService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := reader.NewFileReaderService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*readerinterface.FileReader)(nil))).
+		Set(r, nil)
+
+	return nil
+}
+
 func (app *ResourcesApp) InitPasswordService() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -289,8 +400,13 @@ func (app *ResourcesApp) InitCacheService() error {
 		return loggerService.LogPropagate(err)
 	}
 
+	storage, err := cacher.NewStorageByConfig(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+
 	c := cacher.NewCache(
-		cacher.NewMapCacheStorage(ctx),
+		storage,
 		cacher.NewCacheDisplacer(ctx, time.Second*1),
 	)
 
@@ -481,6 +597,288 @@ func (app *ResourcesApp) InitAuthServices() error {
 	return nil
 }
 
+func (app *ResourcesApp) InitApiKeyServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewApiKeyRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.ApiKey)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.ApiKey)(nil))).
+		Set(r, nil)
+
+	v, err := validator.NewApiKeyValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.ApiKey)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewApiKeyBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.ApiKey)(nil))).
+		Set(b, nil)
+
+	s, err := apikeyservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*apikeyinterface.CRUD)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitPlaylistServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewPlaylistRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.Playlist)(nil))).
+		Set(r, nil)
+
+	v, err := validator.NewPlaylistValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Playlist)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewPlaylistBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Playlist)(nil))).
+		Set(b, nil)
+
+	s, err := playlistservice.NewCRUDService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*playlistinterface.CRUD)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitWatchProgressServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewWatchProgressRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.WatchProgress)(nil))).
+		Set(r, nil)
+
+	v, err := validator.NewWatchProgressValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.WatchProgress)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewWatchProgressBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.WatchProgress)(nil))).
+		Set(b, nil)
+
+	s, err := progressservice.NewWatchProgressService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*progressinterface.WatchProgress)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitSecondFactorServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	t, err := security.NewTotp(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(t, reflect.TypeOf((*securityservice.Totp)(nil))).
+		Set(t, nil)
+
+	r, err := mongodb.NewTotpRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.Totp)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.Totp)(nil))).
+		Set(r, nil)
+
+	v, err := validator.NewTotpValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Totp)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewTotpBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Totp)(nil))).
+		Set(b, nil)
+
+	s, err := twofactorservice.NewSecondFactorService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*twofactorinterface.SecondFactor)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitMailerService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	if app.cfg.MailerType == mailer.SmtpMailerType {
+		// letters are sent through the SMTP server
+		service, merr := mailer.NewSmtpMailer(app.di)
+		if merr != nil {
+			return loggerService.LogPropagate(merr)
+		}
+
+		app.di.
+			Set(service, reflect.TypeOf((*mailerinterface.Mailer)(nil))).
+			Set(service, nil)
+	} else if app.cfg.MailerType == mailer.OutboxMailerType {
+		// letters are written into the outbox directory
+		service, merr := mailer.NewOutboxMailer(app.di)
+		if merr != nil {
+			return loggerService.LogPropagate(merr)
+		}
+
+		app.di.
+			Set(service, reflect.TypeOf((*mailerinterface.Mailer)(nil))).
+			Set(service, nil)
+	}
+
+	return nil
+}
+
+func (app *ResourcesApp) InitAuthThrottlerServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	ar, err := mongodb.NewAuthAttemptRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(ar, reflect.TypeOf((*repositoryinterface.AuthAttempt)(nil))).
+		Set(ar, reflect.TypeOf((*mongodbinterface.AuthAttempt)(nil))).
+		Set(ar, nil)
+
+	lr, err := mongodb.NewAuthAuditRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(lr, reflect.TypeOf((*repositoryinterface.AuthAudit)(nil))).
+		Set(lr, reflect.TypeOf((*mongodbinterface.AuthAudit)(nil))).
+		Set(lr, nil)
+
+	s, err := throttler.NewAuthThrottlerService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*throttlerinterface.AuthThrottler)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *ResourcesApp) InitAccountServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewActionTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.ActionToken)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.ActionToken)(nil))).
+		Set(r, nil)
+
+	v, err := validator.NewAccountValidator(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(v, reflect.TypeOf((*validatorinterface.Account)(nil))).
+		Set(v, nil)
+
+	b, err := builder.NewAccountBuilder(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*builderinterface.Account)(nil))).
+		Set(b, nil)
+
+	s, err := accountservice.NewAccountService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*accountinterface.Account)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
 func (app *ResourcesApp) InitTokenServices() error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
@@ -492,10 +890,17 @@ func (app *ResourcesApp) InitTokenServices() error {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(r, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
 		Set(r, reflect.TypeOf((*mongodbinterface.BlockedToken)(nil))).
 		Set(r, nil)
 
+	c, err := cache.NewBlockedTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
+		Set(c, nil)
+
 	s, err := tokenizer.NewJwtService(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
@@ -619,6 +1024,68 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	// api key
+	apiKeyCreateController, err := apikey.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	apiKeyListController, err := apikey.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	apiKeyDeleteController, err := apikey.NewDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// playlist
+	playlistCreateController, err := playlist.NewCreateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistUpdateController, err := playlist.NewUpdateController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistGetController, err := playlist.NewGetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistListController, err := playlist.NewListController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	playlistDeleteController, err := playlist.NewDeleteController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// watch progress
+	continueWatchingController, err := progress.NewContinueWatchingController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// second factor
+	secondFactorEnrollController, err := twofactor.NewEnrollController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	secondFactorConfirmController, err := twofactor.NewConfirmController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	secondFactorDisableController, err := twofactor.NewDisableController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// admin
+	adminAccountUnlockController, err := admin.NewAccountUnlockController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	// video
 	videoCreateController, err := video.NewCreateController(app.di)
 	if err != nil {
@@ -632,6 +1099,10 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
+	videoFileController, err := video.NewFileController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
 	videoListController, err := video.NewListController(app.di)
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -648,6 +1119,7 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		videoCreateController,
 		videoUpdatedController,
 		videoGetController,
+		videoFileController,
 		videoListController,
 		videoDeleteController,
 		// audio
@@ -660,6 +1132,24 @@ func (app *ResourcesApp) InitAuthedRestApiControllers() ([]controller.Controller
 		userUpdateController,
 		userGetController,
 		userDeleteController,
+		// api key
+		apiKeyCreateController,
+		apiKeyListController,
+		apiKeyDeleteController,
+		// playlist
+		playlistCreateController,
+		playlistUpdateController,
+		playlistGetController,
+		playlistListController,
+		playlistDeleteController,
+		// watch progress
+		continueWatchingController,
+		// second factor
+		secondFactorEnrollController,
+		secondFactorConfirmController,
+		secondFactorDisableController,
+		// admin
+		adminAccountUnlockController,
 	}, nil
 }
 
@@ -679,9 +1169,39 @@ func (app *ResourcesApp) InitUnauthedRestApiControllers() ([]controller.Controll
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	emailVerificationController, err := auth.NewEmailVerificationController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	emailVerificationResendController, err := auth.NewEmailVerificationResendController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	passwordResetController, err := auth.NewPasswordResetController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	passwordResetConfirmController, err := auth.NewPasswordResetConfirmController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountUnlockController, err := auth.NewAccountUnlockController(app.di)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return []controller.Controller{
 		authorizationController,
 		registrationController,
+		emailVerificationController,
+		emailVerificationResendController,
+		passwordResetController,
+		passwordResetConfirmController,
+		accountUnlockController,
 	}, nil
 }
 
@@ -5,8 +5,13 @@ import (
 	"github.com/Borislavv/video-streaming/internal/app"
 	loggerservice "github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/accessor"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
 	cacheservice "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/throttler"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb"
@@ -15,6 +20,8 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/detector"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/limiter"
+	limiterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/limiter/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
@@ -26,9 +33,23 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party"
+	partyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/ws"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue"
+	queueinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/tokenizer"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file"
+	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"github.com/caarlos0/env/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -119,6 +140,60 @@ func (app *StreamingApp) Run(mWg *sync.WaitGroup) {
 		return
 	}
 
+	// auth throttler services (failed token verifications counting)
+	if err = app.InitAuthThrottlerServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// streaming limiter (connections and streams)
+	if err = app.InitStreamingLimiterService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// stream shaper (bandwidth and flow control)
+	if err = app.InitStreamShaperService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// connection registry (graceful drain and inspection)
+	if err = app.InitConnectionRegistryService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// access service (administrators)
+	if err = app.InitAccessService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// playback sessions (resumable streams and watch progress)
+	if err = app.InitPlaybackSessionServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// playlists (queued playback of the playlist items)
+	if err = app.InitPlaylistServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// live broadcasts (and their recording as regular videos)
+	if err = app.InitLiveServices(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
+	// watch parties (synchronized playback of the members)
+	if err = app.InitWatchPartyService(); err != nil {
+		loggerService.Critical(err)
+		return
+	}
+
 	// websocket actions listener
 	if err = app.InitWebSocketListener(); err != nil {
 		loggerService.Critical(err)
@@ -144,6 +219,9 @@ func (app *StreamingApp) Run(mWg *sync.WaitGroup) {
 	}
 
 	<-app.shutdown()
+
+	// the active streams are drained before the context is canceled (the streams are stopped by it)
+	app.DrainConnections()
 }
 
 func (app *StreamingApp) shutdown() chan os.Signal {
@@ -231,8 +309,13 @@ func (app *StreamingApp) InitCacheService() error {
 		return err
 	}
 
+	storage, err := cacher.NewStorageByConfig(app.di)
+	if err != nil {
+		return err
+	}
+
 	c := cacher.NewCache(
-		cacher.NewMapCacheStorage(ctx),
+		storage,
 		cacher.NewCacheDisplacer(ctx, time.Second*1),
 	)
 
@@ -347,10 +430,34 @@ func (app *StreamingApp) InitWebSocketHandler() error {
 	if err != nil {
 		return loggerService.LogPropagate(err)
 	}
+	resumeSessionStrategy, err := strategy.NewResumeSessionActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	playlistStrategy, err := strategy.NewPlaylistActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	publishStrategy, err := strategy.NewPublishActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	watchLiveStrategy, err := strategy.NewWatchLiveActionStrategy(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
 	app.di.
 		Set(streamByIDStrategy, nil).
+		Set(resumeSessionStrategy, nil).
+		Set(playlistStrategy, nil).
+		Set(publishStrategy, nil).
+		Set(watchLiveStrategy, nil).
 		Set([]strategyinterface.ActionStrategy{
 			streamByIDStrategy,
+			resumeSessionStrategy,
+			playlistStrategy,
+			publishStrategy,
+			watchLiveStrategy,
 		}, reflect.TypeOf((*[]strategyinterface.ActionStrategy)(nil)))
 
 	// handler which use strategies
@@ -393,9 +500,17 @@ func (app *StreamingApp) InitTokenServices() error {
 		return loggerService.LogPropagate(err)
 	}
 	app.di.
-		Set(r, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
+		Set(r, reflect.TypeOf((*mongodbinterface.BlockedToken)(nil))).
 		Set(r, nil)
 
+	c, err := cache.NewBlockedTokenRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repositoryinterface.BlockedToken)(nil))).
+		Set(c, nil)
+
 	s, err := tokenizer.NewJwtService(app.di)
 	if err != nil {
 		return loggerService.LogPropagate(err)
@@ -407,6 +522,241 @@ func (app *StreamingApp) InitTokenServices() error {
 	return nil
 }
 
+func (app *StreamingApp) InitAuthThrottlerServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	ar, err := mongodb.NewAuthAttemptRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(ar, reflect.TypeOf((*repositoryinterface.AuthAttempt)(nil))).
+		Set(ar, nil)
+
+	lr, err := mongodb.NewAuthAuditRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(lr, reflect.TypeOf((*repositoryinterface.AuthAudit)(nil))).
+		Set(lr, nil)
+
+	s, err := throttler.NewAuthThrottlerService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*throttlerinterface.AuthThrottler)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitStreamingLimiterService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	l, err := limiter.NewStreamingLimiter(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(l, reflect.TypeOf((*limiterinterface.StreamingLimiter)(nil))).
+		Set(l, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitStreamShaperService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	s, err := shaper.NewStreamShaper(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*shaperinterface.Shaper)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitConnectionRegistryService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := registry.NewConnectionRegistry(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*registryinterface.Registry)(nil))).
+		Set(r, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitPlaybackSessionServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewPlaybackSessionRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.PlaybackSession)(nil))).
+		Set(r, nil)
+
+	p, err := mongodb.NewWatchProgressRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(p, reflect.TypeOf((*repositoryinterface.WatchProgress)(nil))).
+		Set(p, nil)
+
+	s, err := session.NewPlaybackSessions(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(s, reflect.TypeOf((*sessioninterface.Sessions)(nil))).
+		Set(s, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitPlaylistServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	r, err := mongodb.NewPlaylistRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*repositoryinterface.Playlist)(nil))).
+		Set(r, nil)
+
+	q, err := queue.NewPlaybackQueues(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(q, reflect.TypeOf((*queueinterface.Queues)(nil))).
+		Set(q, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitLiveServices() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	// the recording of a broadcast is saved as a resource file with a video
+	r, err := mongodb.NewResourceRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(r, reflect.TypeOf((*mongodbinterface.Resource)(nil))).
+		Set(r, nil)
+
+	c, err := cache.NewResourceRepository(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(c, reflect.TypeOf((*repositoryinterface.Resource)(nil))).
+		Set(c, nil)
+
+	filesystemStorage, err := file.NewFilesystemStorageService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(filesystemStorage, reflect.TypeOf((*fileinterface.Storage)(nil))).
+		Set(filesystemStorage, reflect.TypeOf((*storagerinterface.Storage)(nil))).
+		Set(filesystemStorage, nil)
+
+	b, err := live.NewLiveBroadcasts(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(b, reflect.TypeOf((*liveinterface.Broadcasts)(nil))).
+		Set(b, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitWatchPartyService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	p, err := party.NewWatchParties(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(p, reflect.TypeOf((*partyinterface.Parties)(nil))).
+		Set(p, nil)
+
+	return nil
+}
+
+func (app *StreamingApp) InitAccessService() error {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return err
+	}
+
+	a, err := accessor.NewAccessService(app.di)
+	if err != nil {
+		return loggerService.LogPropagate(err)
+	}
+	app.di.
+		Set(a, reflect.TypeOf((*accessorinterface.Accessor)(nil))).
+		Set(a, nil)
+
+	return nil
+}
+
+// DrainConnections will gracefully close the websocket connections (see registryinterface.Registry.Drain).
+func (app *StreamingApp) DrainConnections() {
+	loggerService, err := app.di.GetLoggerService()
+	if err != nil {
+		return
+	}
+
+	r, err := app.di.GetConnectionRegistryService()
+	if err != nil {
+		loggerService.Error(err)
+		return
+	}
+
+	r.Drain()
+}
+
 func (app *StreamingApp) InitWebSocketServer(wg *sync.WaitGroup) error {
 	loggerService, err := app.di.GetLoggerService()
 	if err != nil {
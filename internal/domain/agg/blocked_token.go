@@ -12,13 +12,16 @@ type BlockedToken struct {
 	Timestamp vo.Timestamp `json:"timestamp" bson:",inline"`
 }
 
-func NewBlockedToken(token string, reason string, userID vo.ID) *BlockedToken {
+// NewBlockedToken makes a blocked token by hash of the raw token (raw tokens are not stored, thus
+// they cannot be leaked from the storage) and expiration time of the original token.
+func NewBlockedToken(hash string, reason string, userID vo.ID, expiresAt time.Time) *BlockedToken {
 	return &BlockedToken{
 		BlockedToken: entity.BlockedToken{
-			Value:     token,
+			Value:     hash,
 			UserID:    userID,
 			Reason:    reason,
 			BlockedAt: time.Now(),
+			ExpiresAt: expiresAt,
 		},
 		Timestamp: vo.Timestamp{
 			CreatedAt: time.Now(),
@@ -26,3 +29,14 @@ func NewBlockedToken(token string, reason string, userID vo.ID) *BlockedToken {
 		},
 	}
 }
+
+// NewUserTokensRevocation makes a blocked token without value which means
+// that all tokens of the user issued before this moment are blocked. The expiresAt must be not less than
+// expiration time of the latest issued token (the revocation is useless after it).
+// The moment is truncated to the second, because the issue time of tokens ('iat' claim) is kept in seconds,
+// thus the tokens which are issued right after the revocation (e.g. by the new password) stay valid.
+func NewUserTokensRevocation(userID vo.ID, reason string, expiresAt time.Time) *BlockedToken {
+	revocation := NewBlockedToken("", reason, userID, expiresAt)
+	revocation.BlockedAt = revocation.BlockedAt.Truncate(time.Second)
+	return revocation
+}
@@ -2,6 +2,7 @@ package agg
 
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 )
 
@@ -11,3 +12,17 @@ type Video struct {
 	Resource  entity.Resource `json:"resource" bson:"resource"`
 	Timestamp vo.Timestamp    `json:"timestamp" bson:",inline"`
 }
+
+// SortValue returns the value of the video by the sort field (see enum.VideoSortFields).
+func (v Video) SortValue(field string) interface{} {
+	switch field {
+	case enum.VideoSortByName:
+		return v.Name
+	case enum.VideoSortByDuration:
+		return v.Duration
+	case enum.VideoSortBySize:
+		return v.Resource.Filesize
+	default:
+		return v.Timestamp.CreatedAt
+	}
+}
@@ -0,0 +1,158 @@
+package builder
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// actionTokenLength is a number of random bytes of a raw token (hex encoded token will be twice longer).
+	actionTokenLength = 32
+	tokenField        = "token"
+)
+
+type AccountBuilder struct {
+	logger    loggerinterface.Logger
+	extractor extractorinterface.RequestParams
+}
+
+// NewAccountBuilder is a constructor of AccountBuilder
+func NewAccountBuilder(serviceContainer diinterface.ServiceContainer) (*AccountBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AccountBuilder{
+		logger:    loggerService,
+		extractor: requestParametersExtractor,
+	}, nil
+}
+
+// BuildEmailVerificationRequestDTOFromRequest - build a dto.VerifyEmailRequest from raw *http.Request,
+// the token is passed as query parameter because the request is made by following the link from the letter.
+func (b *AccountBuilder) BuildEmailVerificationRequestDTOFromRequest(
+	r *http.Request,
+) (*dto.EmailVerificationRequestDTO, error) {
+	token, err := b.extractor.GetParameter(tokenField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &dto.EmailVerificationRequestDTO{Token: token}, nil
+}
+
+// BuildEmailVerificationResendRequestDTOFromRequest - build a dto.ResendEmailVerificationRequest from raw *http.Request
+func (b *AccountBuilder) BuildEmailVerificationResendRequestDTOFromRequest(
+	r *http.Request,
+) (*dto.EmailVerificationResendRequestDTO, error) {
+	reqDTO := &dto.EmailVerificationResendRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(reqDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+	return reqDTO, nil
+}
+
+// BuildPasswordResetRequestDTOFromRequest - build a dto.ResetPasswordRequest from raw *http.Request
+func (b *AccountBuilder) BuildPasswordResetRequestDTOFromRequest(r *http.Request) (*dto.PasswordResetRequestDTO, error) {
+	reqDTO := &dto.PasswordResetRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(reqDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+	return reqDTO, nil
+}
+
+// BuildPasswordResetConfirmRequestDTOFromRequest - build a dto.ConfirmPasswordResetRequest from raw *http.Request
+func (b *AccountBuilder) BuildPasswordResetConfirmRequestDTOFromRequest(
+	r *http.Request,
+) (*dto.PasswordResetConfirmRequestDTO, error) {
+	reqDTO := &dto.PasswordResetConfirmRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(reqDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+	return reqDTO, nil
+}
+
+// BuildAccountUnlockRequestDTOFromRequest - build a dto.UnlockAccountRequest from raw *http.Request,
+// the token is passed as query parameter because the request is made by following the link from the letter.
+func (b *AccountBuilder) BuildAccountUnlockRequestDTOFromRequest(r *http.Request) (*dto.AccountUnlockRequestDTO, error) {
+	token, err := b.extractor.GetParameter(tokenField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &dto.AccountUnlockRequestDTO{Token: token}, nil
+}
+
+// BuildAdminAccountUnlockRequestDTOFromRequest - build a dto.AdminUnlockAccountRequest from raw *http.Request
+func (b *AccountBuilder) BuildAdminAccountUnlockRequestDTOFromRequest(
+	r *http.Request,
+) (*dto.AdminAccountUnlockRequestDTO, error) {
+	reqDTO := &dto.AdminAccountUnlockRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(reqDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up an administrator id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		reqDTO.UserID = userID
+	}
+
+	return reqDTO, nil
+}
+
+// BuildActionTokenAgg - build an agg.ActionToken for given user and action. The raw token is returned
+// separately because only its hash will be stored.
+func (b *AccountBuilder) BuildActionTokenAgg(
+	userID vo.ID, action string, ttl time.Duration,
+) (token *agg.ActionToken, rawToken string, err error) {
+	// generating a new random token
+	p := make([]byte, actionTokenLength)
+	if _, err = rand.Read(p); err != nil {
+		return nil, "", b.logger.CriticalPropagate(err)
+	}
+	rawToken = hex.EncodeToString(p)
+
+	return &agg.ActionToken{
+		ActionToken: entity.ActionToken{
+			UserID:    userID,
+			Action:    action,
+			Hash:      helper.SHA256([]byte(rawToken)),
+			ExpiresAt: time.Now().Add(ttl),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, rawToken, nil
+}
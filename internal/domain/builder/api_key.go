@@ -0,0 +1,155 @@
+package builder
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// apiKeyLength is a number of random bytes of a raw key (hex encoded key will be twice longer).
+	apiKeyLength = 32
+	// apiKeyPrefixLength is a number of first chars of a raw key which will be stored as is.
+	apiKeyPrefixLength = 8
+)
+
+type ApiKeyBuilder struct {
+	logger    loggerinterface.Logger
+	extractor extractorinterface.RequestParams
+}
+
+// NewApiKeyBuilder is a constructor of ApiKeyBuilder
+func NewApiKeyBuilder(serviceContainer diinterface.ServiceContainer) (*ApiKeyBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ApiKeyBuilder{
+		logger:    loggerService,
+		extractor: requestParametersExtractor,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreateApiKeyRequest from raw *http.Request
+func (b *ApiKeyBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.ApiKeyCreateRequestDTO, error) {
+	apiKeyDTO := &dto.ApiKeyCreateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(apiKeyDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		apiKeyDTO.UserID = userID
+	}
+
+	return apiKeyDTO, nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.ApiKey from dto.CreateApiKeyRequest. The raw key is returned
+// separately because only its hash will be stored.
+func (b *ApiKeyBuilder) BuildAggFromCreateRequestDTO(
+	req dtointerface.CreateApiKeyRequest,
+) (apiKey *agg.ApiKey, rawKey string, err error) {
+	// this validation checked previously into the DTO validator
+	var expiresAt time.Time
+	if req.GetExpiresAt() != "" {
+		expiresAt, err = helper.ParseTime(req.GetExpiresAt())
+		if err != nil {
+			return nil, "", b.logger.LogPropagate(errtype.NewTimeParsingValidationError(req.GetExpiresAt()))
+		}
+	}
+
+	// generating a new random key
+	p := make([]byte, apiKeyLength)
+	if _, err = rand.Read(p); err != nil {
+		return nil, "", b.logger.CriticalPropagate(err)
+	}
+	rawKey = hex.EncodeToString(p)
+
+	return &agg.ApiKey{
+		ApiKey: entity.ApiKey{
+			UserID:    req.GetUserID(),
+			Name:      req.GetName(),
+			Prefix:    rawKey[:apiKeyPrefixLength],
+			Hash:      helper.SHA256([]byte(rawKey)),
+			Scopes:    req.GetScopes(),
+			ExpiresAt: expiresAt,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, rawKey, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListApiKeyRequest from raw *http.Request
+func (b *ApiKeyBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.ApiKeyListRequestDTO, error) {
+	apiKeyDTO := &dto.ApiKeyListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		apiKeyDTO.UserID = userID
+	}
+
+	return apiKeyDTO, nil
+}
+
+// BuildDeleteRequestDTOFromRequest - build a dto.DeleteApiKeyRequest from raw *http.Request
+func (b *ApiKeyBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.ApiKeyDeleteRequestDTO, error) {
+	apiKeyDTO := &dto.ApiKeyDeleteRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		apiKeyDTO.UserID = userID
+	}
+
+	// setting up an api key id
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	apiKeyDTO.ID = vo.ID{Value: oID}
+
+	return apiKeyDTO, nil
+}
+
+// BuildResponseDTO - build a dto.ApiKeyResponseDTO, the rawKey must be passed only right after creation.
+func (b *ApiKeyBuilder) BuildResponseDTO(apiKey *agg.ApiKey, rawKey string) *dto.ApiKeyResponseDTO {
+	return &dto.ApiKeyResponseDTO{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.Timestamp.CreatedAt,
+		Key:        rawKey,
+	}
+}
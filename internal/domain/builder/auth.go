@@ -7,6 +7,7 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	"io"
 	"net/http"
 )
@@ -32,5 +33,6 @@ func (b *AuthBuilder) BuildAuthRequestDTOFromRequest(r *http.Request) (dtointerf
 		}
 		return nil, b.logger.LogPropagate(err)
 	}
+	authDTO.IP = helper.RemoteHost(r.RemoteAddr)
 	return authDTO, nil
 }
@@ -0,0 +1,19 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"net/http"
+	"time"
+)
+
+type Account interface {
+	BuildEmailVerificationRequestDTOFromRequest(r *http.Request) (*dto.EmailVerificationRequestDTO, error)
+	BuildEmailVerificationResendRequestDTOFromRequest(r *http.Request) (*dto.EmailVerificationResendRequestDTO, error)
+	BuildPasswordResetRequestDTOFromRequest(r *http.Request) (*dto.PasswordResetRequestDTO, error)
+	BuildPasswordResetConfirmRequestDTOFromRequest(r *http.Request) (*dto.PasswordResetConfirmRequestDTO, error)
+	BuildAccountUnlockRequestDTOFromRequest(r *http.Request) (*dto.AccountUnlockRequestDTO, error)
+	BuildAdminAccountUnlockRequestDTOFromRequest(r *http.Request) (*dto.AdminAccountUnlockRequestDTO, error)
+	BuildActionTokenAgg(userID vo.ID, action string, ttl time.Duration) (token *agg.ActionToken, rawToken string, err error)
+}
@@ -0,0 +1,16 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type ApiKey interface {
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.ApiKeyCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(reqDTO dtointerface.CreateApiKeyRequest) (apiKey *agg.ApiKey, rawKey string, err error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.ApiKeyListRequestDTO, error)
+	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.ApiKeyDeleteRequestDTO, error)
+	BuildResponseDTO(apiKey *agg.ApiKey, rawKey string) *dto.ApiKeyResponseDTO
+}
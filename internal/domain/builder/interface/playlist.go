@@ -0,0 +1,18 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Playlist interface {
+	BuildGetRequestDTOFromRequest(r *http.Request) (*dto.PlaylistGetRequestDTO, error)
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.PlaylistListRequestDTO, error)
+	BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistCreateRequestDTO, error)
+	BuildAggFromCreateRequestDTO(reqDTO dtointerface.CreatePlaylistRequest) (*agg.Playlist, error)
+	BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistUpdateRequestDTO, error)
+	BuildAggFromUpdateRequestDTO(reqDTO dtointerface.UpdatePlaylistRequest) (*agg.Playlist, error)
+	BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.PlaylistDeleteRequestDTO, error)
+}
@@ -0,0 +1,17 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"net/http"
+)
+
+type Totp interface {
+	BuildEnrollRequestDTOFromRequest(r *http.Request) (*dto.TotpEnrollRequestDTO, error)
+	BuildAggFromEnrollRequestDTO(reqDTO dtointerface.EnrollTotpRequest, secret string) *agg.Totp
+	BuildConfirmRequestDTOFromRequest(r *http.Request) (*dto.TotpConfirmRequestDTO, error)
+	BuildDisableRequestDTOFromRequest(r *http.Request) (*dto.TotpDisableRequestDTO, error)
+	BuildEnrollmentResponseDTO(secret string, uri string) *dto.TotpEnrollmentResponseDTO
+	BuildRecoveryCodesResponseDTO(codes []string) *dto.TotpRecoveryCodesResponseDTO
+}
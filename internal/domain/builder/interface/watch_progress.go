@@ -0,0 +1,12 @@
+package builderinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"net/http"
+)
+
+type WatchProgress interface {
+	BuildListRequestDTOFromRequest(r *http.Request) (*dto.WatchProgressListRequestDTO, error)
+	BuildResponseDTO(progress *agg.WatchProgress, video *agg.Video) *dto.WatchProgressResponseDTO
+}
@@ -0,0 +1,244 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type PlaylistBuilder struct {
+	logger             loggerinterface.Logger
+	ctx                context.Context
+	extractor          extractorinterface.RequestParams
+	playlistRepository repositoryinterface.Playlist
+	videoRepository    repositoryinterface.Video
+}
+
+// NewPlaylistBuilder is a constructor of PlaylistBuilder
+func NewPlaylistBuilder(serviceContainer diinterface.ServiceContainer) (*PlaylistBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PlaylistBuilder{
+		ctx:                ctx,
+		logger:             loggerService,
+		extractor:          requestParametersExtractor,
+		playlistRepository: playlistRepository,
+		videoRepository:    videoRepository,
+	}, nil
+}
+
+// BuildCreateRequestDTOFromRequest - build a dto.CreatePlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildCreateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistCreateRequestDTO, error) {
+	playlistDTO := &dto.PlaylistCreateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(playlistDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	return playlistDTO, nil
+}
+
+// BuildAggFromCreateRequestDTO - build an agg.Playlist from dto.CreatePlaylistRequest
+func (b *PlaylistBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreatePlaylistRequest) (*agg.Playlist, error) {
+	if err := b.checkVideos(req.GetVideoIDs(), req.GetUserID()); err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	videoIDs := req.GetVideoIDs()
+	if videoIDs == nil {
+		videoIDs = []vo.ID{}
+	}
+
+	return &agg.Playlist{
+		Playlist: entity.Playlist{
+			UserID:   req.GetUserID(),
+			Name:     req.GetName(),
+			VideoIDs: videoIDs,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	}, nil
+}
+
+// BuildUpdateRequestDTOFromRequest - build a dto.UpdatePlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildUpdateRequestDTOFromRequest(r *http.Request) (*dto.PlaylistUpdateRequestDTO, error) {
+	playlistDTO := &dto.PlaylistUpdateRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(playlistDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	// setting up a playlist id
+	id, err := b.extractID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	playlistDTO.ID = id
+
+	return playlistDTO, nil
+}
+
+// BuildAggFromUpdateRequestDTO - build an agg.Playlist from dto.UpdatePlaylistRequest
+func (b *PlaylistBuilder) BuildAggFromUpdateRequestDTO(req dtointerface.UpdatePlaylistRequest) (*agg.Playlist, error) {
+	playlist, err := b.playlistRepository.FindOneByID(b.ctx, req)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	changes := 0
+	if req.GetName() != "" && playlist.Name != req.GetName() {
+		playlist.Name = req.GetName()
+		changes++
+	}
+	// the omitted list is not changed, the empty one clears the playlist
+	if req.GetVideoIDs() != nil {
+		if err = b.checkVideos(req.GetVideoIDs(), req.GetUserID()); err != nil {
+			return nil, b.logger.LogPropagate(err)
+		}
+		playlist.VideoIDs = req.GetVideoIDs()
+		changes++
+	}
+	if changes > 0 {
+		playlist.Timestamp.UpdatedAt = time.Now()
+	}
+
+	return playlist, nil
+}
+
+// BuildGetRequestDTOFromRequest - build a dto.GetPlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildGetRequestDTOFromRequest(r *http.Request) (*dto.PlaylistGetRequestDTO, error) {
+	playlistDTO := &dto.PlaylistGetRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	// setting up a playlist id
+	id, err := b.extractID(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+	playlistDTO.ID = id
+
+	return playlistDTO, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListPlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.PlaylistListRequestDTO, error) {
+	playlistDTO := &dto.PlaylistListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		playlistDTO.UserID = userID
+	}
+
+	if b.extractor.HasParameter(pageField, r) {
+		pg, _ := b.extractor.GetParameter(pageField, r)
+		pgi, atoiErr := strconv.Atoi(pg)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		playlistDTO.Page = pgi
+	} else {
+		playlistDTO.Page = pageDefaultValue
+	}
+	if b.extractor.HasParameter(limitField, r) {
+		l, _ := b.extractor.GetParameter(limitField, r)
+		li, atoiErr := strconv.Atoi(l)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		playlistDTO.Limit = li
+	} else {
+		playlistDTO.Limit = limitDefaultValue
+	}
+
+	return playlistDTO, nil
+}
+
+// BuildDeleteRequestDTOFromRequest - build a dto.DeletePlaylistRequest from raw *http.Request
+func (b *PlaylistBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.PlaylistDeleteRequestDTO, error) {
+	playlistGetDTO, err := b.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	return &dto.PlaylistDeleteRequestDTO{ID: playlistGetDTO.ID, UserID: playlistGetDTO.UserID}, nil
+}
+
+func (b *PlaylistBuilder) extractID(r *http.Request) (vo.ID, error) {
+	hexID, err := b.extractor.GetParameter(idField, r)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	oID, err := primitive.ObjectIDFromHex(hexID)
+	if err != nil {
+		return vo.ID{}, err
+	}
+	return vo.NewID(oID), nil
+}
+
+// checkVideos makes sure that each video of the playlist exists and is available for the user.
+func (b *PlaylistBuilder) checkVideos(videoIDs []vo.ID, userID vo.ID) error {
+	for _, videoID := range videoIDs {
+		q := dto.NewVideoGetRequestDTO(videoID, "", vo.ID{}, userID)
+		if _, err := b.videoRepository.FindOneByID(b.ctx, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package builder
+
+import (
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"io"
+	"net/http"
+	"time"
+)
+
+type TotpBuilder struct {
+	logger loggerinterface.Logger
+}
+
+// NewTotpBuilder is a constructor of TotpBuilder
+func NewTotpBuilder(serviceContainer diinterface.ServiceContainer) (*TotpBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TotpBuilder{logger: loggerService}, nil
+}
+
+// BuildEnrollRequestDTOFromRequest - build a dto.EnrollTotpRequest from raw *http.Request
+func (b *TotpBuilder) BuildEnrollRequestDTOFromRequest(r *http.Request) (*dto.TotpEnrollRequestDTO, error) {
+	totpDTO := &dto.TotpEnrollRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		totpDTO.UserID = userID
+	}
+
+	return totpDTO, nil
+}
+
+// BuildAggFromEnrollRequestDTO - build an agg.Totp which is waiting for enrolment confirmation.
+func (b *TotpBuilder) BuildAggFromEnrollRequestDTO(req dtointerface.EnrollTotpRequest, secret string) *agg.Totp {
+	return &agg.Totp{
+		Totp: entity.Totp{
+			UserID:        req.GetUserID(),
+			PendingSecret: secret,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
+
+// BuildConfirmRequestDTOFromRequest - build a dto.ConfirmTotpRequest from raw *http.Request
+func (b *TotpBuilder) BuildConfirmRequestDTOFromRequest(r *http.Request) (*dto.TotpConfirmRequestDTO, error) {
+	totpDTO := &dto.TotpConfirmRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(totpDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		totpDTO.UserID = userID
+	}
+
+	return totpDTO, nil
+}
+
+// BuildDisableRequestDTOFromRequest - build a dto.DisableTotpRequest from raw *http.Request
+func (b *TotpBuilder) BuildDisableRequestDTOFromRequest(r *http.Request) (*dto.TotpDisableRequestDTO, error) {
+	totpDTO := &dto.TotpDisableRequestDTO{}
+	if err := json.NewDecoder(r.Body).Decode(totpDTO); err != nil {
+		if err == io.EOF {
+			return nil, b.logger.LogPropagate(errtype.NewRequestBodyIsEmptyError())
+		}
+		return nil, b.logger.LogPropagate(err)
+	}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		totpDTO.UserID = userID
+	}
+
+	return totpDTO, nil
+}
+
+func (b *TotpBuilder) BuildEnrollmentResponseDTO(secret string, uri string) *dto.TotpEnrollmentResponseDTO {
+	return &dto.TotpEnrollmentResponseDTO{
+		Secret: secret,
+		URI:    uri,
+	}
+}
+
+func (b *TotpBuilder) BuildRecoveryCodesResponseDTO(codes []string) *dto.TotpRecoveryCodesResponseDTO {
+	return &dto.TotpRecoveryCodesResponseDTO{RecoveryCodes: codes}
+}
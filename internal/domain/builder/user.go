@@ -108,6 +108,8 @@ func (b *UserBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateUserRe
 			Username: req.GetUsername(),
 			Email:    req.GetEmail(),
 			Birthday: birthday,
+			// the account will be activated after email verification
+			Status: enum.UserPendingStatus,
 		},
 		Timestamp: vo.Timestamp{
 			CreatedAt: time.Now(),
@@ -15,6 +15,7 @@ import (
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"io"
 	"net/http"
@@ -30,6 +31,9 @@ const (
 	toField           = "to"
 	pageField         = "page"
 	limitField        = "limit"
+	sortField         = "sort"
+	afterField        = "after"
+	beforeField       = "before"
 	limitDefaultValue = 25
 	pageDefaultValue  = 1
 )
@@ -40,6 +44,7 @@ type VideoBuilder struct {
 	extractor          extractorinterface.RequestParams
 	videoRepository    repositoryinterface.Video
 	resourceRepository repositoryinterface.Resource
+	codecs             detectorinterface.Codecs
 }
 
 // NewVideoBuilder is a constructor of VideoBuilder
@@ -69,12 +74,18 @@ func NewVideoBuilder(serviceContainer diinterface.ServiceContainer) (*VideoBuild
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &VideoBuilder{
 		ctx:                ctx,
 		logger:             loggerService,
 		extractor:          requestParametersExtractor,
 		videoRepository:    videoRepository,
 		resourceRepository: resourceRepository,
+		codecs:             codecsDetector,
 	}, nil
 }
 
@@ -110,6 +121,7 @@ func (b *VideoBuilder) BuildAggFromCreateRequestDTO(req dtointerface.CreateVideo
 			UserID:      req.GetUserID(),
 			Name:        req.GetName(),
 			Description: req.GetDescription(),
+			Duration:    b.duration(resource.Resource),
 		},
 		Resource: resource.Resource,
 		Timestamp: vo.Timestamp{
@@ -172,6 +184,7 @@ func (b *VideoBuilder) BuildAggFromUpdateRequestDTO(req dtointerface.UpdateVideo
 		}
 		if video.Resource.ID.Value != resource.Resource.ID.Value {
 			video.Resource = resource.Resource
+			video.Duration = b.duration(resource.Resource)
 			changes++
 		}
 	}
@@ -248,6 +261,24 @@ func (b *VideoBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.Vid
 			videoDTO.To = parsedTo
 		}
 	}
+	if b.extractor.HasParameter(sortField, r) {
+		if sort, err := b.extractor.GetParameter(sortField, r); err == nil {
+			videoDTO.Sort = sort
+		}
+	}
+	if videoDTO.Sort == "" {
+		videoDTO.Sort = enum.VideoDefaultSort
+	}
+	if b.extractor.HasParameter(afterField, r) {
+		if after, err := b.extractor.GetParameter(afterField, r); err == nil {
+			videoDTO.After = after
+		}
+	}
+	if b.extractor.HasParameter(beforeField, r) {
+		if before, err := b.extractor.GetParameter(beforeField, r); err == nil {
+			videoDTO.Before = before
+		}
+	}
 	if b.extractor.HasParameter(pageField, r) {
 		pg, _ := b.extractor.GetParameter(pageField, r)
 		pgi, atoiErr := strconv.Atoi(pg)
@@ -281,3 +312,14 @@ func (b *VideoBuilder) BuildDeleteRequestDTOFromRequest(r *http.Request) (*dto.V
 
 	return &dto.VideoDeleteRequestDto{ID: videoGetDTO.ID, UserID: videoGetDTO.UserID}, nil
 }
+
+// duration will determine the duration of the resource, the video is built anyway when the resource
+// cannot be probed (the duration is unknown in this case).
+func (b *VideoBuilder) duration(resource entity.Resource) float64 {
+	seconds, err := b.codecs.DetectDuration(resource)
+	if err != nil {
+		b.logger.Log(err)
+		return 0
+	}
+	return seconds
+}
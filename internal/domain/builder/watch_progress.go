@@ -0,0 +1,90 @@
+package builder
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"net/http"
+	"strconv"
+)
+
+const completedField = "completed"
+
+type WatchProgressBuilder struct {
+	logger    loggerinterface.Logger
+	extractor extractorinterface.RequestParams
+}
+
+// NewWatchProgressBuilder is a constructor of WatchProgressBuilder
+func NewWatchProgressBuilder(serviceContainer diinterface.ServiceContainer) (*WatchProgressBuilder, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	requestParametersExtractor, err := serviceContainer.GetRequestParametersExtractorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &WatchProgressBuilder{
+		logger:    loggerService,
+		extractor: requestParametersExtractor,
+	}, nil
+}
+
+// BuildListRequestDTOFromRequest - build a dto.ListWatchProgressRequest from raw *http.Request
+func (b *WatchProgressBuilder) BuildListRequestDTOFromRequest(r *http.Request) (*dto.WatchProgressListRequestDTO, error) {
+	progressDTO := &dto.WatchProgressListRequestDTO{}
+
+	// setting up a user id
+	if userID, ok := r.Context().Value(enum.UserIDContextKey).(vo.ID); ok {
+		progressDTO.UserID = userID
+	}
+
+	if b.extractor.HasParameter(completedField, r) {
+		c, _ := b.extractor.GetParameter(completedField, r)
+		completed, parseErr := strconv.ParseBool(c)
+		if parseErr != nil {
+			return nil, b.logger.LogPropagate(parseErr)
+		}
+		progressDTO.Completed = completed
+	}
+	if b.extractor.HasParameter(pageField, r) {
+		pg, _ := b.extractor.GetParameter(pageField, r)
+		pgi, atoiErr := strconv.Atoi(pg)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		progressDTO.Page = pgi
+	} else {
+		progressDTO.Page = pageDefaultValue
+	}
+	if b.extractor.HasParameter(limitField, r) {
+		l, _ := b.extractor.GetParameter(limitField, r)
+		li, atoiErr := strconv.Atoi(l)
+		if atoiErr != nil {
+			return nil, b.logger.LogPropagate(atoiErr)
+		}
+		progressDTO.Limit = li
+	} else {
+		progressDTO.Limit = limitDefaultValue
+	}
+
+	return progressDTO, nil
+}
+
+// BuildResponseDTO - build a dto.WatchProgressResponseDTO from the progress and the watched video.
+func (b *WatchProgressBuilder) BuildResponseDTO(progress *agg.WatchProgress, video *agg.Video) *dto.WatchProgressResponseDTO {
+	return &dto.WatchProgressResponseDTO{
+		Video:     video.Video,
+		Position:  progress.Position,
+		Duration:  progress.Duration,
+		Completed: progress.Completed,
+		UpdatedAt: progress.Timestamp.UpdatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// EmailVerificationRequestDTO - used when u want to verify the email by token from the letter.
+type EmailVerificationRequestDTO struct {
+	/*Required*/ Token string `json:"token"`
+}
+
+func (req *EmailVerificationRequestDTO) GetToken() string {
+	return req.Token
+}
+
+// EmailVerificationResendRequestDTO - used when u want to receive the verification letter again.
+type EmailVerificationResendRequestDTO struct {
+	/*Required*/ Email string `json:"email"`
+}
+
+func (req *EmailVerificationResendRequestDTO) GetEmail() string {
+	return req.Email
+}
+
+// PasswordResetRequestDTO - used when u want to receive the password reset letter.
+type PasswordResetRequestDTO struct {
+	/*Required*/ Email string `json:"email"`
+}
+
+func (req *PasswordResetRequestDTO) GetEmail() string {
+	return req.Email
+}
+
+// PasswordResetConfirmRequestDTO - used when u want to set up a new password by token from the letter.
+type PasswordResetConfirmRequestDTO struct {
+	/*Required*/ Token string `json:"token"`
+	/*Required*/ Password string `json:"password"`
+}
+
+func (req *PasswordResetConfirmRequestDTO) GetToken() string {
+	return req.Token
+}
+func (req *PasswordResetConfirmRequestDTO) GetPassword() string {
+	return req.Password
+}
+
+// AccountUnlockRequestDTO - used when u want to unlock the account by token from the letter.
+type AccountUnlockRequestDTO struct {
+	/*Required*/ Token string `json:"token"`
+}
+
+func (req *AccountUnlockRequestDTO) GetToken() string {
+	return req.Token
+}
+
+// AdminAccountUnlockRequestDTO - used when an administrator wants to unlock the account and/or the client address.
+type AdminAccountUnlockRequestDTO struct {
+	/*Required*/ Email string `json:"email"`
+	/*Required*/ IP string `json:"ip"`
+	UserID          vo.ID  `json:"-"` // administrator
+}
+
+func (req *AdminAccountUnlockRequestDTO) GetEmail() string {
+	return req.Email
+}
+func (req *AdminAccountUnlockRequestDTO) GetIP() string {
+	return req.IP
+}
+func (req *AdminAccountUnlockRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// ActionTokenGetRequestDTO - used when u want to find a single-use token by hash.
+type ActionTokenGetRequestDTO struct {
+	/*Required*/ Hash string
+	/*Required*/ Action string
+}
+
+func NewActionTokenGetRequestDTO(hash string, action string) *ActionTokenGetRequestDTO {
+	return &ActionTokenGetRequestDTO{
+		Hash:   hash,
+		Action: action,
+	}
+}
+func (req *ActionTokenGetRequestDTO) GetHash() string {
+	return req.Hash
+}
+func (req *ActionTokenGetRequestDTO) GetAction() string {
+	return req.Action
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// ApiKeyCreateRequestDTO - used when u want to issue a new api key.
+type ApiKeyCreateRequestDTO struct {
+	/*Required*/ Name string `json:"name"`
+	/*Required*/ UserID vo.ID
+	/*Required*/ Scopes []string `json:"scopes"`
+	/*Optional*/ ExpiresAt string `json:"expiresAt,omitempty" format:"2006-01-02T15:04:05Z07:00"`
+}
+
+func (req *ApiKeyCreateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *ApiKeyCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *ApiKeyCreateRequestDTO) GetScopes() []string {
+	return req.Scopes
+}
+func (req *ApiKeyCreateRequestDTO) GetExpiresAt() string {
+	return req.ExpiresAt
+}
+
+// ApiKeyGetRequestDTO - used when u want to find a single api key by ID or Hash.
+type ApiKeyGetRequestDTO struct {
+	/*Optional*/ ID vo.ID `json:"id"`
+	/*Optional*/ Hash string
+	/*Optional*/ UserID vo.ID
+}
+
+func NewApiKeyGetRequestDTO(id vo.ID, hash string, userID vo.ID) *ApiKeyGetRequestDTO {
+	return &ApiKeyGetRequestDTO{
+		ID:     id,
+		Hash:   hash,
+		UserID: userID,
+	}
+}
+func (req *ApiKeyGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *ApiKeyGetRequestDTO) GetHash() string {
+	return req.Hash
+}
+func (req *ApiKeyGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// ApiKeyListRequestDTO - used when u want to find all api keys of the user.
+type ApiKeyListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+}
+
+func (req *ApiKeyListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// ApiKeyDeleteRequestDTO - used when u want to revoke the api key.
+type ApiKeyDeleteRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+}
+
+func (req *ApiKeyDeleteRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *ApiKeyDeleteRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+type ApiKeyResponseDTO struct {
+	ID         vo.ID     `json:"id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	// Key is a raw api key, it will be shown only once, right after creation.
+	Key string `json:"key,omitempty"`
+}
@@ -3,6 +3,10 @@ package dto
 type AuthRequestDTO struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	// Code is a one-time password (or recovery code), required when the user has enabled the second factor.
+	Code string `json:"code,omitempty"`
+	// IP is a client address which is used for count failed attempts, it's filled from the request.
+	IP string `json:"-"`
 }
 
 func (r *AuthRequestDTO) GetEmail() string {
@@ -12,3 +16,11 @@ func (r *AuthRequestDTO) GetEmail() string {
 func (r *AuthRequestDTO) GetPassword() string {
 	return r.Password
 }
+
+func (r *AuthRequestDTO) GetCode() string {
+	return r.Code
+}
+
+func (r *AuthRequestDTO) GetIP() string {
+	return r.IP
+}
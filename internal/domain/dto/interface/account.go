@@ -0,0 +1,30 @@
+package dtointerface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type VerifyEmailRequest interface {
+	GetToken() string
+}
+
+type ResendEmailVerificationRequest interface {
+	GetEmail() string
+}
+
+type ResetPasswordRequest interface {
+	GetEmail() string
+}
+
+type ConfirmPasswordResetRequest interface {
+	GetToken() string
+	GetPassword() string
+}
+
+type UnlockAccountRequest interface {
+	GetToken() string
+}
+
+type AdminUnlockAccountRequest interface {
+	GetEmail() string
+	GetIP() string
+	GetUserID() vo.ID
+}
@@ -0,0 +1,21 @@
+package dtointerface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type CreateApiKeyRequest interface {
+	GetName() string
+	GetUserID() vo.ID
+	GetScopes() []string
+	GetExpiresAt() string
+}
+
+type GetApiKeyRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type ListApiKeyRequest interface {
+	GetUserID() vo.ID
+}
+
+type DeleteApiKeyRequest GetApiKeyRequest
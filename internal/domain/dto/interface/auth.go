@@ -3,4 +3,6 @@ package dtointerface
 type AuthRequest interface {
 	GetEmail() string
 	GetPassword() string
+	GetCode() string
+	GetIP() string
 }
@@ -0,0 +1,28 @@
+package dtointerface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type CreatePlaylistRequest interface {
+	GetName() string
+	GetUserID() vo.ID
+	GetVideoIDs() []vo.ID
+}
+
+type UpdatePlaylistRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+	GetName() string
+	GetVideoIDs() []vo.ID
+}
+
+type GetPlaylistRequest interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type ListPlaylistRequest interface {
+	GetUserID() vo.ID
+	PaginatedRequest
+}
+
+type DeletePlaylistRequest GetPlaylistRequest
@@ -0,0 +1,18 @@
+package dtointerface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type GetTotpRequest interface {
+	GetUserID() vo.ID
+}
+
+type EnrollTotpRequest interface {
+	GetUserID() vo.ID
+}
+
+type ConfirmTotpRequest interface {
+	GetUserID() vo.ID
+	GetCode() string
+}
+
+type DisableTotpRequest = ConfirmTotpRequest
@@ -30,6 +30,9 @@ type ListVideoRequest interface {
 	GetCreatedAt() time.Time // concrete search date point
 	GetFrom() time.Time      // search date limit from
 	GetTo() time.Time        // search date limit to
+	GetSort() string         // sort fields separated by comma, "-" prefix means descending order
+	GetAfter() string        // cursor of the last item of the previous page
+	GetBefore() string       // cursor of the first item of the next page
 	PaginatedRequest
 }
 
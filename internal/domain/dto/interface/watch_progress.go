@@ -0,0 +1,9 @@
+package dtointerface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type ListWatchProgressRequest interface {
+	GetUserID() vo.ID
+	GetCompleted() bool
+	PaginatedRequest
+}
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// PlaylistCreateRequestDTO - used when u want to create a new one playlist.
+type PlaylistCreateRequestDTO struct {
+	/*Required*/ Name string `json:"name"`
+	/*Required*/ UserID vo.ID
+	/*Optional*/ VideoIDs []vo.ID `json:"videoIDs"`
+}
+
+func (req *PlaylistCreateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *PlaylistCreateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *PlaylistCreateRequestDTO) GetVideoIDs() []vo.ID {
+	return req.VideoIDs
+}
+
+// PlaylistUpdateRequestDTO - used when u want to rename the playlist or replace its videos (omitted fields
+// are not changed, the empty list of videos clears the playlist).
+type PlaylistUpdateRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Name string `json:"name"`
+	/*Optional*/ VideoIDs []vo.ID `json:"videoIDs"`
+}
+
+func (req *PlaylistUpdateRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *PlaylistUpdateRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *PlaylistUpdateRequestDTO) GetName() string {
+	return req.Name
+}
+func (req *PlaylistUpdateRequestDTO) GetVideoIDs() []vo.ID {
+	return req.VideoIDs
+}
+
+// PlaylistGetRequestDTO - used when u want to find a single playlist of the user by ID.
+type PlaylistGetRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+}
+
+func NewPlaylistGetRequestDTO(id vo.ID, userID vo.ID) *PlaylistGetRequestDTO {
+	return &PlaylistGetRequestDTO{
+		ID:     id,
+		UserID: userID,
+	}
+}
+func (req *PlaylistGetRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *PlaylistGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// PlaylistListRequestDTO - used when u want to find a collection of playlists of the user.
+type PlaylistListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Optional*/ PaginationRequestDTO
+}
+
+func (req *PlaylistListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// PlaylistDeleteRequestDTO - used when u want to remove the playlist.
+type PlaylistDeleteRequestDTO struct {
+	/*Required*/ ID vo.ID `json:"id"`
+	/*Required*/ UserID vo.ID
+}
+
+func (req *PlaylistDeleteRequestDTO) GetID() vo.ID {
+	return req.ID
+}
+func (req *PlaylistDeleteRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// TotpGetRequestDTO - used when u want to find the second factor state of the user.
+type TotpGetRequestDTO struct {
+	/*Required*/ UserID vo.ID
+}
+
+func NewTotpGetRequestDTO(userID vo.ID) *TotpGetRequestDTO {
+	return &TotpGetRequestDTO{UserID: userID}
+}
+func (req *TotpGetRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// TotpEnrollRequestDTO - used when u want to start enrolment of the second factor.
+type TotpEnrollRequestDTO struct {
+	/*Required*/ UserID vo.ID
+}
+
+func (req *TotpEnrollRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+
+// TotpConfirmRequestDTO - used when u want to confirm enrolment of the second factor by the first code.
+type TotpConfirmRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Required*/ Code string `json:"code"`
+}
+
+func (req *TotpConfirmRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *TotpConfirmRequestDTO) GetCode() string {
+	return req.Code
+}
+
+// TotpDisableRequestDTO - used when u want to disable the second factor (code or recovery code is required).
+type TotpDisableRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Required*/ Code string `json:"code"`
+}
+
+func (req *TotpDisableRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *TotpDisableRequestDTO) GetCode() string {
+	return req.Code
+}
+
+type TotpEnrollmentResponseDTO struct {
+	// Secret is a base32 encoded secret for manual input into the authenticator app.
+	Secret string `json:"secret"`
+	// URI is an otpauth:// provisioning URI (commonly rendered as QR code).
+	URI string `json:"uri"`
+}
+
+type TotpRecoveryCodesResponseDTO struct {
+	// RecoveryCodes is a list of single-use codes, they will be shown only once, right after confirmation.
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
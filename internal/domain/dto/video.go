@@ -87,6 +87,9 @@ type VideoListRequestDTO struct {
 	/*Optional*/ CreatedAt time.Time `json:"createdAt" format:"2006-01-02T15:04:05Z07:00"`
 	/*Optional*/ From time.Time `json:"from" format:"2006-01-02T15:04:05Z07:00"`
 	/*Optional*/ To time.Time `json:"to" format:"2006-01-02T15:04:05Z07:00"`
+	/*Optional*/ Sort string `json:"sort"` // example: "name,-createdAt"
+	/*Optional*/ After string `json:"after"` // cursor of the last item of the previous page
+	/*Optional*/ Before string `json:"before"` // cursor of the first item of the next page
 	/*Optional*/ PaginationRequestDTO
 }
 
@@ -122,6 +125,15 @@ func (req *VideoListRequestDTO) GetFrom() time.Time {
 func (req *VideoListRequestDTO) GetTo() time.Time {
 	return req.To
 }
+func (req *VideoListRequestDTO) GetSort() string {
+	return req.Sort
+}
+func (req *VideoListRequestDTO) GetAfter() string {
+	return req.After
+}
+func (req *VideoListRequestDTO) GetBefore() string {
+	return req.Before
+}
 
 // VideoDeleteRequestDto - used when you want to remove the video.
 type VideoDeleteRequestDto struct {
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// WatchProgressListRequestDTO - used when u want to find the unfinished (or completed) videos of the user.
+type WatchProgressListRequestDTO struct {
+	/*Required*/ UserID vo.ID
+	/*Optional*/ Completed bool `json:"completed"`
+	/*Optional*/ PaginationRequestDTO
+}
+
+func (req *WatchProgressListRequestDTO) GetUserID() vo.ID {
+	return req.UserID
+}
+func (req *WatchProgressListRequestDTO) GetCompleted() bool {
+	return req.Completed
+}
+
+type WatchProgressResponseDTO struct {
+	Video     entity.Video `json:"video"`
+	Position  float64      `json:"position"`
+	Duration  float64      `json:"duration"`
+	Completed bool         `json:"completed"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
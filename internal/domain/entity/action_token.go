@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// ActionToken is a single-use expiring token which is sent to the user by email for confirm an action.
+type ActionToken struct {
+	ID        vo.ID     `json:"-" bson:",inline"`
+	UserID    vo.ID     `json:"userID" bson:"user"`
+	Action    string    `json:"action" bson:"action"`
+	Hash      string    `json:"-" bson:"hash"` // sha256 of raw token, unique key
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
+
+func (r ActionToken) GetID() vo.ID {
+	return r.ID
+}
+func (r ActionToken) GetUserID() vo.ID {
+	return r.UserID
+}
+func (r ActionToken) GetAction() string {
+	return r.Action
+}
+
+// IsExpired checks whether the token is expired (the storage removes expired tokens with a delay).
+func (r ActionToken) IsExpired() bool {
+	return r.ExpiresAt.Before(time.Now())
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type ApiKey struct {
+	ID         vo.ID     `json:"id" bson:",inline"`
+	UserID     vo.ID     `json:"userID" bson:"user"`
+	Name       string    `json:"name" bson:"name"`
+	Prefix     string    `json:"prefix" bson:"prefix"` // first chars of raw key, helps a user to recognize the key
+	Hash       string    `json:"-" bson:"hash"`        // sha256 of raw key, unique key
+	Scopes     []string  `json:"scopes" bson:"scopes"`
+	ExpiresAt  time.Time `json:"expiresAt" bson:"expiresAt,omitempty"`
+	LastUsedAt time.Time `json:"lastUsedAt" bson:"lastUsedAt,omitempty"`
+}
+
+func (r ApiKey) GetID() vo.ID {
+	return r.ID
+}
+func (r ApiKey) GetUserID() vo.ID {
+	return r.UserID
+}
+
+// HasScope checks whether the key was issued with given scope.
+func (r ApiKey) HasScope(scope string) bool {
+	for _, s := range r.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired checks whether the key is expired (a key without expiration date is never expired).
+func (r ApiKey) IsExpired() bool {
+	return !r.ExpiresAt.IsZero() && r.ExpiresAt.Before(time.Now())
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// AuthAttempt is a counter of failed authorization attempts by key (an account or a client address).
+type AuthAttempt struct {
+	ID           vo.ID     `json:"-" bson:",inline"`
+	Key          string    `json:"key" bson:"key"`
+	Failures     int       `json:"failures" bson:"failures"`
+	BlockedUntil time.Time `json:"blockedUntil" bson:"blockedUntil"` // exponential backoff, next attempt is rejected before
+	LockedUntil  time.Time `json:"lockedUntil" bson:"lockedUntil"`   // lockout, may be dropped earlier by email or an admin
+	ExpiresAt    time.Time `json:"expiresAt" bson:"expiresAt"`       // counter is forgotten after this time
+}
+
+func (a AuthAttempt) GetID() vo.ID {
+	return a.ID
+}
+func (a AuthAttempt) GetKey() string {
+	return a.Key
+}
+
+// IsLocked checks whether the key is locked out at given time.
+func (a AuthAttempt) IsLocked(at time.Time) bool {
+	return a.LockedUntil.After(at)
+}
+
+// RetryAfter returns a duration which must pass before the next attempt will be accepted (zero means now).
+func (a AuthAttempt) RetryAfter(at time.Time) time.Duration {
+	until := a.BlockedUntil
+	if a.LockedUntil.After(until) {
+		until = a.LockedUntil
+	}
+	if until.After(at) {
+		return until.Sub(at)
+	}
+	return 0
+}
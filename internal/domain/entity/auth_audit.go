@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// AuthAudit is an audit entry of authorization event (success, failure, lockout or unlock).
+type AuthAudit struct {
+	ID     vo.ID  `json:"id" bson:",inline"`
+	UserID vo.ID  `json:"userID" bson:"user"` // empty when the user is unknown
+	Email  string `json:"email,omitempty" bson:"email,omitempty"`
+	IP     string `json:"ip,omitempty" bson:"ip,omitempty"`
+	Event  string `json:"event" bson:"event"`
+	Reason string `json:"reason,omitempty" bson:"reason,omitempty"`
+	// ExpiresAt is a time when the entry will be removed by storage.
+	ExpiresAt time.Time `json:"-" bson:"expiresAt"`
+}
+
+func (a AuthAudit) GetID() vo.ID {
+	return a.ID
+}
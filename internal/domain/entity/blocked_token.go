@@ -8,9 +8,12 @@ import (
 type BlockedToken struct {
 	ID        vo.ID     `json:"id,omitempty" bson:"_id,omitempty,inline"`
 	UserID    vo.ID     `bson:"user"`
-	Value     string    `bson:"value"`
+	Value     string    `bson:"value"` // sha256 of raw token (empty for revocation of all tokens of the user)
 	Reason    string    `bson:"reason"`
 	BlockedAt time.Time `bson:"blockedAt"`
+	// ExpiresAt is an expiration time of the original token, after it the record is removed by storage
+	// because the token will not be accepted anyway.
+	ExpiresAt time.Time `bson:"expiresAt"`
 }
 
 func (r BlockedToken) GetID() vo.ID {
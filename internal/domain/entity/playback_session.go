@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+// PlaybackSession is a stream of the video by the user which may be resumed from the last position after reconnect.
+type PlaybackSession struct {
+	ID        vo.ID     `json:"id" bson:",inline"`
+	UserID    vo.ID     `json:"userID" bson:"user"`
+	OwnerID   vo.ID     `json:"ownerID" bson:"owner"` // owner of the video (the host, when a party member streams it)
+	VideoID   vo.ID     `json:"videoID" bson:"video"`
+	Position  float64   `json:"position" bson:"position"`   // last acknowledged playhead in seconds
+	Offset    int64     `json:"offset" bson:"offset"`       // byte offset of the position into the resource file
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"` // session cannot be resumed after this time
+}
+
+func (s PlaybackSession) GetID() vo.ID {
+	return s.ID
+}
+func (s PlaybackSession) GetUserID() vo.ID {
+	return s.UserID
+}
+
+// GetOwnerID returns the owner of the video, the sessions which were started before the owner
+// was stored are owned by the user.
+func (s PlaybackSession) GetOwnerID() vo.ID {
+	if s.OwnerID.Value.IsZero() {
+		return s.UserID
+	}
+	return s.OwnerID
+}
+func (s PlaybackSession) GetVideoID() vo.ID {
+	return s.VideoID
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// Playlist is an ordered list of the videos of the user which are streamed back to back.
+type Playlist struct {
+	ID       vo.ID   `json:"id" bson:",inline"`
+	UserID   vo.ID   `json:"userID" bson:"user"`
+	Name     string  `json:"name" bson:"name"`
+	VideoIDs []vo.ID `json:"videoIDs" bson:"videos"` // ordered video identifiers
+}
+
+func (p Playlist) GetID() vo.ID {
+	return p.ID
+}
+func (p Playlist) GetUserID() vo.ID {
+	return p.UserID
+}
+func (p Playlist) GetName() string {
+	return p.Name
+}
+func (p Playlist) GetVideoIDs() []vo.ID {
+	return p.VideoIDs
+}
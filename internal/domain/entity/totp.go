@@ -0,0 +1,25 @@
+package entity
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// Totp is a state of the user's time-based one-time password second factor (RFC 6238).
+type Totp struct {
+	ID            vo.ID    `json:"-" bson:",inline"`
+	UserID        vo.ID    `json:"userID" bson:"user"`               // unique key
+	Secret        string   `json:"-" bson:"secret,omitempty"`        // base32, present when enrolment confirmed
+	PendingSecret string   `json:"-" bson:"pendingSecret,omitempty"` // base32, waiting for enrolment confirmation
+	RecoveryCodes []string `json:"-" bson:"recoveryCodes,omitempty"` // sha256 of unused recovery codes
+	LastUsedStep  int64    `json:"-" bson:"lastUsedStep,omitempty"`  // protects against replaying of the code
+}
+
+func (r Totp) GetID() vo.ID {
+	return r.ID
+}
+func (r Totp) GetUserID() vo.ID {
+	return r.UserID
+}
+
+// IsEnabled checks whether the enrolment was confirmed and the second factor is required.
+func (r Totp) IsEnabled() bool {
+	return r.Secret != ""
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"time"
 )
@@ -11,6 +12,7 @@ type User struct {
 	Password string    `bson:"password"` // hash
 	Email    string    `bson:"email"`    // unique key
 	Birthday time.Time `bson:"birthday,omitempty"`
+	Status   string    `bson:"status,omitempty"` // empty means active (accounts created before verification)
 }
 
 func (r *User) GetID() vo.ID {
@@ -22,3 +24,8 @@ func (r *User) GetPassword() string {
 func (r *User) SetPassword(password string) {
 	r.Password = password
 }
+
+// IsActive checks whether the user's email was verified.
+func (r *User) IsActive() bool {
+	return r.Status != enum.UserPendingStatus
+}
@@ -3,10 +3,11 @@ package entity
 import "github.com/Borislavv/video-streaming/internal/domain/vo"
 
 type Video struct {
-	ID          vo.ID  `json:"id" bson:",inline"`
-	UserID      vo.ID  `json:"userID" bson:"user"`
-	Name        string `json:"name" bson:"name"`
-	Description string `json:"description" bson:"description,omitempty"`
+	ID          vo.ID   `json:"id" bson:",inline"`
+	UserID      vo.ID   `json:"userID" bson:"user"`
+	Name        string  `json:"name" bson:"name"`
+	Description string  `json:"description" bson:"description,omitempty"`
+	Duration    float64 `json:"duration" bson:"duration"` // approximate duration in seconds (zero when it's unknown)
 }
 
 func (r Video) GetID() vo.ID {
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// WatchProgress is the last watched position of the video by the user (single per user and video).
+type WatchProgress struct {
+	ID        vo.ID   `json:"id" bson:",inline"`
+	UserID    vo.ID   `json:"userID" bson:"user"`
+	VideoID   vo.ID   `json:"videoID" bson:"video"`
+	Position  float64 `json:"position" bson:"position"`   // last acknowledged playhead in seconds
+	Duration  float64 `json:"duration" bson:"duration"`   // approximate duration of the video in seconds
+	Completed bool    `json:"completed" bson:"completed"` // the video was watched till the end
+}
+
+func (p WatchProgress) GetID() vo.ID {
+	return p.ID
+}
+func (p WatchProgress) GetUserID() vo.ID {
+	return p.UserID
+}
+func (p WatchProgress) GetVideoID() vo.ID {
+	return p.VideoID
+}
@@ -0,0 +1,15 @@
+package enum
+
+const (
+	// UserPendingStatus means that user's email was not verified yet and the user cannot be authorized.
+	UserPendingStatus = "pending"
+	// UserActiveStatus means that user's email was verified (users without status are active as well).
+	UserActiveStatus = "active"
+)
+
+// Actions of single-use tokens which are sent to the user by email.
+const (
+	EmailVerificationAction = "email_verification"
+	PasswordResetAction     = "password_reset"
+	AccountUnlockAction     = "account_unlock"
+)
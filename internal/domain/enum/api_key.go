@@ -0,0 +1,16 @@
+package enum
+
+const (
+	// AuthorizationHeaderKey is a header which used by machine clients for pass an api key.
+	AuthorizationHeaderKey = "Authorization"
+	// ApiKeyAuthorizationScheme is a scheme of authorization header value, example: "ApiKey {{key}}".
+	ApiKeyAuthorizationScheme = "ApiKey"
+)
+
+const (
+	ApiKeyReadScope   = "read"
+	ApiKeyUploadScope = "upload"
+	ApiKeyDeleteScope = "delete"
+)
+
+var ApiKeyScopes = []string{ApiKeyReadScope, ApiKeyUploadScope, ApiKeyDeleteScope}
@@ -0,0 +1,15 @@
+package enum
+
+// Prefixes of authorization attempts counters keys.
+const (
+	AuthAttemptAccountKeyPrefix = "account:"
+	AuthAttemptIPKeyPrefix      = "ip:"
+)
+
+// Events of authorization audit entries.
+const (
+	AuthSucceededEvent = "auth_succeeded"
+	AuthFailedEvent    = "auth_failed"
+	AuthLockedEvent    = "locked"
+	AuthUnlockedEvent  = "unlocked"
+)
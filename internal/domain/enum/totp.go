@@ -0,0 +1,16 @@
+package enum
+
+// Authentication method references which are stored into the 'amr' claim of access token (RFC 8176).
+const (
+	// AmrPassword means that user was authenticated by password.
+	AmrPassword = "pwd"
+	// AmrOtp means that user was passed the second factor by one-time password (or recovery code).
+	AmrOtp = "otp"
+)
+
+const (
+	// TotpDigits is a number of digits of the one-time password.
+	TotpDigits = 6
+	// TotpPeriod is a number of seconds while the one-time password is valid.
+	TotpPeriod = 30
+)
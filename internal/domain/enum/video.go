@@ -0,0 +1,19 @@
+package enum
+
+const (
+	VideoSortByCreatedAt = "createdAt"
+	VideoSortByName      = "name"
+	VideoSortByDuration  = "duration"
+	VideoSortBySize      = "size"
+)
+
+var VideoSortFields = []string{VideoSortByCreatedAt, VideoSortByName, VideoSortByDuration, VideoSortBySize}
+
+const (
+	// SortDescendingPrefix is a prefix of the sort field which means the descending order, example: "-createdAt".
+	SortDescendingPrefix = "-"
+	// SortFieldsSeparator is a separator of the sort fields, example: "name,-createdAt".
+	SortFieldsSeparator = ","
+	// VideoDefaultSort is a sort of the videos list when it's not passed (the most recent first).
+	VideoDefaultSort = SortDescendingPrefix + VideoSortByCreatedAt
+)
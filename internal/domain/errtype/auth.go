@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
 	"net/http"
+	"time"
 )
 
 const (
 	authErrType           = "authorization"
+	secondFactorErrType   = "second_factor"
 	internalAuthErrLevel  = logger.CriticalLevel
 	internalAuthErrStatus = http.StatusInternalServerError
 	publicAuthErrLevel    = logger.InfoLevel
@@ -36,6 +38,11 @@ func NewAuthFailedError(msg string) *AuthFailedError {
 	}
 }
 
+func IsAuthFailedError(err error) bool {
+	_, ok := err.(*AuthFailedError)
+	return ok
+}
+
 type AccessTokenIsEmptyOrOmittedError struct{ publicError }
 
 func NewAccessTokenIsEmptyOrOmittedError() *AccessTokenIsEmptyOrOmittedError {
@@ -140,3 +147,198 @@ func NewTokenIssuerWasNotMatchedInternalError(token string) *TokenIssuerWasNotMa
 		},
 	}
 }
+
+type ApiKeyIsInvalidError struct{ publicError }
+
+func NewApiKeyIsInvalidError() *ApiKeyIsInvalidError {
+	return &ApiKeyIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: provided api key is invalid",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type ApiKeyWasExpiredError struct{ publicError }
+
+func NewApiKeyWasExpiredError() *ApiKeyWasExpiredError {
+	return &ApiKeyWasExpiredError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: api key was expired",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type SecondFactorIsRequiredError struct{ publicError }
+
+// NewSecondFactorIsRequiredError is returned with 401 status, thus a client is able to determine
+// that the one-time password must be requested from the user and the authorization request repeated.
+func NewSecondFactorIsRequiredError() *SecondFactorIsRequiredError {
+	return &SecondFactorIsRequiredError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: second factor is required, provide the one-time password as 'code'",
+				ErrorType:    secondFactorErrType,
+				errorStatus:  http.StatusUnauthorized,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type SecondFactorCodeIsInvalidError struct{ publicError }
+
+func NewSecondFactorCodeIsInvalidError() *SecondFactorCodeIsInvalidError {
+	return &SecondFactorCodeIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: provided one-time password or recovery code is invalid",
+				ErrorType:    secondFactorErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+func IsSecondFactorCodeIsInvalidError(err error) bool {
+	_, ok := err.(*SecondFactorCodeIsInvalidError)
+	return ok
+}
+
+type RecentSecondFactorIsRequiredError struct{ publicError }
+
+func NewRecentSecondFactorIsRequiredError() *RecentSecondFactorIsRequiredError {
+	return &RecentSecondFactorIsRequiredError{
+		publicError{
+			errored{
+				ErrorMessage: "access denied: operation requires recently passed second factor, authorize with code again",
+				ErrorType:    secondFactorErrType,
+				errorStatus:  http.StatusForbidden,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TotpIsAlreadyEnabledError struct{ publicError }
+
+func NewTotpIsAlreadyEnabledError() *TotpIsAlreadyEnabledError {
+	return &TotpIsAlreadyEnabledError{
+		publicError{
+			errored{
+				ErrorMessage: "second factor is already enabled, disable it first",
+				ErrorType:    secondFactorErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TotpIsNotEnabledError struct{ publicError }
+
+func NewTotpIsNotEnabledError() *TotpIsNotEnabledError {
+	return &TotpIsNotEnabledError{
+		publicError{
+			errored{
+				ErrorMessage: "second factor is not enabled",
+				ErrorType:    secondFactorErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TotpEnrolmentWasNotStartedError struct{ publicError }
+
+func NewTotpEnrolmentWasNotStartedError() *TotpEnrolmentWasNotStartedError {
+	return &TotpEnrolmentWasNotStartedError{
+		publicError{
+			errored{
+				ErrorMessage: "second factor enrolment was not started",
+				ErrorType:    secondFactorErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type EmailIsNotVerifiedError struct{ publicError }
+
+func NewEmailIsNotVerifiedError() *EmailIsNotVerifiedError {
+	return &EmailIsNotVerifiedError{
+		publicError{
+			errored{
+				ErrorMessage: "authorization failed: email is not verified, follow the link from the verification letter",
+				ErrorType:    authErrType,
+				errorStatus:  http.StatusForbidden,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type ActionTokenIsInvalidError struct{ publicError }
+
+func NewActionTokenIsInvalidError() *ActionTokenIsInvalidError {
+	return &ActionTokenIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: "provided token is invalid, expired or was already used",
+				ErrorType:    authErrType,
+				errorStatus:  publicAuthErrStatus,
+				errorLevel:   publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type TooManyAuthAttemptsError struct{ publicError }
+
+// NewTooManyAuthAttemptsError is returned with 429 status while the backoff after failed attempts is not passed.
+func NewTooManyAuthAttemptsError(retryAfter time.Duration) *TooManyAuthAttemptsError {
+	return &TooManyAuthAttemptsError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf(
+					"authorization failed: too many failed attempts, retry after %v", retryAfter.Round(time.Second),
+				),
+				ErrorType:   authErrType,
+				errorStatus: http.StatusTooManyRequests,
+				errorLevel:  publicAuthErrLevel,
+			},
+		},
+	}
+}
+
+type AccountIsLockedError struct{ publicError }
+
+// NewAccountIsLockedError is returned with 423 status while the account is locked out after failed attempts.
+func NewAccountIsLockedError(retryAfter time.Duration) *AccountIsLockedError {
+	return &AccountIsLockedError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf(
+					"authorization failed: account is locked due to too many failed attempts for %v, "+
+						"follow the link from the letter or contact the administrator for unlock it",
+					retryAfter.Round(time.Second),
+				),
+				ErrorType:   authErrType,
+				errorStatus: http.StatusLocked,
+				errorLevel:  publicAuthErrLevel,
+			},
+		},
+	}
+}
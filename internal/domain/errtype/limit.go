@@ -0,0 +1,66 @@
+package errtype
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"net/http"
+)
+
+const (
+	limitErrType        = "limit"
+	publicLimitErrLevel = logger.InfoLevel
+)
+
+type ServerIsOverloadedError struct{ publicError }
+
+// NewServerIsOverloadedError is returned with 503 status while the global limit of connections is reached.
+func NewServerIsOverloadedError() *ServerIsOverloadedError {
+	return &ServerIsOverloadedError{
+		publicError{
+			errored{
+				ErrorMessage: "server is overloaded, try again later",
+				ErrorType:    limitErrType,
+				errorStatus:  http.StatusServiceUnavailable,
+				errorLevel:   publicLimitErrLevel,
+			},
+		},
+	}
+}
+
+func IsServerIsOverloadedError(err error) bool {
+	_, ok := err.(*ServerIsOverloadedError)
+	return ok
+}
+
+type TooManyConnectionsError struct{ publicError }
+
+// NewTooManyConnectionsError is returned with 429 status while the limit of connections by the client address
+// or by the user is reached (the 'by' is a name of the limit).
+func NewTooManyConnectionsError(by string, limit int) *TooManyConnectionsError {
+	return &TooManyConnectionsError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("too many connections by %v, max. %d are allowed", by, limit),
+				ErrorType:    limitErrType,
+				errorStatus:  http.StatusTooManyRequests,
+				errorLevel:   publicLimitErrLevel,
+			},
+		},
+	}
+}
+
+type TooManyStreamsError struct{ publicError }
+
+// NewTooManyStreamsError is returned with 429 status while the limit of concurrent streams by the user is reached.
+func NewTooManyStreamsError(limit int) *TooManyStreamsError {
+	return &TooManyStreamsError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("too many concurrent streams, max. %d are allowed", limit),
+				ErrorType:    limitErrType,
+				errorStatus:  http.StatusTooManyRequests,
+				errorLevel:   publicLimitErrLevel,
+			},
+		},
+	}
+}
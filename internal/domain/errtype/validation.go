@@ -258,3 +258,83 @@ func NewTimeParsingValidationError(date string) *TimeParsingValidationError {
 		},
 	}
 }
+
+type FieldValueIsNotAllowedError struct{ publicError }
+
+func NewFieldValueIsNotAllowedError(field string, value string, allowed ...string) *FieldValueIsNotAllowedError {
+	return &FieldValueIsNotAllowedError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf(
+					"value '%v' of the field '%v' is not allowed, allowed values: [%v]",
+					value, field, strings.Join(allowed, ", "),
+				),
+				ErrorType:   validationType,
+				errorLevel:  publicValidationLevel,
+				errorStatus: publicValidationStatus,
+			},
+		},
+	}
+}
+
+type FieldMustBeInFutureError struct{ publicError }
+
+func NewFieldMustBeInFutureError(field string) *FieldMustBeInFutureError {
+	return &FieldMustBeInFutureError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("date of the field '%v' must be in the future", field),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type CursorIsInvalidError struct{ publicError }
+
+func NewCursorIsInvalidError(field string) *CursorIsInvalidError {
+	return &CursorIsInvalidError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("cursor of the field '%v' is invalid or it was made for another sort", field),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type FieldsCannotBePassedTogetherError struct{ publicError }
+
+func NewFieldsCannotBePassedTogetherError(fields ...string) *FieldsCannotBePassedTogetherError {
+	return &FieldsCannotBePassedTogetherError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("fields '%v' cannot be passed together", strings.Join(fields, "', '")),
+				ErrorType:    validationType,
+				errorLevel:   publicValidationLevel,
+				errorStatus:  publicValidationStatus,
+			},
+		},
+	}
+}
+
+type PlaylistHasNoPlayableItemsError struct{ publicError }
+
+// NewPlaylistHasNoPlayableItemsError is returned when a whole cycle of the playlist queue has not a playable item
+// (the videos were removed or their files are broken), thus the repeating of it would never end.
+func NewPlaylistHasNoPlayableItemsError(name string) *PlaylistHasNoPlayableItemsError {
+	return &PlaylistHasNoPlayableItemsError{
+		publicError{
+			errored{
+				ErrorMessage: fmt.Sprintf("playlist '%v' has no playable items", name),
+				ErrorType:    validationType,
+				errorStatus:  publicValidationStatus,
+				errorLevel:   publicValidationLevel,
+			},
+		},
+	}
+}
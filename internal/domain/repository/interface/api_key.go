@@ -0,0 +1,19 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type ApiKey interface {
+	FindOneByID(ctx context.Context, q queryinterface.FindOneApiKeyByID) (*agg.ApiKey, error)
+	FindOneByHash(ctx context.Context, q queryinterface.FindOneApiKeyByHash) (*agg.ApiKey, error)
+	FindList(ctx context.Context, q queryinterface.FindApiKeyList) (list []*agg.ApiKey, err error)
+	Insert(ctx context.Context, key *agg.ApiKey) (*agg.ApiKey, error)
+	Touch(ctx context.Context, key *agg.ApiKey) error
+	Remove(ctx context.Context, key *agg.ApiKey) error
+	// RemoveByUser will remove all keys of the user.
+	RemoveByUser(ctx context.Context, userID vo.ID) error
+}
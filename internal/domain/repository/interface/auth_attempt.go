@@ -0,0 +1,16 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"time"
+)
+
+type AuthAttempt interface {
+	FindOneByKey(ctx context.Context, key string) (*agg.AuthAttempt, error)
+	// IncrementFailures will count a failed attempt by key (the counter is created if it does not exist yet
+	// or was expired) and prolong the counter lifetime at least until given time.
+	IncrementFailures(ctx context.Context, key string, expiresAt time.Time) (*agg.AuthAttempt, error)
+	Update(ctx context.Context, attempt *agg.AuthAttempt) error
+	RemoveByKey(ctx context.Context, key string) error
+}
@@ -0,0 +1,10 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+)
+
+type AuthAudit interface {
+	Insert(ctx context.Context, audit *agg.AuthAudit) (*agg.AuthAudit, error)
+}
@@ -3,9 +3,14 @@ package repositoryinterface
 import (
 	"context"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
 )
 
 type BlockedToken interface {
 	Insert(ctx context.Context, token *agg.BlockedToken) error
-	Has(ctx context.Context, token string) (found bool, err error)
+	// Has checks whether the token is blocked by sha256 hash of the one.
+	Has(ctx context.Context, hash string) (found bool, err error)
+	// HasRevocation checks whether all tokens of the user which were issued before given time are revoked.
+	HasRevocation(ctx context.Context, userID vo.ID, issuedAt time.Time) (found bool, err error)
 }
@@ -0,0 +1,16 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
+)
+
+type PlaybackSession interface {
+	Insert(ctx context.Context, session *agg.PlaybackSession) (*agg.PlaybackSession, error)
+	// FindOneByID returns the unexpired session of the user.
+	FindOneByID(ctx context.Context, id vo.ID, userID vo.ID) (*agg.PlaybackSession, error)
+	// UpdatePosition will store the position of the session and prolong its lifetime until given time.
+	UpdatePosition(ctx context.Context, id vo.ID, position float64, offset int64, expiresAt time.Time) error
+}
@@ -0,0 +1,15 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Playlist interface {
+	FindOneByID(ctx context.Context, q queryinterface.FindOnePlaylistByID) (*agg.Playlist, error)
+	FindList(ctx context.Context, q queryinterface.FindPlaylistList) (list []*agg.Playlist, total int64, err error)
+	Insert(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error)
+	Update(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error)
+	Remove(ctx context.Context, playlist *agg.Playlist) error
+}
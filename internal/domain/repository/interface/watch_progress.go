@@ -0,0 +1,17 @@
+package repositoryinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type WatchProgress interface {
+	// FindOneByVideo returns the progress of the video by the user.
+	FindOneByVideo(ctx context.Context, userID vo.ID, videoID vo.ID) (*agg.WatchProgress, error)
+	// FindList returns the progress list of the user ordered by the last update (most recent first).
+	FindList(ctx context.Context, q queryinterface.FindWatchProgressList) (list []*agg.WatchProgress, err error)
+	// Save will create or update the progress of the video by the user.
+	Save(ctx context.Context, progress *agg.WatchProgress) error
+}
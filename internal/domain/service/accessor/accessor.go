@@ -8,7 +8,9 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"reflect"
+	"strings"
 )
 
 type AggregateAccessType int
@@ -26,6 +28,8 @@ type AccessService struct {
 	logger                    loggerinterface.Logger
 	handlers                  map[AggregateAccessType]AggregateAccessHandler
 	isAppropriateHandlerFuncs map[AggregateAccessType]AggregateAccessIsAppropriateHandler
+	// admins is a set of users which have access to the administration endpoints.
+	admins map[vo.ID]struct{}
 }
 
 func NewAccessService(serviceContainer diinterface.ServiceContainer) (*AccessService, error) {
@@ -34,13 +38,39 @@ func NewAccessService(serviceContainer diinterface.ServiceContainer) (*AccessSer
 		return nil, err
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	admins := map[vo.ID]struct{}{}
+	for _, hex := range strings.Split(cfg.AdminUserIDs, ",") {
+		if hex = strings.TrimSpace(hex); hex == "" {
+			continue
+		}
+		oid, perr := primitive.ObjectIDFromHex(hex)
+		if perr != nil {
+			return nil, loggerService.LogPropagate(fmt.Errorf("invalid admin user id '%v': %w", hex, perr))
+		}
+		admins[vo.NewID(oid)] = struct{}{}
+	}
+
 	return (&AccessService{
 		logger:                    loggerService,
 		handlers:                  map[AggregateAccessType]AggregateAccessHandler{},
 		isAppropriateHandlerFuncs: map[AggregateAccessType]AggregateAccessIsAppropriateHandler{},
+		admins:                    admins,
 	}).setHandlers(), nil
 }
 
+// IsAdmin is a method which will check the access to the administration endpoints.
+func (s *AccessService) IsAdmin(userID vo.ID) error {
+	if _, found := s.admins[userID]; !found {
+		return errtype.NewAccessDeniedError("administrator access is required")
+	}
+	return nil
+}
+
 // IsGranted is a method which will check the access to target scope of aggregates.
 func (s *AccessService) IsGranted(userID vo.ID, aggregates ...dtointerface.Aggregate) error {
 	for _, aggregate := range aggregates {
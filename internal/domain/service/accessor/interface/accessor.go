@@ -8,4 +8,6 @@ import (
 type Accessor interface {
 	// IsGranted is a method which will check the access to target aggregates scope.
 	IsGranted(userID vo.ID, aggregates ...dtointerface.Aggregate) error
+	// IsAdmin is a method which will check the access to the administration endpoints.
+	IsAdmin(userID vo.ID) error
 }
@@ -0,0 +1,427 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	mailerinterface "github.com/Borislavv/video-streaming/internal/domain/service/mailer/interface"
+	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
+	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"time"
+)
+
+const (
+	passwordWasResetReason = "password was reset"
+
+	emailVerificationSubject = "Email verification"
+	emailVerificationBody    = "Hello, %v!\n\n" +
+		"To complete the registration, follow the link:\n%v\n\n" +
+		"The link is valid for %v. If you didn't register, just ignore this letter.\n"
+	// emailVerificationLinkFormat is a link to the verification endpoint: base url, api prefix and token.
+	emailVerificationLinkFormat = "%v%v/registration/verification?token=%v"
+
+	accountUnlockSubject = "Account is locked"
+	accountUnlockBody    = "Hello, %v!\n\n" +
+		"Your account was locked due to too many failed authorization attempts.\n" +
+		"If it was you, follow the link for unlock the account (it's valid for %v):\n%v\n\n" +
+		"Otherwise, someone is trying to guess your password, we recommend to reset it and enable the second factor.\n"
+	// accountUnlockLinkFormat is a link to the unlock endpoint: base url, api prefix and token.
+	accountUnlockLinkFormat = "%v%v/account/unlock?token=%v"
+	unlockedByEmailReason   = "unlocked by email"
+	unlockedByAdminReason   = "unlocked by administrator %v"
+
+	passwordResetSubject = "Password reset"
+	passwordResetBody    = "Hello, %v!\n\n" +
+		"To set up a new password, use the token below (it's valid for %v):\n%v\n\n" +
+		"If you didn't request a password reset, just ignore this letter, your password will stay the same.\n"
+)
+
+type AccountService struct {
+	ctx                       context.Context
+	logger                    loggerinterface.Logger
+	builder                   builderinterface.Account
+	validator                 validatorinterface.Account
+	userRepository            repositoryinterface.User
+	actionTokenRepository     repositoryinterface.ActionToken
+	apiKeyRepository          repositoryinterface.ApiKey
+	mailer                    mailerinterface.Mailer
+	tokenizer                 tokenizerinterface.Tokenizer
+	passwordHasher            securityinterface.PasswordHasher
+	throttler                 throttlerinterface.AuthThrottler
+	accessor                  accessorinterface.Accessor
+	linksBaseUrl              string
+	apiVersionPrefix          string
+	emailVerificationTokenTTL time.Duration
+	passwordResetTokenTTL     time.Duration
+	accountUnlockTokenTTL     time.Duration
+}
+
+func NewAccountService(serviceContainer diinterface.ServiceContainer) (*AccountService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	emailVerificationTokenTTL, err := time.ParseDuration(cfg.EmailVerificationTokenTTL)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	passwordResetTokenTTL, err := time.ParseDuration(cfg.PasswordResetTokenTTL)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// the unlock link is valid while the lockout lasts
+	accountUnlockTokenTTL, err := time.ParseDuration(cfg.AuthLockoutDuration)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountBuilder, err := serviceContainer.GetAccountBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountValidator, err := serviceContainer.GetAccountValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	userRepository, err := serviceContainer.GetUserRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	actionTokenRepository, err := serviceContainer.GetActionTokenRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyRepository, err := serviceContainer.GetApiKeyRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mailerService, err := serviceContainer.GetMailerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	passwordHasherService, err := serviceContainer.GetPasswordHasherService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authThrottlerService, err := serviceContainer.GetAuthThrottlerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AccountService{
+		ctx:                       ctx,
+		logger:                    loggerService,
+		builder:                   accountBuilder,
+		validator:                 accountValidator,
+		userRepository:            userRepository,
+		actionTokenRepository:     actionTokenRepository,
+		apiKeyRepository:          apiKeyRepository,
+		mailer:                    mailerService,
+		tokenizer:                 tokenizerService,
+		passwordHasher:            passwordHasherService,
+		throttler:                 authThrottlerService,
+		accessor:                  accessService,
+		linksBaseUrl:              cfg.MailLinksBaseUrl,
+		apiVersionPrefix:          cfg.ResourcesApiVersionPrefix,
+		emailVerificationTokenTTL: emailVerificationTokenTTL,
+		passwordResetTokenTTL:     passwordResetTokenTTL,
+		accountUnlockTokenTTL:     accountUnlockTokenTTL,
+	}, nil
+}
+
+// SendEmailVerification - will send the letter with verification link to the registered user.
+func (s *AccountService) SendEmailVerification(user *agg.User) error {
+	rawToken, err := s.issueToken(user.ID, enum.EmailVerificationAction, s.emailVerificationTokenTTL)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	link := fmt.Sprintf(emailVerificationLinkFormat, s.linksBaseUrl, s.apiVersionPrefix, rawToken)
+	body := fmt.Sprintf(emailVerificationBody, user.Username, link, s.emailVerificationTokenTTL)
+
+	if err = s.mailer.Send(user.Email, emailVerificationSubject, body); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// VerifyEmail - will activate the user by token from the verification letter.
+func (s *AccountService) VerifyEmail(req dtointerface.VerifyEmailRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateEmailVerificationRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.consumeToken(req.GetToken(), enum.EmailVerificationAction)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.activate(userAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// ResendEmailVerification - will send the verification letter again. Unknown or already active users
+// are skipped silently, otherwise the endpoint may be used for check which emails are registered.
+func (s *AccountService) ResendEmailVerification(req dtointerface.ResendEmailVerificationRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateEmailVerificationResendRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.findByEmail(req.GetEmail())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if userAgg == nil || userAgg.IsActive() {
+		return nil
+	}
+
+	if err = s.SendEmailVerification(userAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// ResetPassword - will send the letter with password reset token. Unknown users are skipped silently,
+// otherwise the endpoint may be used for check which emails are registered.
+func (s *AccountService) ResetPassword(req dtointerface.ResetPasswordRequest) error {
+	// validation of input request
+	if err := s.validator.ValidatePasswordResetRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.findByEmail(req.GetEmail())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if userAgg == nil {
+		return nil
+	}
+
+	rawToken, err := s.issueToken(userAgg.ID, enum.PasswordResetAction, s.passwordResetTokenTTL)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	body := fmt.Sprintf(passwordResetBody, userAgg.Username, s.passwordResetTokenTTL, rawToken)
+	if err = s.mailer.Send(userAgg.Email, passwordResetSubject, body); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// ConfirmPasswordReset - will set up a new password by token from the letter and revoke all access tokens
+// and api keys of the user. The user becomes active as well because the token proves that the email belongs to the user.
+func (s *AccountService) ConfirmPasswordReset(req dtointerface.ConfirmPasswordResetRequest) error {
+	// validation of input request
+	if err := s.validator.ValidatePasswordResetConfirmRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.consumeToken(req.GetToken(), enum.PasswordResetAction)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(req.GetPassword())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	userAgg.SetPassword(passwordHash)
+
+	if err = s.activate(userAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// all access tokens which were issued by the old password must not be accepted anymore
+	if err = s.tokenizer.RevokeAll(userAgg.ID, passwordWasResetReason); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// api keys may be issued by someone who knew the old password as well
+	if err = s.apiKeyRepository.RemoveByUser(s.ctx, userAgg.ID); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the account may be locked out by guessing of the old password
+	if err = s.throttler.Unlock(userAgg.Email, "", passwordWasResetReason); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// SendAccountUnlock - will send the letter with unlock link to the user whose account was locked out.
+func (s *AccountService) SendAccountUnlock(user *agg.User) error {
+	rawToken, err := s.issueToken(user.ID, enum.AccountUnlockAction, s.accountUnlockTokenTTL)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	link := fmt.Sprintf(accountUnlockLinkFormat, s.linksBaseUrl, s.apiVersionPrefix, rawToken)
+	body := fmt.Sprintf(accountUnlockBody, user.Username, s.accountUnlockTokenTTL, link)
+
+	if err = s.mailer.Send(user.Email, accountUnlockSubject, body); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// UnlockAccount - will unlock the account by token from the unlock letter.
+func (s *AccountService) UnlockAccount(req dtointerface.UnlockAccountRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateAccountUnlockRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.consumeToken(req.GetToken(), enum.AccountUnlockAction)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.throttler.Unlock(userAgg.Email, "", unlockedByEmailReason); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// AdminUnlockAccount - will unlock the account and/or the client address by an administrator request.
+func (s *AccountService) AdminUnlockAccount(req dtointerface.AdminUnlockAccountRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateAdminAccountUnlockRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// checking that the requester is an administrator
+	if err := s.accessor.IsAdmin(req.GetUserID()); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	reason := fmt.Sprintf(unlockedByAdminReason, req.GetUserID().Value.Hex())
+	if err := s.throttler.Unlock(req.GetEmail(), req.GetIP(), reason); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// issueToken will store a new token (previous tokens of the user with the same action are removed).
+func (s *AccountService) issueToken(userID vo.ID, action string, ttl time.Duration) (rawToken string, err error) {
+	if err = s.actionTokenRepository.RemoveByUser(s.ctx, userID, action); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
+	tokenAgg, rawToken, err := s.builder.BuildActionTokenAgg(userID, action, ttl)
+	if err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
+	if _, err = s.actionTokenRepository.Insert(s.ctx, tokenAgg); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
+	return rawToken, nil
+}
+
+// consumeToken will remove the token from storage and return the owner, if the token is valid.
+func (s *AccountService) consumeToken(rawToken string, action string) (*agg.User, error) {
+	q := dto.NewActionTokenGetRequestDTO(helper.SHA256([]byte(rawToken)), action)
+
+	tokenAgg, err := s.actionTokenRepository.Consume(s.ctx, q)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil, errtype.NewActionTokenIsInvalidError()
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+	if tokenAgg.IsExpired() {
+		return nil, errtype.NewActionTokenIsInvalidError()
+	}
+
+	userAgg, err := s.userRepository.FindOneByID(s.ctx, dto.NewUserGetRequestDTO(tokenAgg.UserID, ""))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil, errtype.NewActionTokenIsInvalidError()
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return userAgg, nil
+}
+
+// activate will save the user as active (the storage is updated even if the user was active already,
+// because a caller may change other fields).
+func (s *AccountService) activate(userAgg *agg.User) error {
+	userAgg.Status = enum.UserActiveStatus
+	userAgg.Timestamp.UpdatedAt = time.Now()
+
+	if _, err := s.userRepository.Update(s.ctx, userAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// findByEmail will return the user or nil if the one was not found.
+func (s *AccountService) findByEmail(email string) (*agg.User, error) {
+	userAgg, err := s.userRepository.FindOneByEmail(s.ctx, dto.NewUserGetRequestDTO(vo.ID{}, email))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+	return userAgg, nil
+}
@@ -0,0 +1,25 @@
+package accountinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Account interface {
+	// SendEmailVerification will send the letter with verification link to the registered user.
+	SendEmailVerification(user *agg.User) error
+	// VerifyEmail will activate the user by token from the verification letter.
+	VerifyEmail(reqDTO dtointerface.VerifyEmailRequest) error
+	// ResendEmailVerification will send the verification letter again (if the user is still not active).
+	ResendEmailVerification(reqDTO dtointerface.ResendEmailVerificationRequest) error
+	// ResetPassword will send the letter with password reset token.
+	ResetPassword(reqDTO dtointerface.ResetPasswordRequest) error
+	// ConfirmPasswordReset will set up a new password by token from the letter and revoke all access tokens.
+	ConfirmPasswordReset(reqDTO dtointerface.ConfirmPasswordResetRequest) error
+	// SendAccountUnlock will send the letter with unlock link to the user whose account was locked out.
+	SendAccountUnlock(user *agg.User) error
+	// UnlockAccount will unlock the account by token from the unlock letter.
+	UnlockAccount(reqDTO dtointerface.UnlockAccountRequest) error
+	// AdminUnlockAccount will unlock the account and/or the client address by an administrator request.
+	AdminUnlockAccount(reqDTO dtointerface.AdminUnlockAccountRequest) error
+}
@@ -0,0 +1,161 @@
+package apikey
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"time"
+)
+
+// lastUsedAtPrecision is a min. interval between two writes of the key last usage time,
+// it's necessary for not produce write into the storage on each request.
+const lastUsedAtPrecision = time.Minute
+
+type CRUDService struct {
+	ctx        context.Context
+	logger     loggerinterface.Logger
+	builder    builderinterface.ApiKey
+	validator  validatorinterface.ApiKey
+	repository repositoryinterface.ApiKey
+}
+
+func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetApiKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyValidator, err := serviceContainer.GetApiKeyValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyRepository, err := serviceContainer.GetApiKeyRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CRUDService{
+		ctx:        ctx,
+		logger:     loggerService,
+		builder:    apiKeyBuilder,
+		validator:  apiKeyValidator,
+		repository: apiKeyRepository,
+	}, nil
+}
+
+// List - will fetch all api keys of specified user.
+func (s *CRUDService) List(req dtointerface.ListApiKeyRequest) (list []*agg.ApiKey, err error) {
+	// validation of input request
+	if err = s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching an api keys list by user
+	list, err = s.repository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Create - will issue a new api key for specified user. The raw key is returned only here, the storage keeps a hash.
+func (s *CRUDService) Create(req dtointerface.CreateApiKeyRequest) (apiKey *agg.ApiKey, rawKey string, err error) {
+	// validation of input request
+	if err = s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return nil, "", s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	apiKey, rawKey, err = s.builder.BuildAggFromCreateRequestDTO(req)
+	if err != nil {
+		return nil, "", s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(apiKey); err != nil {
+		return nil, "", s.logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	apiKey, err = s.repository.Insert(s.ctx, apiKey)
+	if err != nil {
+		return nil, "", s.logger.LogPropagate(err)
+	}
+
+	return apiKey, rawKey, nil
+}
+
+// Delete - will revoke the api key of specified user.
+func (s *CRUDService) Delete(req dtointerface.DeleteApiKeyRequest) (err error) {
+	// validation of input request
+	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching an api key which will be deleted
+	apiKey, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// api key removing
+	if err = s.repository.Remove(s.ctx, apiKey); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// Verify - will find the key by raw value and check that one is not expired and has the required scope.
+func (s *CRUDService) Verify(rawKey string, scope string) (*agg.ApiKey, error) {
+	q := dto.NewApiKeyGetRequestDTO(vo.ID{}, helper.SHA256([]byte(rawKey)), vo.ID{})
+	apiKey, err := s.repository.FindOneByHash(s.ctx, q)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil, s.logger.LogPropagate(errtype.NewApiKeyIsInvalidError())
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	if apiKey.IsExpired() {
+		return nil, s.logger.LogPropagate(errtype.NewApiKeyWasExpiredError())
+	}
+
+	if !apiKey.HasScope(scope) {
+		return nil, s.logger.LogPropagate(
+			errtype.NewAccessDeniedError("api key has not the '" + scope + "' scope"),
+		)
+	}
+
+	// tracking the key usage
+	if time.Since(apiKey.LastUsedAt) > lastUsedAtPrecision {
+		apiKey.LastUsedAt = time.Now()
+		if err = s.repository.Touch(s.ctx, apiKey); err != nil {
+			// the key is valid, so don't interrupt the request
+			s.logger.Log(err)
+		}
+	}
+
+	return apiKey, nil
+}
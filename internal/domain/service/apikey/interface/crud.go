@@ -0,0 +1,14 @@
+package apikeyinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type CRUD interface {
+	List(reqDTO dtointerface.ListApiKeyRequest) (list []*agg.ApiKey, err error)
+	Create(reqDTO dtointerface.CreateApiKeyRequest) (apiKey *agg.ApiKey, rawKey string, err error)
+	Delete(reqDTO dtointerface.DeleteApiKeyRequest) error
+	// Verify will find the key by raw value and check that one is not expired and has the required scope.
+	Verify(rawKey string, scope string) (*agg.ApiKey, error)
+}
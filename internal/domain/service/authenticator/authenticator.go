@@ -1,18 +1,26 @@
 package authenticator
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 )
 
 var (
@@ -25,6 +33,12 @@ type AuthService struct {
 	validator      validatorinterface.Auth
 	tokenizer      tokenizerinterface.Tokenizer
 	passwordHasher securityinterface.PasswordHasher
+	apiKeyService  apikeyinterface.CRUD
+	secondFactor   twofactorinterface.SecondFactor
+	throttler      throttlerinterface.AuthThrottler
+	accountService accountinterface.Account
+	// secondFactorMaxAge is a max. age of the second factor passing for sensitive operations.
+	secondFactorMaxAge time.Duration
 }
 
 func NewAuthService(serviceContainer diinterface.ServiceContainer) (*AuthService, error) {
@@ -53,12 +67,47 @@ func NewAuthService(serviceContainer diinterface.ServiceContainer) (*AuthService
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	apiKeyCRUDService, err := serviceContainer.GetApiKeyCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	secondFactorService, err := serviceContainer.GetSecondFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authThrottlerService, err := serviceContainer.GetAuthThrottlerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	secondFactorMaxAge, err := time.ParseDuration(cfg.SecondFactorMaxAge)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &AuthService{
-		logger:         loggerService,
-		userService:    userCRUDService,
-		validator:      authValidator,
-		tokenizer:      tokenizerService,
-		passwordHasher: passwordHasherService,
+		logger:             loggerService,
+		userService:        userCRUDService,
+		validator:          authValidator,
+		tokenizer:          tokenizerService,
+		passwordHasher:     passwordHasherService,
+		apiKeyService:      apiKeyCRUDService,
+		secondFactor:       secondFactorService,
+		throttler:          authThrottlerService,
+		accountService:     accountService,
+		secondFactorMaxAge: secondFactorMaxAge,
 	}, nil
 }
 
@@ -69,33 +118,68 @@ func (s *AuthService) Auth(req dtointerface.AuthRequest) (token string, err erro
 		return "", s.logger.LogPropagate(err)
 	}
 
+	// checking that the account and the client address are not locked out after failed attempts
+	if err = s.throttler.Check(req.GetEmail(), req.GetIP()); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
 	// getting the target user agg. by email
 	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(vo.ID{}, req.GetEmail()))
 	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return "", s.logger.LogPropagate(s.fail(nil, req, err))
+		}
 		return "", s.logger.LogPropagate(err)
 	}
 
 	// checking that credentials are valid
 	if err = s.passwordHasher.Verify(userAgg, req.GetPassword()); err != nil {
+		if errtype.IsAuthFailedError(err) {
+			return "", s.logger.LogPropagate(s.fail(userAgg, req, err))
+		}
+		return "", s.logger.LogPropagate(err)
+	}
+
+	// checking that user's email was verified
+	if !userAgg.IsActive() {
+		return "", s.logger.LogPropagate(errtype.NewEmailIsNotVerifiedError())
+	}
+
+	// checking the second factor, if the user has enabled it
+	amr, err := s.passSecondFactor(userAgg.ID, req.GetCode())
+	if err != nil {
+		if errtype.IsSecondFactorCodeIsInvalidError(err) {
+			return "", s.logger.LogPropagate(s.fail(userAgg, req, err))
+		}
 		return "", s.logger.LogPropagate(err)
 	}
 
 	// generating a new access token string
-	token, err = s.tokenizer.New(userAgg)
+	token, err = s.tokenizer.New(userAgg, amr...)
 	if err != nil {
 		return "", s.logger.LogPropagate(err)
 	}
 
+	// resetting the failed attempts of the account
+	if err = s.throttler.Succeed(userAgg.ID, req.GetEmail(), req.GetIP()); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+
 	return token, nil
 }
 
-// IsAuthed with check that token is valid and extract userID from it.
+// IsAuthed with check that token (or api key) is valid and extract userID from it.
 func (s *AuthService) IsAuthed(r *http.Request) (userID vo.ID, err error) {
 	// validate that token is present into request headers
 	if err = s.validator.ValidateTokennessRequest(r); err != nil {
 		return vo.ID{}, s.logger.LogPropagate(err)
 	}
 
+	// machine clients are authorized by api key instead of token
+	if apiKey, isApiKey := s.extractApiKey(r); isApiKey {
+		return s.isAuthedByApiKey(r, apiKey)
+	}
+
 	// extract token from request
 	token, err := s.extractToken(r)
 	if err != nil {
@@ -114,6 +198,136 @@ func (s *AuthService) IsAuthed(r *http.Request) (userID vo.ID, err error) {
 	return userID, nil
 }
 
+// IsSecondFactorRecent will check that the user, who has enabled the second factor, was passed it recently.
+// It's used by sensitive operations, requests authorized by api key are not allowed for them in this case.
+func (s *AuthService) IsSecondFactorRecent(r *http.Request, userID vo.ID) error {
+	enabled, err := s.secondFactor.IsEnabled(userID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	if _, isApiKey := s.extractApiKey(r); isApiKey {
+		return s.logger.LogPropagate(errtype.NewRecentSecondFactorIsRequiredError())
+	}
+
+	token, err := s.extractToken(r)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	amr, authedAt, err := s.tokenizer.AuthMethods(token)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if !slices.Contains(amr, enum.AmrOtp) || time.Since(authedAt) > s.secondFactorMaxAge {
+		return s.logger.LogPropagate(errtype.NewRecentSecondFactorIsRequiredError())
+	}
+
+	return nil
+}
+
+// IsAuthedByToken will check that the request was authorized by access token instead of api key.
+// It's used by api keys management, otherwise a leaked key would be able to issue new ones with any scope.
+func (s *AuthService) IsAuthedByToken(r *http.Request) error {
+	if _, isApiKey := s.extractApiKey(r); isApiKey {
+		return s.logger.LogPropagate(errtype.NewAccessDeniedError("api keys cannot be managed by api key"))
+	}
+	return nil
+}
+
+// passSecondFactor will verify the code if the user has enabled the second factor
+// and return the list of methods which were used for authentication.
+func (s *AuthService) passSecondFactor(userID vo.ID, code string) (amr []string, err error) {
+	amr = []string{enum.AmrPassword}
+
+	enabled, err := s.secondFactor.IsEnabled(userID)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	if !enabled {
+		return amr, nil
+	}
+
+	if code == "" {
+		return nil, s.logger.LogPropagate(errtype.NewSecondFactorIsRequiredError())
+	}
+	if err = s.secondFactor.Verify(userID, code); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return append(amr, enum.AmrOtp), nil
+}
+
+// fail will count the failed attempt and send the unlock letter if the account was locked out by it.
+// The given reason is returned as is, thus a caller is able to respond with it.
+func (s *AuthService) fail(userAgg *agg.User, req dtointerface.AuthRequest, reason error) error {
+	locked, err := s.throttler.Fail(req.GetEmail(), req.GetIP(), reason.Error())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if locked && userAgg != nil {
+		// the lockout is applied anyway, the letter failure is only logged
+		if err = s.accountService.SendAccountUnlock(userAgg); err != nil {
+			s.logger.Log(err)
+		}
+	}
+
+	return reason
+}
+
+// isAuthedByApiKey will check that api key is valid and has enough scope for perform the request.
+// The key owner must be able to authorize by password as well: exists, verified and not locked out.
+func (s *AuthService) isAuthedByApiKey(r *http.Request, rawKey string) (userID vo.ID, err error) {
+	apiKey, err := s.apiKeyService.Verify(rawKey, s.requiredApiKeyScope(r))
+	if err != nil {
+		return vo.ID{}, s.logger.LogPropagate(err)
+	}
+
+	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(apiKey.UserID, ""))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return vo.ID{}, s.logger.LogPropagate(errtype.NewApiKeyIsInvalidError())
+		}
+		return vo.ID{}, s.logger.LogPropagate(err)
+	}
+
+	if !userAgg.IsActive() {
+		return vo.ID{}, s.logger.LogPropagate(errtype.NewEmailIsNotVerifiedError())
+	}
+
+	if err = s.throttler.Check(userAgg.Email, ""); err != nil {
+		return vo.ID{}, s.logger.LogPropagate(err)
+	}
+
+	return userAgg.ID, nil
+}
+
+// requiredApiKeyScope determines the scope of api key which needed for the request by its method:
+// safe methods requires the 'read' scope, removing requires the 'delete' scope, others requires the 'upload' scope.
+func (s *AuthService) requiredApiKeyScope(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return enum.ApiKeyReadScope
+	case http.MethodDelete:
+		return enum.ApiKeyDeleteScope
+	default:
+		return enum.ApiKeyUploadScope
+	}
+}
+
+func (s *AuthService) extractApiKey(r *http.Request) (apiKey string, found bool) {
+	scheme, apiKey, found := strings.Cut(r.Header.Get(enum.AuthorizationHeaderKey), " ")
+	if !found || !strings.EqualFold(scheme, enum.ApiKeyAuthorizationScheme) {
+		return "", false
+	}
+	return strings.TrimSpace(apiKey), true
+}
+
 func (s *AuthService) extractToken(r *http.Request) (token string, err error) {
 	token = r.Header.Get(enum.AccessTokenHeaderKey)
 	if token != "" {
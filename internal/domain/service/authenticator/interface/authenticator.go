@@ -9,6 +9,10 @@ import (
 type Authenticator interface {
 	// Auth will check raw credentials and generate a new access token for given user.
 	Auth(reqDTO dtointerface.AuthRequest) (token string, err error)
-	// IsAuthed with check that token is valid and extract userID from it.
+	// IsAuthed with check that token (or api key) is valid and extract userID from it.
 	IsAuthed(r *http.Request) (userID vo.ID, err error)
+	// IsSecondFactorRecent will check that the user, who has enabled the second factor, was passed it recently.
+	IsSecondFactorRecent(r *http.Request, userID vo.ID) error
+	// IsAuthedByToken will check that the request was authorized by access token instead of api key.
+	IsAuthedByToken(r *http.Request) error
 }
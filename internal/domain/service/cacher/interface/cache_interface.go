@@ -3,4 +3,8 @@ package cacherinterface
 type Cacher interface {
 	Get(key string, fn func(CacheItem) (data interface{}, err error)) (data interface{}, err error)
 	Delete(key string)
+	// InvalidateTags will remove the items which are marked by any of the given tags.
+	InvalidateTags(tags ...string)
+	// Stats will return the current hit/miss/eviction counters and the size of the cache.
+	Stats() CacheStats
 }
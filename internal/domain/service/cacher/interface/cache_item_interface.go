@@ -4,4 +4,12 @@ import "time"
 
 type CacheItem interface {
 	SetTTL(ttl time.Duration)
+	// SetSoftTTL will set the lifetime after which the item is refreshed in background (the stale item is still
+	// served until the refresh is done), thus the hot items don't expire under load. Must be less than TTL.
+	SetSoftTTL(ttl time.Duration)
+	// SetNegativeTTL will make the error of the computation cached for the ttl instead of being returned only
+	// (e.g. for the not found results).
+	SetNegativeTTL(ttl time.Duration)
+	// SetTags will mark the item by tags (e.g. "video:<id>"), thus it can be invalidated by any of them.
+	SetTags(tags ...string)
 }
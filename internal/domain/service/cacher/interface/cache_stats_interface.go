@@ -0,0 +1,16 @@
+package cacherinterface
+
+// CacheStats is a snapshot of the cache counters.
+type CacheStats interface {
+	// Hits is a number of requests which were served from the cache.
+	Hits() uint64
+	// Misses is a number of requests which required computing of the item.
+	Misses() uint64
+	// Evictions is a number of items which were evicted due to the limits of the cache (expired and invalidated
+	// items are not counted).
+	Evictions() uint64
+	// Entries is a number of stored items.
+	Entries() int
+	// Bytes is an estimated size of the stored items.
+	Bytes() int64
+}
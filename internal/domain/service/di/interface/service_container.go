@@ -7,12 +7,19 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	mailerinterface "github.com/Borislavv/video-streaming/internal/domain/service/mailer/interface"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	progressinterface "github.com/Borislavv/video-streaming/internal/domain/service/progress/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
@@ -22,12 +29,19 @@ import (
 	cacheinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	limiterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/limiter/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	handlerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	strategyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	partyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	queueinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -45,6 +59,9 @@ type ServiceContainer interface {
 	GetVideoMongoRepository() (mongodbinterface.Video, error)
 	GetUserMongoRepository() (mongodbinterface.User, error)
 	GetBlockedTokenMongoRepository() (mongodbinterface.BlockedToken, error)
+	GetApiKeyMongoRepository() (mongodbinterface.ApiKey, error)
+	GetTotpMongoRepository() (mongodbinterface.Totp, error)
+	GetActionTokenMongoRepository() (mongodbinterface.ActionToken, error)
 
 	GetResourceCacheRepository() (cacheinterface.Resource, error)
 	GetVideoCacheRepository() (cacheinterface.Video, error)
@@ -73,12 +90,42 @@ type ServiceContainer interface {
 	GetAuthValidator() (validatorinterface.Auth, error)
 	GetAuthService() (authenticatorinterface.Authenticator, error)
 
+	GetApiKeyBuilder() (builderinterface.ApiKey, error)
+	GetWatchProgressBuilder() (builderinterface.WatchProgress, error)
+	GetPlaylistBuilder() (builderinterface.Playlist, error)
+	GetApiKeyValidator() (validatorinterface.ApiKey, error)
+	GetWatchProgressValidator() (validatorinterface.WatchProgress, error)
+	GetPlaylistValidator() (validatorinterface.Playlist, error)
+	GetApiKeyRepository() (repositoryinterface.ApiKey, error)
+	GetApiKeyCRUDService() (apikeyinterface.CRUD, error)
+	GetWatchProgressService() (progressinterface.WatchProgress, error)
+	GetPlaylistCRUDService() (playlistinterface.CRUD, error)
+
+	GetTotpBuilder() (builderinterface.Totp, error)
+	GetTotpValidator() (validatorinterface.Totp, error)
+	GetTotpRepository() (repositoryinterface.Totp, error)
+	GetSecondFactorService() (twofactorinterface.SecondFactor, error)
+
+	GetAccountBuilder() (builderinterface.Account, error)
+	GetAccountValidator() (validatorinterface.Account, error)
+	GetActionTokenRepository() (repositoryinterface.ActionToken, error)
+	GetPlaybackSessionRepository() (repositoryinterface.PlaybackSession, error)
+	GetWatchProgressRepository() (repositoryinterface.WatchProgress, error)
+	GetPlaylistRepository() (repositoryinterface.Playlist, error)
+	GetAccountService() (accountinterface.Account, error)
+
+	GetAuthAttemptRepository() (repositoryinterface.AuthAttempt, error)
+	GetAuthAuditRepository() (repositoryinterface.AuthAudit, error)
+	GetAuthThrottlerService() (throttlerinterface.AuthThrottler, error)
+
 	GetLoggerService() (loggerinterface.Logger, error)
 	GetCacheService() (cacherinterface.Cacher, error)
 	GetRequestParametersExtractorService() (extractorinterface.RequestParams, error)
 	GetResponderService() (responseinterface.Responder, error)
 	GetPasswordHasherService() (securityinterface.PasswordHasher, error)
+	GetTotpService() (securityinterface.Totp, error)
 	GetTokenizerService() (tokenizerinterface.Tokenizer, error)
+	GetMailerService() (mailerinterface.Mailer, error)
 
 	GetFileStorageService() (fileinterface.Storage, error)
 	GetFileNameComputerService() (fileinterface.NameComputer, error)
@@ -93,4 +140,11 @@ type ServiceContainer interface {
 	GetCodecsDetectorService() (detectorinterface.Codecs, error)
 
 	GetStreamingService() (streamerinterface.Streamer, error)
+	GetStreamingLimiterService() (limiterinterface.StreamingLimiter, error)
+	GetStreamShaperService() (shaperinterface.Shaper, error)
+	GetConnectionRegistryService() (registryinterface.Registry, error)
+	GetPlaybackSessionsService() (sessioninterface.Sessions, error)
+	GetPlaybackQueuesService() (queueinterface.Queues, error)
+	GetLiveBroadcastsService() (liveinterface.Broadcasts, error)
+	GetWatchPartiesService() (partyinterface.Parties, error)
 }
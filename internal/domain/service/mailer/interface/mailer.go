@@ -0,0 +1,6 @@
+package mailerinterface
+
+type Mailer interface {
+	// Send will deliver a plain text letter to given address.
+	Send(to string, subject string, body string) error
+}
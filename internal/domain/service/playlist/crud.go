@@ -0,0 +1,162 @@
+package playlist
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+)
+
+type CRUDService struct {
+	ctx        context.Context
+	logger     loggerinterface.Logger
+	builder    builderinterface.Playlist
+	validator  validatorinterface.Playlist
+	repository repositoryinterface.Playlist
+}
+
+func NewCRUDService(serviceContainer diinterface.ServiceContainer) (*CRUDService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistValidator, err := serviceContainer.GetPlaylistValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CRUDService{
+		ctx:        ctx,
+		logger:     loggerService,
+		builder:    playlistBuilder,
+		validator:  playlistValidator,
+		repository: playlistRepository,
+	}, nil
+}
+
+// Get - will fetch a single playlist by ID and specified user.
+func (s *CRUDService) Get(req dtointerface.GetPlaylistRequest) (*agg.Playlist, error) {
+	// validation of input request
+	if err := s.validator.ValidateGetRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a playlist by id and user
+	playlist, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return playlist, nil
+}
+
+// List - will fetch a playlist list of specified user.
+func (s *CRUDService) List(req dtointerface.ListPlaylistRequest) (list []*agg.Playlist, total int64, err error) {
+	// validation of input request
+	if err = s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	// fetching a playlist list by user
+	list, total, err = s.repository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, 0, s.logger.LogPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+// Create - will make a new playlist for specified user. Have an access check for each video of the playlist.
+func (s *CRUDService) Create(req dtointerface.CreatePlaylistRequest) (*agg.Playlist, error) {
+	// validation of input request
+	if err := s.validator.ValidateCreateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	playlistAgg, err := s.builder.BuildAggFromCreateRequestDTO(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(playlistAgg); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving an aggregate into storage
+	playlistAgg, err = s.repository.Insert(s.ctx, playlistAgg)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return playlistAgg, nil
+}
+
+// Update - will rename the playlist or replace its videos. Have an access check for each new video.
+func (s *CRUDService) Update(req dtointerface.UpdatePlaylistRequest) (*agg.Playlist, error) {
+	// validation of input request
+	if err := s.validator.ValidateUpdateRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// building an aggregate
+	playlistAgg, err := s.builder.BuildAggFromUpdateRequestDTO(req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// validation of an aggregate
+	if err = s.validator.ValidateAggregate(playlistAgg); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// saving updated aggregate into storage
+	playlistAgg, err = s.repository.Update(s.ctx, playlistAgg)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return playlistAgg, nil
+}
+
+// Delete - will remove the playlist from the storage (the videos are not affected).
+func (s *CRUDService) Delete(req dtointerface.DeletePlaylistRequest) (err error) {
+	// validation of input request
+	if err = s.validator.ValidateDeleteRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// fetching a playlist which will be deleted
+	playlistAgg, err := s.repository.FindOneByID(s.ctx, req)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// playlist removing
+	if err = s.repository.Remove(s.ctx, playlistAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package playlistinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type CRUD interface {
+	Get(reqDTO dtointerface.GetPlaylistRequest) (*agg.Playlist, error)
+	List(reqDTO dtointerface.ListPlaylistRequest) (list []*agg.Playlist, total int64, err error)
+	Create(reqDTO dtointerface.CreatePlaylistRequest) (*agg.Playlist, error)
+	Update(reqDTO dtointerface.UpdatePlaylistRequest) (*agg.Playlist, error)
+	Delete(reqDTO dtointerface.DeletePlaylistRequest) error
+}
@@ -0,0 +1,11 @@
+package progressinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type WatchProgress interface {
+	// List returns the unfinished (or completed) videos of the user with their progress, most recent first.
+	List(reqDTO dtointerface.ListWatchProgressRequest) (list []*dto.WatchProgressResponseDTO, err error)
+}
@@ -0,0 +1,99 @@
+package progress
+
+import (
+	"context"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+// WatchProgressService provides the watch progress of the user which is saved by the streaming service.
+type WatchProgressService struct {
+	ctx             context.Context
+	logger          loggerinterface.Logger
+	builder         builderinterface.WatchProgress
+	validator       validatorinterface.WatchProgress
+	repository      repositoryinterface.WatchProgress
+	videoRepository repositoryinterface.Video
+}
+
+func NewWatchProgressService(serviceContainer diinterface.ServiceContainer) (*WatchProgressService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressBuilder, err := serviceContainer.GetWatchProgressBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressValidator, err := serviceContainer.GetWatchProgressValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressRepository, err := serviceContainer.GetWatchProgressRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &WatchProgressService{
+		ctx:             ctx,
+		logger:          loggerService,
+		builder:         watchProgressBuilder,
+		validator:       watchProgressValidator,
+		repository:      watchProgressRepository,
+		videoRepository: videoRepository,
+	}, nil
+}
+
+// List - will fetch the unfinished (or completed) videos of the user with their progress, most recent first.
+func (s *WatchProgressService) List(
+	req dtointerface.ListWatchProgressRequest,
+) (list []*dto.WatchProgressResponseDTO, err error) {
+	// validation of input request
+	if err = s.validator.ValidateListRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// fetching a progress list by user
+	progressList, err := s.repository.FindList(s.ctx, req)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	list = make([]*dto.WatchProgressResponseDTO, 0, len(progressList))
+	for _, progress := range progressList {
+		// fetching the watched video (access to it is checked as well)
+		q := dto.NewVideoGetRequestDTO(progress.VideoID, "", vo.ID{}, req.GetUserID())
+		video, err := s.videoRepository.FindOneByID(s.ctx, q)
+		if err != nil {
+			// the video was removed or access to it was revoked, just skip it
+			if errtype.IsEntityNotFoundError(err) {
+				continue
+			}
+			return nil, s.logger.LogPropagate(err)
+		}
+
+		list = append(list, s.builder.BuildResponseDTO(progress, video))
+	}
+
+	return list, nil
+}
@@ -0,0 +1,9 @@
+package securityinterface
+
+import "time"
+
+type Totp interface {
+	GenerateSecret() (secret string, err error)
+	ProvisioningURI(secret string, account string) string
+	Validate(secret string, code string, at time.Time) (step int64, valid bool)
+}
@@ -0,0 +1,16 @@
+package throttlerinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type AuthThrottler interface {
+	// Check will return an error if the account (by email) or the client address is locked out
+	// or must wait before the next authorization attempt. Empty email or address is skipped.
+	Check(email string, ip string) error
+	// Fail will count a failed authorization attempt of the account and the client address.
+	// Returns true when the account was locked out by this attempt.
+	Fail(email string, ip string, reason string) (locked bool, err error)
+	// Succeed will reset the failed attempts of the account and write the audit entry.
+	Succeed(userID vo.ID, email string, ip string) error
+	// Unlock will reset the failed attempts and the lockout of the account and/or the client address.
+	Unlock(email string, ip string, reason string) error
+}
@@ -0,0 +1,234 @@
+package throttler
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"strings"
+	"time"
+)
+
+// maxBackoffShift limits the exponent of backoff, thus the delay cannot overflow.
+const maxBackoffShift = 30
+
+type AuthThrottlerService struct {
+	ctx                     context.Context
+	logger                  loggerinterface.Logger
+	attemptRepository       repositoryinterface.AuthAttempt
+	auditRepository         repositoryinterface.AuthAudit
+	freeAttempts            int
+	backoffBase             time.Duration
+	backoffMax              time.Duration
+	attemptsWindow          time.Duration
+	accountLockoutThreshold int
+	ipLockoutThreshold      int
+	lockoutDuration         time.Duration
+	auditTTL                time.Duration
+}
+
+func NewAuthThrottlerService(serviceContainer diinterface.ServiceContainer) (*AuthThrottlerService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	durations := make([]time.Duration, 0, 5)
+	for _, value := range []string{
+		cfg.AuthBackoffBase,
+		cfg.AuthBackoffMax,
+		cfg.AuthAttemptsWindow,
+		cfg.AuthLockoutDuration,
+		cfg.AuthAuditTTL,
+	} {
+		duration, perr := time.ParseDuration(value)
+		if perr != nil {
+			return nil, loggerService.LogPropagate(perr)
+		}
+		durations = append(durations, duration)
+	}
+
+	authAttemptRepository, err := serviceContainer.GetAuthAttemptRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authAuditRepository, err := serviceContainer.GetAuthAuditRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AuthThrottlerService{
+		ctx:                     ctx,
+		logger:                  loggerService,
+		attemptRepository:       authAttemptRepository,
+		auditRepository:         authAuditRepository,
+		freeAttempts:            cfg.AuthFreeAttempts,
+		backoffBase:             durations[0],
+		backoffMax:              durations[1],
+		attemptsWindow:          durations[2],
+		lockoutDuration:         durations[3],
+		auditTTL:                durations[4],
+		accountLockoutThreshold: cfg.AuthAccountLockoutThreshold,
+		ipLockoutThreshold:      cfg.AuthIPLockoutThreshold,
+	}, nil
+}
+
+// Check will return an error if the account or the client address must wait before the next attempt.
+func (s *AuthThrottlerService) Check(email string, ip string) error {
+	now := time.Now()
+
+	for _, key := range s.keys(email, ip) {
+		attempt, err := s.attemptRepository.FindOneByKey(s.ctx, key)
+		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				continue
+			}
+			return s.logger.LogPropagate(err)
+		}
+
+		if attempt.IsLocked(now) && strings.HasPrefix(key, enum.AuthAttemptAccountKeyPrefix) {
+			return errtype.NewAccountIsLockedError(attempt.RetryAfter(now))
+		}
+		if retryAfter := attempt.RetryAfter(now); retryAfter > 0 {
+			return errtype.NewTooManyAuthAttemptsError(retryAfter)
+		}
+	}
+
+	return nil
+}
+
+// Fail will count a failed attempt, delay the next one exponentially and lock out the key when
+// the threshold is reached. Returns true when the account was locked out by this attempt.
+func (s *AuthThrottlerService) Fail(email string, ip string, reason string) (locked bool, err error) {
+	now := time.Now()
+
+	for _, key := range s.keys(email, ip) {
+		attempt, ierr := s.attemptRepository.IncrementFailures(s.ctx, key, now.Add(s.attemptsWindow))
+		if ierr != nil {
+			return false, s.logger.LogPropagate(ierr)
+		}
+
+		if exceeded := attempt.Failures - s.freeAttempts; exceeded > 0 {
+			attempt.BlockedUntil = now.Add(s.backoff(exceeded))
+		}
+
+		threshold := s.ipLockoutThreshold
+		isAccount := strings.HasPrefix(key, enum.AuthAttemptAccountKeyPrefix)
+		if isAccount {
+			threshold = s.accountLockoutThreshold
+		}
+
+		lockedNow := threshold > 0 && attempt.Failures >= threshold && !attempt.IsLocked(now)
+		if lockedNow {
+			attempt.LockedUntil = now.Add(s.lockoutDuration)
+			attempt.ExpiresAt = attempt.LockedUntil
+			locked = locked || isAccount
+		}
+
+		if err = s.attemptRepository.Update(s.ctx, attempt); err != nil {
+			return false, s.logger.LogPropagate(err)
+		}
+
+		if lockedNow {
+			s.audit(vo.ID{}, email, ip, enum.AuthLockedEvent, key)
+		}
+	}
+
+	s.audit(vo.ID{}, email, ip, enum.AuthFailedEvent, reason)
+
+	return locked, nil
+}
+
+// Succeed will reset the failed attempts of the account. The counter of the client address is not reset,
+// otherwise an attacker is able to reset it by authorizing into own account between the attempts.
+func (s *AuthThrottlerService) Succeed(userID vo.ID, email string, ip string) error {
+	if err := s.attemptRepository.RemoveByKey(s.ctx, s.accountKey(email)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	s.audit(userID, email, ip, enum.AuthSucceededEvent, "")
+
+	return nil
+}
+
+// Unlock will reset the failed attempts and the lockout of the account and/or the client address.
+func (s *AuthThrottlerService) Unlock(email string, ip string, reason string) error {
+	for _, key := range s.keys(email, ip) {
+		if err := s.attemptRepository.RemoveByKey(s.ctx, key); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+
+	s.audit(vo.ID{}, email, ip, enum.AuthUnlockedEvent, reason)
+
+	return nil
+}
+
+// backoff returns a delay before the next attempt: base * 2^(exceeded-1), but not more than max.
+func (s *AuthThrottlerService) backoff(exceeded int) time.Duration {
+	shift := exceeded - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+
+	delay := s.backoffBase << shift
+	if delay <= 0 || delay > s.backoffMax {
+		return s.backoffMax
+	}
+	return delay
+}
+
+// keys returns the counters keys of given account and client address (empty values are skipped).
+func (s *AuthThrottlerService) keys(email string, ip string) []string {
+	keys := make([]string, 0, 2)
+	if email != "" {
+		keys = append(keys, s.accountKey(email))
+	}
+	if ip != "" {
+		keys = append(keys, enum.AuthAttemptIPKeyPrefix+ip)
+	}
+	return keys
+}
+
+// accountKey is built by email in lower case, thus the counter cannot be bypassed by changing the case.
+func (s *AuthThrottlerService) accountKey(email string) string {
+	return enum.AuthAttemptAccountKeyPrefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+// audit will write the audit entry, an error is only logged because the audit must not break the authorization.
+func (s *AuthThrottlerService) audit(userID vo.ID, email string, ip string, event string, reason string) {
+	now := time.Now()
+
+	_, err := s.auditRepository.Insert(s.ctx, &agg.AuthAudit{
+		AuthAudit: entity.AuthAudit{
+			UserID:    userID,
+			Email:     email,
+			IP:        ip,
+			Event:     event,
+			Reason:    reason,
+			ExpiresAt: now.Add(s.auditTTL),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: now,
+		},
+	})
+	if err != nil {
+		s.logger.Log(err)
+	}
+}
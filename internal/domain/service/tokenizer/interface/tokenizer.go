@@ -3,10 +3,13 @@ package tokenizerinterface
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"time"
 )
 
 type Tokenizer interface {
-	New(user *agg.User) (token string, err error)
+	New(user *agg.User, amr ...string) (token string, err error)
 	Verify(token string) (userID vo.ID, err error)
+	AuthMethods(token string) (amr []string, authedAt time.Time, err error)
 	Block(token string, reason string) error
+	RevokeAll(userID vo.ID, reason string) error
 }
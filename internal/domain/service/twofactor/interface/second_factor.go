@@ -0,0 +1,19 @@
+package twofactorinterface
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+)
+
+type SecondFactor interface {
+	// Enroll will generate a new secret which must be confirmed by the first one-time password.
+	Enroll(reqDTO dtointerface.EnrollTotpRequest) (secret string, uri string, err error)
+	// Confirm will enable the second factor and issue the recovery codes.
+	Confirm(reqDTO dtointerface.ConfirmTotpRequest) (recoveryCodes []string, err error)
+	// Disable will remove the second factor of user (one-time password or recovery code is required).
+	Disable(reqDTO dtointerface.DisableTotpRequest) error
+	// IsEnabled checks whether the user must pass the second factor.
+	IsEnabled(userID vo.ID) (enabled bool, err error)
+	// Verify will check the one-time password or recovery code (a recovery code may be used only once).
+	Verify(userID vo.ID, code string) error
+}
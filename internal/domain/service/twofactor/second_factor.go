@@ -0,0 +1,332 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
+	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
+	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"strings"
+	"time"
+)
+
+// recoveryCodeLength is a number of random bytes of a recovery code (hex encoded code will be twice longer).
+const recoveryCodeLength = 5
+
+type SecondFactorService struct {
+	ctx                 context.Context
+	logger              loggerinterface.Logger
+	builder             builderinterface.Totp
+	validator           validatorinterface.Totp
+	repository          repositoryinterface.Totp
+	userService         userinterface.CRUD
+	totp                securityinterface.Totp
+	throttler           throttlerinterface.AuthThrottler
+	accountService      accountinterface.Account
+	recoveryCodesNumber int
+}
+
+func NewSecondFactorService(serviceContainer diinterface.ServiceContainer) (*SecondFactorService, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	totpBuilder, err := serviceContainer.GetTotpBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	totpValidator, err := serviceContainer.GetTotpValidator()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	totpRepository, err := serviceContainer.GetTotpRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	userCRUDService, err := serviceContainer.GetUserCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	totpService, err := serviceContainer.GetTotpService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authThrottlerService, err := serviceContainer.GetAuthThrottlerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &SecondFactorService{
+		ctx:                 ctx,
+		logger:              loggerService,
+		builder:             totpBuilder,
+		validator:           totpValidator,
+		repository:          totpRepository,
+		userService:         userCRUDService,
+		totp:                totpService,
+		throttler:           authThrottlerService,
+		accountService:      accountService,
+		recoveryCodesNumber: cfg.TotpRecoveryCodesNumber,
+	}, nil
+}
+
+// Enroll - will generate a new secret which must be confirmed by the first one-time password.
+// Repeated call replaces the pending secret (for example, when the user has lost the QR code).
+func (s *SecondFactorService) Enroll(req dtointerface.EnrollTotpRequest) (secret string, uri string, err error) {
+	// validation of input request
+	if err = s.validator.ValidateEnrollRequestDTO(req); err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	// fetching the user for build an account label
+	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(req.GetUserID(), ""))
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	totpAgg, err := s.find(req.GetUserID())
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+	if totpAgg != nil && totpAgg.IsEnabled() {
+		return "", "", s.logger.LogPropagate(errtype.NewTotpIsAlreadyEnabledError())
+	}
+
+	secret, err = s.totp.GenerateSecret()
+	if err != nil {
+		return "", "", s.logger.LogPropagate(err)
+	}
+
+	// saving the pending secret into storage
+	if totpAgg == nil {
+		if _, err = s.repository.Insert(s.ctx, s.builder.BuildAggFromEnrollRequestDTO(req, secret)); err != nil {
+			return "", "", s.logger.LogPropagate(err)
+		}
+	} else {
+		totpAgg.PendingSecret = secret
+		totpAgg.Timestamp.UpdatedAt = time.Now()
+		if _, err = s.repository.Update(s.ctx, totpAgg); err != nil {
+			return "", "", s.logger.LogPropagate(err)
+		}
+	}
+
+	return secret, s.totp.ProvisioningURI(secret, userAgg.Email), nil
+}
+
+// Confirm - will enable the second factor and issue the recovery codes (only hashes of them will be stored).
+func (s *SecondFactorService) Confirm(req dtointerface.ConfirmTotpRequest) (recoveryCodes []string, err error) {
+	// validation of input request
+	if err = s.validator.ValidateConfirmRequestDTO(req); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	totpAgg, err := s.find(req.GetUserID())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	if totpAgg != nil && totpAgg.IsEnabled() {
+		return nil, s.logger.LogPropagate(errtype.NewTotpIsAlreadyEnabledError())
+	}
+	if totpAgg == nil || totpAgg.PendingSecret == "" {
+		return nil, s.logger.LogPropagate(errtype.NewTotpEnrolmentWasNotStartedError())
+	}
+
+	// checking that user's authenticator app generates the same codes
+	step, valid := s.totp.Validate(totpAgg.PendingSecret, req.GetCode(), time.Now())
+	if !valid {
+		return nil, s.logger.LogPropagate(errtype.NewSecondFactorCodeIsInvalidError())
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	totpAgg.Secret = totpAgg.PendingSecret
+	totpAgg.PendingSecret = ""
+	totpAgg.RecoveryCodes = hashes
+	totpAgg.LastUsedStep = step
+	totpAgg.Timestamp.UpdatedAt = time.Now()
+
+	if _, err = s.repository.Update(s.ctx, totpAgg); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// Disable - will remove the second factor of user (one-time password or recovery code is required).
+func (s *SecondFactorService) Disable(req dtointerface.DisableTotpRequest) error {
+	// validation of input request
+	if err := s.validator.ValidateDisableRequestDTO(req); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	totpAgg, err := s.find(req.GetUserID())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if totpAgg == nil || !totpAgg.IsEnabled() {
+		return s.logger.LogPropagate(errtype.NewTotpIsNotEnabledError())
+	}
+
+	// fetching the user for counting the failed attempts of the account
+	userAgg, err := s.userService.Get(dto.NewUserGetRequestDTO(req.GetUserID(), ""))
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// checking that the account is not locked out after failed attempts (the codes must not be brute forced)
+	if err = s.throttler.Check(userAgg.Email, ""); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.verify(totpAgg, req.GetCode()); err != nil {
+		if errtype.IsSecondFactorCodeIsInvalidError(err) {
+			return s.logger.LogPropagate(s.fail(userAgg, err))
+		}
+		return s.logger.LogPropagate(err)
+	}
+
+	if err = s.repository.Remove(s.ctx, totpAgg); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// IsEnabled - checks whether the user must pass the second factor.
+func (s *SecondFactorService) IsEnabled(userID vo.ID) (enabled bool, err error) {
+	totpAgg, err := s.find(userID)
+	if err != nil {
+		return false, s.logger.LogPropagate(err)
+	}
+	return totpAgg != nil && totpAgg.IsEnabled(), nil
+}
+
+// Verify - will check the one-time password or recovery code of the user.
+func (s *SecondFactorService) Verify(userID vo.ID, code string) error {
+	totpAgg, err := s.find(userID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	if totpAgg == nil || !totpAgg.IsEnabled() {
+		return s.logger.LogPropagate(errtype.NewTotpIsNotEnabledError())
+	}
+
+	if err = s.verify(totpAgg, code); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
+
+// verify will check the code and mark the used step (or remove the used recovery code) atomically,
+// thus the same code cannot be used twice, even by the concurrent requests.
+func (s *SecondFactorService) verify(totpAgg *agg.Totp, code string) error {
+	var used bool
+	var err error
+	if step, valid := s.totp.Validate(totpAgg.Secret, code, time.Now()); valid {
+		used, err = s.repository.UseStep(s.ctx, totpAgg, step)
+	} else {
+		used, err = s.repository.UseRecoveryCode(s.ctx, totpAgg, helper.SHA256([]byte(s.normalizeRecoveryCode(code))))
+	}
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if !used {
+		// the code is wrong or was already used
+		return errtype.NewSecondFactorCodeIsInvalidError()
+	}
+
+	return nil
+}
+
+// fail will count the failed attempt and send the unlock letter if the account was locked out by it.
+// The given reason is returned as is, thus a caller is able to respond with it.
+func (s *SecondFactorService) fail(userAgg *agg.User, reason error) error {
+	locked, err := s.throttler.Fail(userAgg.Email, "", reason.Error())
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	if locked {
+		// the lockout is applied anyway, the letter failure is only logged
+		if err = s.accountService.SendAccountUnlock(userAgg); err != nil {
+			s.logger.Log(err)
+		}
+	}
+
+	return reason
+}
+
+// find will return the second factor state of user or nil if the user has never enrolled it.
+func (s *SecondFactorService) find(userID vo.ID) (*agg.Totp, error) {
+	totpAgg, err := s.repository.FindOneByUserID(s.ctx, dto.NewTotpGetRequestDTO(userID))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+	return totpAgg, nil
+}
+
+// generateRecoveryCodes will generate codes in the 'xxxxx-xxxxx' format and their hashes.
+func (s *SecondFactorService) generateRecoveryCodes() (codes []string, hashes []string, err error) {
+	codes = make([]string, 0, s.recoveryCodesNumber)
+	hashes = make([]string, 0, s.recoveryCodesNumber)
+
+	b := make([]byte, recoveryCodeLength)
+	for i := 0; i < s.recoveryCodesNumber; i++ {
+		if _, err = rand.Read(b); err != nil {
+			return nil, nil, s.logger.LogPropagate(err)
+		}
+		code := hex.EncodeToString(b)
+
+		codes = append(codes, code[:len(code)/2]+"-"+code[len(code)/2:])
+		hashes = append(hashes, helper.SHA256([]byte(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode removes the separator and whitespaces which a user could add while typing.
+func (s *SecondFactorService) normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+const tokenField = "token"
+
+type AccountValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewAccountValidator(serviceContainer diinterface.ServiceContainer) (*AccountValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountValidator{logger: loggerService}, nil
+}
+
+func (v *AccountValidator) ValidateEmailVerificationRequestDTO(req dtointerface.VerifyEmailRequest) error {
+	if req.GetToken() == "" {
+		return errtype.NewFieldCannotBeEmptyError(tokenField)
+	}
+	return nil
+}
+
+func (v *AccountValidator) ValidateEmailVerificationResendRequestDTO(
+	req dtointerface.ResendEmailVerificationRequest,
+) error {
+	if req.GetEmail() == "" {
+		return errtype.NewFieldCannotBeEmptyError(emailField)
+	}
+	return nil
+}
+
+func (v *AccountValidator) ValidatePasswordResetRequestDTO(req dtointerface.ResetPasswordRequest) error {
+	if req.GetEmail() == "" {
+		return errtype.NewFieldCannotBeEmptyError(emailField)
+	}
+	return nil
+}
+
+func (v *AccountValidator) ValidatePasswordResetConfirmRequestDTO(req dtointerface.ConfirmPasswordResetRequest) error {
+	if req.GetToken() == "" {
+		return errtype.NewFieldCannotBeEmptyError(tokenField)
+	}
+	return validatePassword(req.GetPassword())
+}
+
+func (v *AccountValidator) ValidateAccountUnlockRequestDTO(req dtointerface.UnlockAccountRequest) error {
+	if req.GetToken() == "" {
+		return errtype.NewFieldCannotBeEmptyError(tokenField)
+	}
+	return nil
+}
+
+// ValidateAdminAccountUnlockRequestDTO - at least one of email and ip must be provided.
+func (v *AccountValidator) ValidateAdminAccountUnlockRequestDTO(req dtointerface.AdminUnlockAccountRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetEmail() == "" && req.GetIP() == "" {
+		return errtype.NewFieldCannotBeEmptyError(emailField)
+	}
+	return nil
+}
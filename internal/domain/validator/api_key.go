@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"time"
+)
+
+const (
+	scopesField    = "scopes"
+	expiresAtField = "expiresAt"
+)
+
+type ApiKeyValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewApiKeyValidator(serviceContainer diinterface.ServiceContainer) (*ApiKeyValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApiKeyValidator{logger: loggerService}, nil
+}
+
+func (v *ApiKeyValidator) ValidateCreateRequestDTO(req dtointerface.CreateApiKeyRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetName() == "" {
+		return errtype.NewFieldCannotBeEmptyError(nameField)
+	}
+	if len(req.GetScopes()) == 0 {
+		return errtype.NewFieldCannotBeEmptyError(scopesField)
+	}
+	for _, scope := range req.GetScopes() {
+		if !v.isKnownScope(scope) {
+			return errtype.NewFieldValueIsNotAllowedError(scopesField, scope, enum.ApiKeyScopes...)
+		}
+	}
+	if req.GetExpiresAt() != "" {
+		expiresAt, err := helper.ParseTime(req.GetExpiresAt())
+		if err != nil {
+			return errtype.NewTimeParsingValidationError(req.GetExpiresAt())
+		}
+		if expiresAt.Before(time.Now()) {
+			return errtype.NewFieldMustBeInFutureError(expiresAtField)
+		}
+	}
+	return nil
+}
+
+func (v *ApiKeyValidator) ValidateListRequestDTO(req dtointerface.ListApiKeyRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *ApiKeyValidator) ValidateDeleteRequestDTO(req dtointerface.DeleteApiKeyRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *ApiKeyValidator) ValidateAggregate(agg *agg.ApiKey) error {
+	if agg.UserID.Value.IsZero() {
+		return errtype.NewInternalValidationError("api key agg was built with empty userID")
+	}
+	if agg.Name == "" {
+		return errtype.NewInternalValidationError("api key agg was built with empty name")
+	}
+	if agg.Hash == "" {
+		return errtype.NewInternalValidationError("api key agg was built with empty hash")
+	}
+	if len(agg.Scopes) == 0 {
+		return errtype.NewInternalValidationError("api key agg was built with empty scopes")
+	}
+	return nil
+}
+
+func (v *ApiKeyValidator) isKnownScope(scope string) bool {
+	for _, knownScope := range enum.ApiKeyScopes {
+		if scope == knownScope {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"net/http"
+	"strings"
 )
 
 type AuthValidator struct {
@@ -44,12 +45,17 @@ func (v *AuthValidator) ValidateAuthRequest(req dtointerface.AuthRequest) error
 	return nil
 }
 
-// ValidateTokennessRequest is method which will check that access token header exists.
+// ValidateTokennessRequest is method which will check that access token header (or api key) exists.
 func (v *AuthValidator) ValidateTokennessRequest(r *http.Request) error {
 	if token := r.Header.Get(enum.AccessTokenHeaderKey); token != "" {
 		return nil
 	}
 
+	if scheme, apiKey, found := strings.Cut(r.Header.Get(enum.AuthorizationHeaderKey), " "); found &&
+		strings.EqualFold(scheme, enum.ApiKeyAuthorizationScheme) && strings.TrimSpace(apiKey) != "" {
+		return nil
+	}
+
 	if _, err := r.Cookie(enum.AccessTokenHeaderKey); err == nil {
 		return nil
 	}
@@ -0,0 +1,14 @@
+package validatorinterface
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Account interface {
+	ValidateEmailVerificationRequestDTO(req dtointerface.VerifyEmailRequest) error
+	ValidateEmailVerificationResendRequestDTO(req dtointerface.ResendEmailVerificationRequest) error
+	ValidatePasswordResetRequestDTO(req dtointerface.ResetPasswordRequest) error
+	ValidatePasswordResetConfirmRequestDTO(req dtointerface.ConfirmPasswordResetRequest) error
+	ValidateAccountUnlockRequestDTO(req dtointerface.UnlockAccountRequest) error
+	ValidateAdminAccountUnlockRequestDTO(req dtointerface.AdminUnlockAccountRequest) error
+}
@@ -0,0 +1,13 @@
+package validatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type ApiKey interface {
+	ValidateCreateRequestDTO(req dtointerface.CreateApiKeyRequest) error
+	ValidateListRequestDTO(req dtointerface.ListApiKeyRequest) error
+	ValidateDeleteRequestDTO(req dtointerface.DeleteApiKeyRequest) error
+	ValidateAggregate(agg *agg.ApiKey) error
+}
@@ -0,0 +1,15 @@
+package validatorinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Playlist interface {
+	ValidateGetRequestDTO(req dtointerface.GetPlaylistRequest) error
+	ValidateListRequestDTO(req dtointerface.ListPlaylistRequest) error
+	ValidateCreateRequestDTO(req dtointerface.CreatePlaylistRequest) error
+	ValidateUpdateRequestDTO(req dtointerface.UpdatePlaylistRequest) error
+	ValidateDeleteRequestDTO(req dtointerface.DeletePlaylistRequest) error
+	ValidateAggregate(agg *agg.Playlist) error
+}
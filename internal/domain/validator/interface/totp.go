@@ -0,0 +1,11 @@
+package validatorinterface
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type Totp interface {
+	ValidateEnrollRequestDTO(req dtointerface.EnrollTotpRequest) error
+	ValidateConfirmRequestDTO(req dtointerface.ConfirmTotpRequest) error
+	ValidateDisableRequestDTO(req dtointerface.DisableTotpRequest) error
+}
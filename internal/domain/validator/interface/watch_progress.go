@@ -0,0 +1,9 @@
+package validatorinterface
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+)
+
+type WatchProgress interface {
+	ValidateListRequestDTO(req dtointerface.ListWatchProgressRequest) error
+}
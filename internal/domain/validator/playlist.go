@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+const (
+	videoIDsField = "videoIDs"
+	// maxPlaylistLength is a max. number of videos into the playlist.
+	maxPlaylistLength = 1000
+)
+
+type PlaylistValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewPlaylistValidator(serviceContainer diinterface.ServiceContainer) (*PlaylistValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaylistValidator{logger: loggerService}, nil
+}
+
+func (v *PlaylistValidator) ValidateGetRequestDTO(req dtointerface.GetPlaylistRequest) error {
+	if req.GetID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(idField)
+	}
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateListRequestDTO(req dtointerface.ListPlaylistRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetPage() <= 0 || req.GetLimit() <= 0 {
+		return errtype.NewInternalValidationError("fields 'page' and 'limit' must be greater than zero")
+	}
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateCreateRequestDTO(req dtointerface.CreatePlaylistRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetName() == "" {
+		return errtype.NewFieldCannotBeEmptyError(nameField)
+	}
+	if len(req.GetVideoIDs()) > maxPlaylistLength {
+		return errtype.NewFieldLengthMustBeMoreOrLessError(videoIDsField, false, maxPlaylistLength)
+	}
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateUpdateRequestDTO(req dtointerface.UpdatePlaylistRequest) error {
+	if err := v.ValidateGetRequestDTO(req); err != nil {
+		return err
+	}
+	if len(req.GetVideoIDs()) > maxPlaylistLength {
+		return errtype.NewFieldLengthMustBeMoreOrLessError(videoIDsField, false, maxPlaylistLength)
+	}
+	return nil
+}
+
+func (v *PlaylistValidator) ValidateDeleteRequestDTO(req dtointerface.DeletePlaylistRequest) error {
+	return v.ValidateGetRequestDTO(req)
+}
+
+func (v *PlaylistValidator) ValidateAggregate(agg *agg.Playlist) error {
+	if agg.Name == "" {
+		return errtype.NewInternalValidationError("'name' cannot be empty")
+	}
+	if agg.UserID.Value.IsZero() {
+		return errtype.NewInternalValidationError("'userID' cannot be empty")
+	}
+	for _, videoID := range agg.VideoIDs {
+		if videoID.Value.IsZero() {
+			return errtype.NewInternalValidationError("'videoIDs' cannot contain empty identifiers")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+const codeField = "code"
+
+type TotpValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewTotpValidator(serviceContainer diinterface.ServiceContainer) (*TotpValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TotpValidator{logger: loggerService}, nil
+}
+
+func (v *TotpValidator) ValidateEnrollRequestDTO(req dtointerface.EnrollTotpRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	return nil
+}
+
+func (v *TotpValidator) ValidateConfirmRequestDTO(req dtointerface.ConfirmTotpRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetCode() == "" {
+		return errtype.NewFieldCannotBeEmptyError(codeField)
+	}
+	return nil
+}
+
+func (v *TotpValidator) ValidateDisableRequestDTO(req dtointerface.DisableTotpRequest) error {
+	return v.ValidateConfirmRequestDTO(req)
+}
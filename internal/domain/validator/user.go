@@ -167,6 +167,11 @@ func (v *UserValidator) isValidEmail(email string) error {
 }
 
 func (v *UserValidator) isValidPassword(password string) error {
+	return validatePassword(password)
+}
+
+// validatePassword checks the password rules (it's shared with the password reset validation).
+func validatePassword(password string) error {
 	if password == "" {
 		return errtype.NewFieldCannotBeEmptyError(passwordField)
 	}
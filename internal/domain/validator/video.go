@@ -5,6 +5,7 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
@@ -12,6 +13,7 @@ import (
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	validatorinterface "github.com/Borislavv/video-streaming/internal/domain/validator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"slices"
 )
 
 const (
@@ -19,6 +21,9 @@ const (
 	userIDField     = "userID"
 	nameField       = "name"
 	resourceIDField = "resourceID"
+	sortField       = "sort"
+	afterField      = "after"
+	beforeField     = "before"
 )
 
 type VideoValidator struct {
@@ -88,6 +93,52 @@ func (v *VideoValidator) ValidateListRequestDTO(req dtointerface.ListVideoReques
 	if !req.GetCreatedAt().IsZero() && (!req.GetFrom().IsZero() || !req.GetTo().IsZero()) {
 		return errtype.NewInternalValidationError("field 'from' or 'to' cannot be passed with 'createdAt'")
 	}
+
+	sort := req.GetSort()
+	if sort == "" {
+		sort = enum.VideoDefaultSort
+	}
+	fields := vo.ParseSort(sort)
+	if len(fields) == 0 {
+		return errtype.NewFieldCannotBeEmptyError(sortField)
+	}
+	for i, field := range fields {
+		if !slices.Contains(enum.VideoSortFields, field.Name) {
+			return errtype.NewFieldValueIsNotAllowedError(sortField, field.Name, enum.VideoSortFields...)
+		}
+		for _, prev := range fields[:i] {
+			if prev.Name == field.Name {
+				return errtype.NewUniquenessCheckFailedError(sortField)
+			}
+		}
+	}
+
+	if req.GetAfter() != "" && req.GetBefore() != "" {
+		return errtype.NewFieldsCannotBePassedTogetherError(afterField, beforeField)
+	}
+	if req.GetAfter() != "" {
+		if err := v.validateCursor(req.GetAfter(), sort, len(fields)); err != nil {
+			return errtype.NewCursorIsInvalidError(afterField)
+		}
+	}
+	if req.GetBefore() != "" {
+		if err := v.validateCursor(req.GetBefore(), sort, len(fields)); err != nil {
+			return errtype.NewCursorIsInvalidError(beforeField)
+		}
+	}
+
+	return nil
+}
+
+// validateCursor will check that the cursor was made for the same sort of the list.
+func (v *VideoValidator) validateCursor(encoded string, sort string, fields int) error {
+	cursor, err := vo.DecodeCursor(encoded)
+	if err != nil {
+		return err
+	}
+	if cursor.Sort != sort || len(cursor.Values) != fields || cursor.ID.IsZero() {
+		return errtype.NewCursorIsInvalidError(sortField)
+	}
 	return nil
 }
 
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+)
+
+type WatchProgressValidator struct {
+	logger loggerinterface.Logger
+}
+
+func NewWatchProgressValidator(serviceContainer diinterface.ServiceContainer) (*WatchProgressValidator, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &WatchProgressValidator{logger: loggerService}, nil
+}
+
+func (v *WatchProgressValidator) ValidateListRequestDTO(req dtointerface.ListWatchProgressRequest) error {
+	if req.GetUserID().Value.IsZero() {
+		return errtype.NewFieldCannotBeEmptyError(userIDField)
+	}
+	if req.GetPage() <= 0 || req.GetLimit() <= 0 {
+		return errtype.NewInternalValidationError("fields 'page' and 'limit' must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package vo
+
+import (
+	"encoding/base64"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cursor is an opaque position of the item into the sorted list. It keeps the sort of the list, the values
+// of the sort fields of the item and its id (the id is the last sort field, thus the order is stable).
+// The values are encoded by BSON, thus their types are kept (dates, numbers and strings).
+type Cursor struct {
+	Sort   string             `bson:"s"`
+	Values bson.A             `bson:"v"`
+	ID     primitive.ObjectID `bson:"id"`
+}
+
+func NewCursor(sort string, values bson.A, id primitive.ObjectID) Cursor {
+	return Cursor{Sort: sort, Values: values, ID: id}
+}
+
+// Encode will return the url safe string of the cursor.
+func (c Cursor) Encode() (string, error) {
+	b, err := bson.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor will parse the cursor from the string made by Cursor.Encode.
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	c := Cursor{}
+	if err = bson.Unmarshal(b, &c); err != nil {
+		return Cursor{}, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,27 @@
+package vo
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"strings"
+)
+
+// SortField is a field of the list sort.
+type SortField struct {
+	Name       string
+	Descending bool
+}
+
+// ParseSort will split the sort of the list into the fields, example: "name,-createdAt".
+func ParseSort(sort string) []SortField {
+	fields := make([]SortField, 0, strings.Count(sort, enum.SortFieldsSeparator)+1)
+	for _, field := range strings.Split(sort, enum.SortFieldsSeparator) {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		fields = append(fields, SortField{
+			Name:       strings.TrimPrefix(field, enum.SortDescendingPrefix),
+			Descending: strings.HasPrefix(field, enum.SortDescendingPrefix),
+		})
+	}
+	return fields
+}
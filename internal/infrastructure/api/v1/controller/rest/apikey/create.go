@@ -0,0 +1,88 @@
+package apikey
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const CreatePath = "/api-key"
+
+type CreateController struct {
+	logger        loggerinterface.Logger
+	builder       builderinterface.ApiKey
+	service       apikeyinterface.CRUD
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
+}
+
+func NewCreateController(serviceContainer diinterface.ServiceContainer) (*CreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetApiKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyCRUDService, err := serviceContainer.GetApiKeyCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CreateController{
+		logger:        loggerService,
+		builder:       apiKeyBuilder,
+		service:       apiKeyCRUDService,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+// Create - is an endpoint for issue a new api key, the raw key is shown only in this response.
+func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	// api keys are managed only by access token, a key must not be able to manage keys
+	if err := c.authenticator.IsAuthedByToken(r); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	reqDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	apiKeyAgg, rawKey, err := c.service.Create(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.responder.Respond(w, c.builder.BuildResponseDTO(apiKeyAgg, rawKey))
+}
+
+func (c *CreateController) AddRoute(router *mux.Router) {
+	router.
+		Path(CreatePath).
+		HandlerFunc(c.Create).
+		Methods(http.MethodPost)
+}
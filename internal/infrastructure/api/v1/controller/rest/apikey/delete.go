@@ -0,0 +1,86 @@
+package apikey
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const DeletePath = "/api-key/{id}"
+
+type DeleteController struct {
+	logger        loggerinterface.Logger
+	builder       builderinterface.ApiKey
+	service       apikeyinterface.CRUD
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
+}
+
+func NewDeleteController(serviceContainer diinterface.ServiceContainer) (*DeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetApiKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyCRUDService, err := serviceContainer.GetApiKeyCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DeleteController{
+		logger:        loggerService,
+		builder:       apiKeyBuilder,
+		service:       apiKeyCRUDService,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+// Delete - is an endpoint for revoke the api key.
+func (c *DeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	// api keys are managed only by access token, a key must not be able to manage keys
+	if err := c.authenticator.IsAuthedByToken(r); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	reqDTO, err := c.builder.BuildDeleteRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Delete(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *DeleteController) AddRoute(router *mux.Router) {
+	router.
+		Path(DeletePath).
+		HandlerFunc(c.Delete).
+		Methods(http.MethodDelete)
+}
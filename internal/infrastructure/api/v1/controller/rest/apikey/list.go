@@ -0,0 +1,92 @@
+package apikey
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ListPath = "/api-key"
+
+type ListController struct {
+	logger        loggerinterface.Logger
+	builder       builderinterface.ApiKey
+	service       apikeyinterface.CRUD
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
+}
+
+func NewListController(serviceContainer diinterface.ServiceContainer) (*ListController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyBuilder, err := serviceContainer.GetApiKeyBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	apiKeyCRUDService, err := serviceContainer.GetApiKeyCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ListController{
+		logger:        loggerService,
+		builder:       apiKeyBuilder,
+		service:       apiKeyCRUDService,
+		authenticator: authService,
+		responder:     responseService,
+	}, nil
+}
+
+func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
+	// api keys are managed only by access token, a key must not be able to manage keys
+	if err := c.authenticator.IsAuthedByToken(r); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	reqDTO, err := c.builder.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	aggList, err := c.service.List(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	respList := make([]*dto.ApiKeyResponseDTO, 0, len(aggList))
+	for _, apiKeyAgg := range aggList {
+		respList = append(respList, c.builder.BuildResponseDTO(apiKeyAgg, ""))
+	}
+
+	c.responder.Respond(w, respList)
+}
+
+func (c *ListController) AddRoute(router *mux.Router) {
+	router.
+		Path(ListPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
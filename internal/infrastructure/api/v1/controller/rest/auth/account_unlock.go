@@ -0,0 +1,72 @@
+package auth
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const AccountUnlockPath = "/account/unlock"
+
+type AccountUnlockController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Account
+	service   accountinterface.Account
+	responder responseinterface.Responder
+}
+
+func NewAccountUnlockController(serviceContainer diinterface.ServiceContainer) (*AccountUnlockController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	accountBuilder, err := serviceContainer.GetAccountBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &AccountUnlockController{
+		logger:    loggerService,
+		builder:   accountBuilder,
+		service:   accountService,
+		responder: responseService,
+	}, nil
+}
+
+// Unlock - is an endpoint for unlock the account by the link from the unlock letter.
+func (c *AccountUnlockController) Unlock(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildAccountUnlockRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.UnlockAccount(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *AccountUnlockController) AddRoute(router *mux.Router) {
+	router.
+		Path(AccountUnlockPath).
+		HandlerFunc(c.Unlock).
+		Methods(http.MethodGet)
+}
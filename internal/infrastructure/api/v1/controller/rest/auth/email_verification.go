@@ -0,0 +1,72 @@
+package auth
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const EmailVerificationPath = "/registration/verification"
+
+type EmailVerificationController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Account
+	service   accountinterface.Account
+	responder responseinterface.Responder
+}
+
+func NewEmailVerificationController(serviceContainer diinterface.ServiceContainer) (*EmailVerificationController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	accountBuilder, err := serviceContainer.GetAccountBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &EmailVerificationController{
+		logger:    loggerService,
+		builder:   accountBuilder,
+		service:   accountService,
+		responder: responseService,
+	}, nil
+}
+
+// Verify - is an endpoint for activate the user by the link from the verification letter.
+func (c *EmailVerificationController) Verify(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildEmailVerificationRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.VerifyEmail(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *EmailVerificationController) AddRoute(router *mux.Router) {
+	router.
+		Path(EmailVerificationPath).
+		HandlerFunc(c.Verify).
+		Methods(http.MethodGet)
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const EmailVerificationResendPath = "/registration/verification"
+
+type EmailVerificationResendController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Account
+	service   accountinterface.Account
+	responder responseinterface.Responder
+}
+
+func NewEmailVerificationResendController(serviceContainer diinterface.ServiceContainer) (*EmailVerificationResendController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	accountBuilder, err := serviceContainer.GetAccountBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &EmailVerificationResendController{
+		logger:    loggerService,
+		builder:   accountBuilder,
+		service:   accountService,
+		responder: responseService,
+	}, nil
+}
+
+// Resend - is an endpoint for send the verification letter again (the response is the same for unknown emails).
+func (c *EmailVerificationResendController) Resend(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildEmailVerificationResendRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.ResendEmailVerification(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *EmailVerificationResendController) AddRoute(router *mux.Router) {
+	router.
+		Path(EmailVerificationResendPath).
+		HandlerFunc(c.Resend).
+		Methods(http.MethodPost)
+}
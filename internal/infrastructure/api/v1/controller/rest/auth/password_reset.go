@@ -0,0 +1,72 @@
+package auth
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const PasswordResetPath = "/password-reset"
+
+type PasswordResetController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Account
+	service   accountinterface.Account
+	responder responseinterface.Responder
+}
+
+func NewPasswordResetController(serviceContainer diinterface.ServiceContainer) (*PasswordResetController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	accountBuilder, err := serviceContainer.GetAccountBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PasswordResetController{
+		logger:    loggerService,
+		builder:   accountBuilder,
+		service:   accountService,
+		responder: responseService,
+	}, nil
+}
+
+// Reset - is an endpoint for send the password reset letter (the response is the same for unknown emails).
+func (c *PasswordResetController) Reset(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildPasswordResetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.ResetPassword(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (c *PasswordResetController) AddRoute(router *mux.Router) {
+	router.
+		Path(PasswordResetPath).
+		HandlerFunc(c.Reset).
+		Methods(http.MethodPost)
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
@@ -13,10 +14,11 @@ import (
 const RegistrationPath = "/registration"
 
 type RegistrationController struct {
-	logger    loggerinterface.Logger
-	builder   builderinterface.User
-	service   userinterface.CRUD
-	responder responseinterface.Responder
+	logger         loggerinterface.Logger
+	builder        builderinterface.User
+	service        userinterface.CRUD
+	accountService accountinterface.Account
+	responder      responseinterface.Responder
 }
 
 func NewRegistrationController(serviceContainer diinterface.ServiceContainer) (*RegistrationController, error) {
@@ -35,16 +37,22 @@ func NewRegistrationController(serviceContainer diinterface.ServiceContainer) (*
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	accountService, err := serviceContainer.GetAccountService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	responseService, err := serviceContainer.GetResponderService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &RegistrationController{
-		logger:    loggerService,
-		builder:   userBuilder,
-		service:   userCRUDService,
-		responder: responseService,
+		logger:         loggerService,
+		builder:        userBuilder,
+		service:        userCRUDService,
+		accountService: accountService,
+		responder:      responseService,
 	}, nil
 }
 
@@ -62,6 +70,11 @@ func (c *RegistrationController) Registration(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// the registration is not failed if the letter was not sent, the user is able to request it again
+	if err = c.accountService.SendEmailVerification(userAgg); err != nil {
+		c.logger.Log(err)
+	}
+
 	userRespDTO, err := c.builder.BuildResponseDTO(userAgg)
 	if err != nil {
 		c.responder.Respond(w, c.logger.LogPropagate(err))
@@ -0,0 +1,73 @@
+package playlist
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const CreatePath = "/playlist"
+
+type CreateController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Playlist
+	service   playlistinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewCreateController(serviceContainer diinterface.ServiceContainer) (*CreateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistCRUDService, err := serviceContainer.GetPlaylistCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &CreateController{
+		logger:    loggerService,
+		builder:   playlistBuilder,
+		service:   playlistCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *CreateController) Create(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildCreateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	playlistAgg, err := c.service.Create(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	c.responder.Respond(w, playlistAgg)
+}
+
+func (c *CreateController) AddRoute(router *mux.Router) {
+	router.
+		Path(CreatePath).
+		HandlerFunc(c.Create).
+		Methods(http.MethodPost)
+}
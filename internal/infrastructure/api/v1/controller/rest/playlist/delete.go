@@ -0,0 +1,71 @@
+package playlist
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const DeletePath = "/playlist/{id}"
+
+type DeleteController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Playlist
+	service   playlistinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewDeleteController(serviceContainer diinterface.ServiceContainer) (*DeleteController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistCRUDService, err := serviceContainer.GetPlaylistCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DeleteController{
+		logger:    loggerService,
+		builder:   playlistBuilder,
+		service:   playlistCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *DeleteController) Delete(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildDeleteRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Delete(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *DeleteController) AddRoute(router *mux.Router) {
+	router.
+		Path(DeletePath).
+		HandlerFunc(c.Delete).
+		Methods(http.MethodDelete)
+}
@@ -0,0 +1,81 @@
+package playlist
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ListPath = "/playlist"
+
+type ListController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Playlist
+	service   playlistinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewListController(serviceContainer diinterface.ServiceContainer) (*ListController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistCRUDService, err := serviceContainer.GetPlaylistCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ListController{
+		logger:    loggerService,
+		builder:   playlistBuilder,
+		service:   playlistCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	aggList, total, err := c.service.List(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w,
+		map[string]interface{}{
+			"list": aggList,
+			"pagination": map[string]interface{}{
+				"page":  reqDTO.Page,
+				"limit": reqDTO.Limit,
+				"total": total,
+			},
+		},
+	)
+}
+
+func (c *ListController) AddRoute(router *mux.Router) {
+	router.
+		Path(ListPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
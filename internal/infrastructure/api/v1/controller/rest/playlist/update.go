@@ -0,0 +1,72 @@
+package playlist
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const UpdatePath = "/playlist/{id}"
+
+type UpdateController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Playlist
+	service   playlistinterface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewUpdateController(serviceContainer diinterface.ServiceContainer) (*UpdateController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	playlistBuilder, err := serviceContainer.GetPlaylistBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistCRUDService, err := serviceContainer.GetPlaylistCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &UpdateController{
+		logger:    loggerService,
+		builder:   playlistBuilder,
+		service:   playlistCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *UpdateController) Update(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildUpdateRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	playlistAgg, err := c.service.Update(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, playlistAgg)
+}
+
+func (c *UpdateController) AddRoute(router *mux.Router) {
+	router.
+		Path(UpdatePath).
+		HandlerFunc(c.Update).
+		Methods(http.MethodPatch)
+}
@@ -0,0 +1,72 @@
+package progress
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	progressinterface "github.com/Borislavv/video-streaming/internal/domain/service/progress/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ContinueWatchingPath = "/me/continue-watching"
+
+type ContinueWatchingController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.WatchProgress
+	service   progressinterface.WatchProgress
+	responder responseinterface.Responder
+}
+
+func NewContinueWatchingController(serviceContainer diinterface.ServiceContainer) (*ContinueWatchingController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	watchProgressBuilder, err := serviceContainer.GetWatchProgressBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressService, err := serviceContainer.GetWatchProgressService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ContinueWatchingController{
+		logger:    loggerService,
+		builder:   watchProgressBuilder,
+		service:   watchProgressService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *ContinueWatchingController) List(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildListRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	respList, err := c.service.List(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, respList)
+}
+
+func (c *ContinueWatchingController) AddRoute(router *mux.Router) {
+	router.
+		Path(ContinueWatchingPath).
+		HandlerFunc(c.List).
+		Methods(http.MethodGet)
+}
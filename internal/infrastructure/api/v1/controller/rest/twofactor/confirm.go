@@ -0,0 +1,74 @@
+package twofactor
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const ConfirmPath = "/user/2fa/totp/confirm"
+
+type ConfirmController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Totp
+	service   twofactorinterface.SecondFactor
+	responder responseinterface.Responder
+}
+
+func NewConfirmController(serviceContainer diinterface.ServiceContainer) (*ConfirmController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	totpBuilder, err := serviceContainer.GetTotpBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	secondFactorService, err := serviceContainer.GetSecondFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ConfirmController{
+		logger:    loggerService,
+		builder:   totpBuilder,
+		service:   secondFactorService,
+		responder: responseService,
+	}, nil
+}
+
+// Confirm - is an endpoint for enable the second factor by the first code,
+// the recovery codes are shown only in this response.
+func (c *ConfirmController) Confirm(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildConfirmRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	recoveryCodes, err := c.service.Confirm(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, c.builder.BuildRecoveryCodesResponseDTO(recoveryCodes))
+}
+
+func (c *ConfirmController) AddRoute(router *mux.Router) {
+	router.
+		Path(ConfirmPath).
+		HandlerFunc(c.Confirm).
+		Methods(http.MethodPost)
+}
@@ -0,0 +1,72 @@
+package twofactor
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const DisablePath = "/user/2fa/totp"
+
+type DisableController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Totp
+	service   twofactorinterface.SecondFactor
+	responder responseinterface.Responder
+}
+
+func NewDisableController(serviceContainer diinterface.ServiceContainer) (*DisableController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	totpBuilder, err := serviceContainer.GetTotpBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	secondFactorService, err := serviceContainer.GetSecondFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &DisableController{
+		logger:    loggerService,
+		builder:   totpBuilder,
+		service:   secondFactorService,
+		responder: responseService,
+	}, nil
+}
+
+// Disable - is an endpoint for disable the second factor (one-time password or recovery code is required).
+func (c *DisableController) Disable(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildDisableRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	if err = c.service.Disable(reqDTO); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *DisableController) AddRoute(router *mux.Router) {
+	router.
+		Path(DisablePath).
+		HandlerFunc(c.Disable).
+		Methods(http.MethodDelete)
+}
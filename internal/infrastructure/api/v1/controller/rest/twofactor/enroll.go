@@ -0,0 +1,74 @@
+package twofactor
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+const EnrollPath = "/user/2fa/totp"
+
+type EnrollController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Totp
+	service   twofactorinterface.SecondFactor
+	responder responseinterface.Responder
+}
+
+func NewEnrollController(serviceContainer diinterface.ServiceContainer) (*EnrollController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	totpBuilder, err := serviceContainer.GetTotpBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	secondFactorService, err := serviceContainer.GetSecondFactorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &EnrollController{
+		logger:    loggerService,
+		builder:   totpBuilder,
+		service:   secondFactorService,
+		responder: responseService,
+	}, nil
+}
+
+// Enroll - is an endpoint for start the second factor enrolment, the response contains a secret
+// and otpauth:// URI for the authenticator app. The enrolment must be confirmed by the first code.
+func (c *EnrollController) Enroll(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildEnrollRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	secret, uri, err := c.service.Enroll(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	c.responder.Respond(w, c.builder.BuildEnrollmentResponseDTO(secret, uri))
+}
+
+func (c *EnrollController) AddRoute(router *mux.Router) {
+	router.
+		Path(EnrollPath).
+		HandlerFunc(c.Enroll).
+		Methods(http.MethodPost)
+}
@@ -3,6 +3,7 @@ package user
 import (
 	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
@@ -13,10 +14,11 @@ import (
 const DeletePath = "/user/{id}"
 
 type DeleteController struct {
-	logger    loggerinterface.Logger
-	builder   builderinterface.User
-	service   userinterface.CRUD
-	responder responseinterface.Responder
+	logger        loggerinterface.Logger
+	builder       builderinterface.User
+	service       userinterface.CRUD
+	authenticator authenticatorinterface.Authenticator
+	responder     responseinterface.Responder
 }
 
 func NewDeleteController(serviceContainer diinterface.ServiceContainer) (*DeleteController, error) {
@@ -35,16 +37,22 @@ func NewDeleteController(serviceContainer diinterface.ServiceContainer) (*Delete
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	authService, err := serviceContainer.GetAuthService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	responseService, err := serviceContainer.GetResponderService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &DeleteController{
-		logger:    loggerService,
-		builder:   userBuilder,
-		service:   userCRUDService,
-		responder: responseService,
+		logger:        loggerService,
+		builder:       userBuilder,
+		service:       userCRUDService,
+		authenticator: authService,
+		responder:     responseService,
 	}, nil
 }
 
@@ -55,6 +63,12 @@ func (c *DeleteController) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// deletion is a sensitive operation, the second factor must be passed recently (if it's enabled)
+	if err = c.authenticator.IsSecondFactorRecent(r, reqDTO.GetID()); err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
 	if err = c.service.Delete(reqDTO); err != nil {
 		c.responder.Respond(w, c.logger.LogPropagate(err))
 		return
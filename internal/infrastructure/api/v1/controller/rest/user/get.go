@@ -1,33 +1,21 @@
 package user
 
 import (
-	"encoding/json"
-	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
-	"github.com/Borislavv/video-streaming/internal/domain/dto"
-	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
-	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	userinterface "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
-	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	"github.com/gorilla/mux"
 	"net/http"
-	"reflect"
-	"time"
 )
 
-const (
-	GetPath  = "/user"
-	cacheTTL = time.Minute * 60
-)
+const GetPath = "/user"
 
 type GetController struct {
 	logger    loggerinterface.Logger
 	builder   builderinterface.User
 	service   userinterface.CRUD
-	cacher    cacherinterface.Cacher
 	responder responseinterface.Responder
 }
 
@@ -37,11 +25,6 @@ func NewGetController(serviceContainer diinterface.ServiceContainer) (*GetContro
 		return nil, err
 	}
 
-	cacheService, err := serviceContainer.GetCacheService()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	userBuilder, err := serviceContainer.GetUserBuilder()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -62,7 +45,6 @@ func NewGetController(serviceContainer diinterface.ServiceContainer) (*GetContro
 		builder:   userBuilder,
 		service:   userCRUDService,
 		responder: responseService,
-		cacher:    cacheService,
 	}, nil
 }
 
@@ -73,7 +55,7 @@ func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userAgg, err := c.getCached(userReqDTO)
+	userAgg, err := c.service.Get(userReqDTO)
 	if err != nil {
 		c.responder.Respond(w, c.logger.LogPropagate(err))
 		return
@@ -88,35 +70,6 @@ func (c *GetController) Get(w http.ResponseWriter, r *http.Request) {
 	c.responder.Respond(w, userRespDTO)
 }
 
-func (c *GetController) getCached(reqDTO *dto.UserGetRequestDTO) (*agg.User, error) {
-	key, err := json.Marshal(reqDTO)
-	if err != nil {
-		return nil, c.logger.LogPropagate(err)
-	}
-
-	cacheKey := helper.MD5(key)
-
-	data, err := c.cacher.Get(
-		cacheKey,
-		func(item cacherinterface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(cacheTTL)
-			return c.service.Get(reqDTO)
-		},
-	)
-	if err != nil {
-		return nil, c.logger.LogPropagate(err)
-	}
-
-	userAgg, ok := data.(*agg.User)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.User{}), reflect.TypeOf(data),
-		)
-	}
-
-	return userAgg, err
-}
-
 func (c *GetController) AddRoute(router *mux.Router) {
 	router.
 		Path(GetPath).
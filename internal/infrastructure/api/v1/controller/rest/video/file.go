@@ -0,0 +1,91 @@
+package video
+
+import (
+	builderinterface "github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
+	"github.com/gorilla/mux"
+	"net/http"
+	"os"
+)
+
+const FilePath = "/video/{id}/file"
+
+// FileController delivers the resource file of the video over HTTP. The file is copied into the response
+// without intermediate buffers, thus the kernel sends it right into the socket.
+type FileController struct {
+	logger    loggerinterface.Logger
+	builder   builderinterface.Video
+	service   videointerface.CRUD
+	responder responseinterface.Responder
+}
+
+func NewFileController(serviceContainer diinterface.ServiceContainer) (*FileController, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoBuilder, err := serviceContainer.GetVideoBuilder()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoCRUDService, err := serviceContainer.GetVideoCRUDService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	responseService, err := serviceContainer.GetResponderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &FileController{
+		logger:    loggerService,
+		builder:   videoBuilder,
+		service:   videoCRUDService,
+		responder: responseService,
+	}, nil
+}
+
+func (c *FileController) File(w http.ResponseWriter, r *http.Request) {
+	reqDTO, err := c.builder.BuildGetRequestDTOFromRequest(r)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	videoAgg, err := c.service.Get(reqDTO)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	file, err := os.Open(videoAgg.Resource.GetFilepath())
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	stat, err := file.Stat()
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
+	// the ranges (including multiple ones), the conditional requests and HEAD are handled by the standard library,
+	// the file is still copied right into the socket (sendfile/splice)
+	w.Header().Set("Content-Type", videoAgg.Resource.GetFiletype())
+	http.ServeContent(w, r, videoAgg.Resource.GetFilename(), stat.ModTime(), file)
+}
+
+func (c *FileController) AddRoute(router *mux.Router) {
+	router.
+		Path(FilePath).
+		HandlerFunc(c.File).
+		Methods(http.MethodGet, http.MethodHead)
+}
@@ -1,12 +1,16 @@
 package video
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/builder/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	videointerface "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	responseinterface "github.com/Borislavv/video-streaming/internal/infrastructure/api/v1/response/interface"
 	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
 	"net/http"
 )
 
@@ -61,6 +65,12 @@ func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	next, prev, err := c.links(r, reqDTO, aggList)
+	if err != nil {
+		c.responder.Respond(w, c.logger.LogPropagate(err))
+		return
+	}
+
 	// TODO must be refactored to paginated list DTO.
 	c.responder.Respond(w,
 		map[string]interface{}{
@@ -69,11 +79,66 @@ func (c *ListController) List(w http.ResponseWriter, r *http.Request) {
 				"page":  reqDTO.Page,
 				"limit": reqDTO.Limit,
 				"total": total,
+				"sort":  reqDTO.Sort,
+				"next":  next,
+				"prev":  prev,
 			},
 		},
 	)
 }
 
+// links will make the links of the next and the previous pages by the cursors of the last and the first items
+// of the list (the link is empty when there is no such page). The full page means that the next page may exist.
+func (c *ListController) links(
+	r *http.Request, reqDTO *dto.VideoListRequestDTO, list []*agg.Video,
+) (next string, prev string, err error) {
+	if len(list) == 0 {
+		return "", "", nil
+	}
+
+	full := reqDTO.Limit > 0 && len(list) == reqDTO.Limit
+
+	hasNext := full
+	hasPrev := reqDTO.After != "" || reqDTO.Page > 1
+	if reqDTO.Before != "" {
+		hasNext, hasPrev = true, full
+	}
+
+	if hasNext {
+		if next, err = c.link(r, reqDTO.Sort, "after", list[len(list)-1]); err != nil {
+			return "", "", err
+		}
+	}
+	if hasPrev {
+		if prev, err = c.link(r, reqDTO.Sort, "before", list[0]); err != nil {
+			return "", "", err
+		}
+	}
+
+	return next, prev, nil
+}
+
+// link will make the link of the current request with the cursor of the video instead of the page.
+func (c *ListController) link(r *http.Request, sort string, param string, video *agg.Video) (string, error) {
+	values := bson.A{}
+	for _, field := range vo.ParseSort(sort) {
+		values = append(values, video.SortValue(field.Name))
+	}
+
+	cursor, err := vo.NewCursor(sort, values, video.ID.Value).Encode()
+	if err != nil {
+		return "", err
+	}
+
+	query := r.URL.Query()
+	query.Del("page")
+	query.Del("after")
+	query.Del("before")
+	query.Set(param, cursor)
+
+	return r.URL.Path + "?" + query.Encode(), nil
+}
+
 func (c *ListController) AddRoute(router *mux.Router) {
 	router.
 		Path(ListPath).
@@ -8,12 +8,19 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
+	accountinterface "github.com/Borislavv/video-streaming/internal/domain/service/account/interface"
+	apikeyinterface "github.com/Borislavv/video-streaming/internal/domain/service/apikey/interface"
 	authenticatorinterface "github.com/Borislavv/video-streaming/internal/domain/service/authenticator/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	extractorinterface "github.com/Borislavv/video-streaming/internal/domain/service/extractor/interface"
+	mailerinterface "github.com/Borislavv/video-streaming/internal/domain/service/mailer/interface"
+	playlistinterface "github.com/Borislavv/video-streaming/internal/domain/service/playlist/interface"
+	progressinterface "github.com/Borislavv/video-streaming/internal/domain/service/progress/interface"
 	resourceservice "github.com/Borislavv/video-streaming/internal/domain/service/resource/interface"
 	securityinterface "github.com/Borislavv/video-streaming/internal/domain/service/security/interface"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
 	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	twofactorinterface "github.com/Borislavv/video-streaming/internal/domain/service/twofactor/interface"
 	uploaderinterface "github.com/Borislavv/video-streaming/internal/domain/service/uploader/interface"
 	userservice "github.com/Borislavv/video-streaming/internal/domain/service/user/interface"
 	videoservice "github.com/Borislavv/video-streaming/internal/domain/service/video/interface"
@@ -23,12 +30,19 @@ import (
 	cacheinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/cache/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	limiterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/limiter/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	handlerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	strategyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/strategy/interface"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	partyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	queueinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
 	fileinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/uploader/file/interface"
 	"go.mongodb.org/mongo-driver/mongo"
 	"reflect"
@@ -148,6 +162,45 @@ func (s *ServiceContainer) GetBlockedTokenMongoRepository() (mongodbinterface.Bl
 	return repo, nil
 }
 
+func (s *ServiceContainer) GetApiKeyMongoRepository() (mongodbinterface.ApiKey, error) {
+	key := (*mongodbinterface.ApiKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodbinterface.ApiKey)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTotpMongoRepository() (mongodbinterface.Totp, error) {
+	key := (*mongodbinterface.Totp)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodbinterface.Totp)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetActionTokenMongoRepository() (mongodbinterface.ActionToken, error) {
+	key := (*mongodbinterface.ActionToken)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mongodbinterface.ActionToken)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainer) GetResourceCacheRepository() (cacheinterface.Resource, error) {
 	key := (*cacheinterface.Resource)(nil)
 	service, err := s.Get(reflect.TypeOf(key))
@@ -408,6 +461,318 @@ func (s *ServiceContainer) GetAuthService() (authenticatorinterface.Authenticato
 	return service, nil
 }
 
+func (s *ServiceContainer) GetApiKeyBuilder() (builderinterface.ApiKey, error) {
+	key := (*builderinterface.ApiKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.ApiKey)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetWatchProgressBuilder() (builderinterface.WatchProgress, error) {
+	key := (*builderinterface.WatchProgress)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.WatchProgress)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaylistBuilder() (builderinterface.Playlist, error) {
+	key := (*builderinterface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Playlist)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetApiKeyValidator() (validatorinterface.ApiKey, error) {
+	key := (*validatorinterface.ApiKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.ApiKey)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetWatchProgressValidator() (validatorinterface.WatchProgress, error) {
+	key := (*validatorinterface.WatchProgress)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.WatchProgress)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaylistValidator() (validatorinterface.Playlist, error) {
+	key := (*validatorinterface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Playlist)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetApiKeyRepository() (repositoryinterface.ApiKey, error) {
+	key := (*repositoryinterface.ApiKey)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.ApiKey)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetApiKeyCRUDService() (apikeyinterface.CRUD, error) {
+	key := (*apikeyinterface.CRUD)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(apikeyinterface.CRUD)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetWatchProgressService() (progressinterface.WatchProgress, error) {
+	key := (*progressinterface.WatchProgress)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(progressinterface.WatchProgress)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaylistCRUDService() (playlistinterface.CRUD, error) {
+	key := (*playlistinterface.CRUD)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(playlistinterface.CRUD)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTotpBuilder() (builderinterface.Totp, error) {
+	key := (*builderinterface.Totp)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Totp)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTotpValidator() (validatorinterface.Totp, error) {
+	key := (*validatorinterface.Totp)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Totp)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetTotpRepository() (repositoryinterface.Totp, error) {
+	key := (*repositoryinterface.Totp)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Totp)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetSecondFactorService() (twofactorinterface.SecondFactor, error) {
+	key := (*twofactorinterface.SecondFactor)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(twofactorinterface.SecondFactor)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAccountBuilder() (builderinterface.Account, error) {
+	key := (*builderinterface.Account)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(builderinterface.Account)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAccountValidator() (validatorinterface.Account, error) {
+	key := (*validatorinterface.Account)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(validatorinterface.Account)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetActionTokenRepository() (repositoryinterface.ActionToken, error) {
+	key := (*repositoryinterface.ActionToken)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.ActionToken)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaybackSessionRepository() (repositoryinterface.PlaybackSession, error) {
+	key := (*repositoryinterface.PlaybackSession)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.PlaybackSession)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetWatchProgressRepository() (repositoryinterface.WatchProgress, error) {
+	key := (*repositoryinterface.WatchProgress)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.WatchProgress)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaylistRepository() (repositoryinterface.Playlist, error) {
+	key := (*repositoryinterface.Playlist)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.Playlist)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAccountService() (accountinterface.Account, error) {
+	key := (*accountinterface.Account)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(accountinterface.Account)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAuthAttemptRepository() (repositoryinterface.AuthAttempt, error) {
+	key := (*repositoryinterface.AuthAttempt)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.AuthAttempt)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAuthAuditRepository() (repositoryinterface.AuthAudit, error) {
+	key := (*repositoryinterface.AuthAudit)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(repositoryinterface.AuthAudit)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetAuthThrottlerService() (throttlerinterface.AuthThrottler, error) {
+	key := (*throttlerinterface.AuthThrottler)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(throttlerinterface.AuthThrottler)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainer) GetLoggerService() (loggerinterface.Logger, error) {
 	key := (*loggerinterface.Logger)(nil)
 	reflectService, err := s.Get(reflect.TypeOf(key))
@@ -525,6 +890,19 @@ func (s *ServiceContainer) GetPasswordHasherService() (securityinterface.Passwor
 	return service, nil
 }
 
+func (s *ServiceContainer) GetTotpService() (securityinterface.Totp, error) {
+	key := (*securityinterface.Totp)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(securityinterface.Totp)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainer) GetTokenizerService() (tokenizerinterface.Tokenizer, error) {
 	key := (*tokenizerinterface.Tokenizer)(nil)
 	reflectService, err := s.Get(reflect.TypeOf(key))
@@ -538,6 +916,19 @@ func (s *ServiceContainer) GetTokenizerService() (tokenizerinterface.Tokenizer,
 	return service, nil
 }
 
+func (s *ServiceContainer) GetMailerService() (mailerinterface.Mailer, error) {
+	key := (*mailerinterface.Mailer)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(mailerinterface.Mailer)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
 func (s *ServiceContainer) GetWebSocketCommunicatorService() (protointerface.Communicator, error) {
 	key := (*protointerface.Communicator)(nil)
 	reflectService, err := s.Get(reflect.TypeOf(key))
@@ -615,3 +1006,94 @@ func (s *ServiceContainer) GetStreamingService() (streamerinterface.Streamer, er
 	}
 	return service, nil
 }
+
+func (s *ServiceContainer) GetStreamingLimiterService() (limiterinterface.StreamingLimiter, error) {
+	key := (*limiterinterface.StreamingLimiter)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(limiterinterface.StreamingLimiter)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetStreamShaperService() (shaperinterface.Shaper, error) {
+	key := (*shaperinterface.Shaper)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(shaperinterface.Shaper)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetConnectionRegistryService() (registryinterface.Registry, error) {
+	key := (*registryinterface.Registry)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(registryinterface.Registry)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaybackSessionsService() (sessioninterface.Sessions, error) {
+	key := (*sessioninterface.Sessions)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(sessioninterface.Sessions)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetPlaybackQueuesService() (queueinterface.Queues, error) {
+	key := (*queueinterface.Queues)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(queueinterface.Queues)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetLiveBroadcastsService() (liveinterface.Broadcasts, error) {
+	key := (*liveinterface.Broadcasts)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(liveinterface.Broadcasts)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
+
+func (s *ServiceContainer) GetWatchPartiesService() (partyinterface.Parties, error) {
+	key := (*partyinterface.Parties)(nil)
+	reflectService, err := s.Get(reflect.TypeOf(key))
+	if err != nil {
+		return nil, errtype.NewServiceWasNotFoundIntoContainerError(reflect.TypeOf(key))
+	}
+	service, ok := reflectService.Interface().(partyinterface.Parties)
+	if !ok {
+		return nil, errtype.NewTypesMismatchedServiceContainerError(reflect.TypeOf(reflectService), reflect.TypeOf(key))
+	}
+	return service, nil
+}
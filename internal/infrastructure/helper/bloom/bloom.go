@@ -0,0 +1,80 @@
+package bloom
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// Filter is a thread-safe bloom filter of strings. It may answer that a key is present while it's not
+// (with configured probability), but never answers that a present key is absent.
+type Filter struct {
+	mu     sync.RWMutex
+	bits   []uint64
+	size   uint64 // number of bits
+	hashes uint64 // number of hash functions
+}
+
+// New makes a filter which is sized for given number of keys and false positive rate.
+func New(capacity int, falsePositiveRate float64) *Filter {
+	if capacity < 1 {
+		capacity = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	// optimal number of bits: m = -n*ln(p) / ln(2)^2, hash functions: k = m/n * ln(2)
+	size := uint64(math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/float64(capacity)*math.Ln2)))
+
+	return &Filter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// Add puts the key into the filter.
+func (f *Filter) Add(key string) {
+	h1, h2 := f.hash(key)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MightContain checks whether the key may be present into the filter (false means that it's absent for sure).
+func (f *Filter) MightContain(key string) bool {
+	h1, h2 := f.hash(key)
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hash returns two independent hashes of the key which are combined for emulate k hash functions
+// (the double hashing technique by Kirsch and Mitzenmacher).
+func (f *Filter) hash(key string) (h1 uint64, h2 uint64) {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	h1 = hash.Sum64()
+
+	hash.Reset()
+	_, _ = hash.Write([]byte{0x9e})
+	_, _ = hash.Write([]byte(key))
+	h2 = hash.Sum64() | 1 // odd step never degenerates into the same bit
+
+	return h1, h2
+}
@@ -0,0 +1,13 @@
+package helper
+
+import "net"
+
+// RemoteHost returns the host part of a remote address like "127.0.0.1:54321" (or the address as is,
+// if it has no port). The port is dropped because each connection of the same client has a new one.
+func RemoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -7,10 +7,11 @@ import (
 )
 
 const (
-	StaticDirPath    = "/public/statics/"
-	TemplatesDirPath = "/html/"
-	ResourcesDirPath = "/public/resources/"
-	LogsDirPath      = "/var/log/"
+	StaticDirPath     = "/public/statics/"
+	TemplatesDirPath  = "/html/"
+	ResourcesDirPath  = "/public/resources/"
+	LogsDirPath       = "/var/log/"
+	MailOutboxDirPath = "/var/mail/"
 )
 
 func TemplatePath(template string, dirs ...string) (string, error) {
@@ -37,6 +38,10 @@ func LogsDir() (string, error) {
 	return path(LogsDirPath)
 }
 
+func MailOutboxDir() (string, error) {
+	return path(MailOutboxDirPath)
+}
+
 // path is a function which builts any path from root dir.
 func path(additionalPath string) (string, error) {
 	root, err := os.Getwd()
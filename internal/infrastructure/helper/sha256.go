@@ -0,0 +1,14 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+func SHA256(p []byte) string {
+	hash := sha256.New()
+	if _, err := hash.Write(p); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
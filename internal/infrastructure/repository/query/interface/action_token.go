@@ -0,0 +1,6 @@
+package queryinterface
+
+type FindOneActionTokenByHash interface {
+	GetHash() string
+	GetAction() string
+}
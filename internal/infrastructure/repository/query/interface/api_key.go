@@ -0,0 +1,16 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneApiKeyByID interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindOneApiKeyByHash interface {
+	GetHash() string
+}
+
+type FindApiKeyList interface {
+	GetUserID() vo.ID
+}
@@ -0,0 +1,13 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOnePlaylistByID interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+}
+
+type FindPlaylistList interface {
+	GetUserID() vo.ID
+	Pagination
+}
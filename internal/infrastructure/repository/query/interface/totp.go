@@ -0,0 +1,7 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindOneTotpByUserID interface {
+	GetUserID() vo.ID
+}
@@ -0,0 +1,10 @@
+package queryinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type FindWatchProgressList interface {
+	GetUserID() vo.ID
+	GetCompleted() bool
+	GetPage() int
+	GetLimit() int
+}
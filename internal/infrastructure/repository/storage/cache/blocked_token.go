@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper/bloom"
+	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
+	"sync/atomic"
+	"time"
+)
+
+// revocationKeyPrefix is a prefix of the filter key which means that the user has a revocation of all tokens.
+const revocationKeyPrefix = "revocation:"
+
+// BlockedTokenRepository keeps an in-process bloom filter of blocked tokens in front of the storage,
+// thus the verification of the token which is not blocked (the most frequent case) doesn't hit the storage.
+type BlockedTokenRepository struct {
+	mongodbinterface.BlockedToken
+	logger              loggerinterface.Logger
+	filter              atomic.Pointer[bloom.Filter]
+	filterCapacity      int
+	filterFalsePositive float64
+	syncInterval        time.Duration
+	rebuildInterval     time.Duration
+	syncedAt            time.Time
+	rebuiltAt           time.Time
+}
+
+func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*BlockedTokenRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	blockedTokenMongoDbRepository, err := serviceContainer.GetBlockedTokenMongoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	syncInterval, err := time.ParseDuration(cfg.BlockedTokensSyncInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	rebuildInterval, err := time.ParseDuration(cfg.BlockedTokensFilterRebuildInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &BlockedTokenRepository{
+		BlockedToken:        blockedTokenMongoDbRepository,
+		logger:              loggerService,
+		filterCapacity:      cfg.BlockedTokensFilterCapacity,
+		filterFalsePositive: cfg.BlockedTokensFilterFalsePositiveRate,
+		syncInterval:        syncInterval,
+		rebuildInterval:     rebuildInterval,
+	}
+
+	// the filter must be complete before the first lookup, otherwise blocked tokens will be accepted
+	if err = r.rebuild(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	go r.syncing(ctx)
+
+	return r, nil
+}
+
+func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedToken) error {
+	if err := r.BlockedToken.Insert(ctx, token); err != nil {
+		return r.logger.LogPropagate(err)
+	}
+
+	r.add(token)
+
+	return nil
+}
+
+// Has checks the filter first, the storage is requested only if the token may be blocked.
+func (r *BlockedTokenRepository) Has(ctx context.Context, hash string) (found bool, err error) {
+	if !r.filter.Load().MightContain(hash) {
+		return false, nil
+	}
+	return r.BlockedToken.Has(ctx, hash)
+}
+
+// HasRevocation checks the filter first, the storage is requested only if the user may have a revocation.
+func (r *BlockedTokenRepository) HasRevocation(ctx context.Context, userID vo.ID, issuedAt time.Time) (bool, error) {
+	if !r.filter.Load().MightContain(revocationKeyPrefix + userID.Value.Hex()) {
+		return false, nil
+	}
+	return r.BlockedToken.HasRevocation(ctx, userID, issuedAt)
+}
+
+// syncing will load the tokens which were blocked by other instances and rebuild the filter periodically.
+func (r *BlockedTokenRepository) syncing(ctx context.Context) {
+	ticker := time.NewTicker(r.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			var err error
+			if time.Since(r.rebuiltAt) >= r.rebuildInterval {
+				err = r.rebuild(ctx)
+			} else {
+				err = r.sync(ctx)
+			}
+			if err != nil {
+				r.logger.Log(err)
+			}
+		}
+	}
+}
+
+// rebuild will make a new filter from all not expired blocked tokens and swap the current one.
+func (r *BlockedTokenRepository) rebuild(ctx context.Context) error {
+	startedAt := time.Now()
+
+	tokens, err := r.BlockedToken.FindBlockedSince(ctx, time.Time{})
+	if err != nil {
+		return r.logger.LogPropagate(err)
+	}
+
+	filter := bloom.New(r.filterCapacity, r.filterFalsePositive)
+	for _, token := range tokens {
+		r.addInto(filter, token)
+	}
+	r.filter.Store(filter)
+
+	r.rebuiltAt, r.syncedAt = startedAt, startedAt
+
+	return nil
+}
+
+// sync will add the tokens which were blocked since the previous sync. The previous interval is requested again
+// because records may become visible with a delay (and the clocks of instances may differ a bit).
+func (r *BlockedTokenRepository) sync(ctx context.Context) error {
+	startedAt := time.Now()
+
+	tokens, err := r.BlockedToken.FindBlockedSince(ctx, r.syncedAt.Add(-r.syncInterval))
+	if err != nil {
+		return r.logger.LogPropagate(err)
+	}
+
+	for _, token := range tokens {
+		r.add(token)
+	}
+	r.syncedAt = startedAt
+
+	return nil
+}
+
+func (r *BlockedTokenRepository) add(token *agg.BlockedToken) {
+	r.addInto(r.filter.Load(), token)
+}
+
+func (r *BlockedTokenRepository) addInto(filter *bloom.Filter, token *agg.BlockedToken) {
+	if token.Value == "" {
+		filter.Add(revocationKeyPrefix + token.UserID.Value.Hex())
+		return
+	}
+	filter.Add(token.Value)
+}
@@ -2,23 +2,21 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
-	"reflect"
-	"time"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/typed"
 )
 
 type ResourceRepository struct {
 	mongodbinterface.Resource
 	logger loggerinterface.Logger
 	cache  cacherinterface.Cacher
+	byID   *typed.Cache[queryinterface.FindOneResourceByID, *agg.Resource]
 }
 
 func NewResourceRepository(serviceContainer diinterface.ServiceContainer) (*ResourceRepository, error) {
@@ -41,45 +39,44 @@ func NewResourceRepository(serviceContainer diinterface.ServiceContainer) (*Reso
 		Resource: mongoRepository,
 		logger:   loggerService,
 		cache:    cacheService,
+		byID: typed.NewCache[queryinterface.FindOneResourceByID, *agg.Resource](
+			cacheService, "resource:id", typed.JSONKey[queryinterface.FindOneResourceByID],
+		),
 	}, nil
 }
 
 func (r *ResourceRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneResourceByID) (*agg.Resource, error) {
-	// attempt to fetch data from cache
-	if resource, err := r.findOneByID(ctx, q); err == nil {
-		return resource, nil
-	}
-	// fetch data from storage if an error occurred
-	return r.Resource.FindOneByID(ctx, q)
-}
-
-func (r *ResourceRepository) findOneByID(ctx context.Context, q queryinterface.FindOneResourceByID) (*agg.Resource, error) {
-	p, err := json.Marshal(q)
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
-	}
-	cacheKey := helper.MD5(p)
+	// attempt to fetch data from cache (the not found result is cached as well)
+	resourceAgg, err := r.byID.Get(q, func(item cacherinterface.CacheItem) (*agg.Resource, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
 
-	resourceInterface, err := r.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
-		item.SetTTL(time.Hour)
-
-		resourceAgg, err := r.Resource.FindOneByID(ctx, q)
+		resourceAgg, err := r.Resource.FindOneByID(detached(ctx), q)
 		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				item.SetNegativeTTL(notFoundTTL)
+				item.SetTags(resourceTag(q.GetID()))
+			}
 			return nil, r.logger.LogPropagate(err)
 		}
+		item.SetTags(resourceTag(resourceAgg.ID))
 
 		return resourceAgg, nil
 	})
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+	if err == nil || errtype.IsEntityNotFoundError(err) {
+		return resourceAgg, err
 	}
+	// fetch data from storage if an error occurred
+	return r.Resource.FindOneByID(ctx, q)
+}
 
-	resourceAgg, ok := resourceInterface.(*agg.Resource)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.Resource{}), reflect.TypeOf(resourceInterface),
-		)
+// Remove will remove the resource from storage and drop the cached entries of one.
+func (r *ResourceRepository) Remove(ctx context.Context, resource *agg.Resource) error {
+	if err := r.Resource.Remove(ctx, resource); err != nil {
+		return r.logger.LogPropagate(err)
 	}
 
-	return resourceAgg, nil
+	r.cache.InvalidateTags(resourceTag(resource.ID))
+
+	return nil
 }
@@ -0,0 +1,31 @@
+package cache
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+// The cached entries are tagged by the entities which they are built from, thus the writes of the repositories
+// invalidate only the dependent entries.
+
+// videoTag marks the entries which contain the video.
+func videoTag(id vo.ID) string {
+	return "video:" + id.Value.Hex()
+}
+
+// userVideosTag marks the entries which depend on the set of videos of the user (e.g. list pages).
+func userVideosTag(userID vo.ID) string {
+	return "videos:user:" + userID.Value.Hex()
+}
+
+// userTag marks the entries which contain the user.
+func userTag(id vo.ID) string {
+	return "user:" + id.Value.Hex()
+}
+
+// userEmailTag marks the not found results of the user lookups by email.
+func userEmailTag(email string) string {
+	return "user:email:" + email
+}
+
+// resourceTag marks the entries which contain the resource.
+func resourceTag(id vo.ID) string {
+	return "resource:" + id.Value.Hex()
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// entryTTL is a max. lifetime of the cached entry.
+	entryTTL = time.Hour
+	// entrySoftTTL is a lifetime after which the cached entry is refreshed in background (it's served meanwhile).
+	entrySoftTTL = time.Minute
+	// notFoundTTL is a lifetime of the cached not found result. It's short, because the entries which are
+	// not found by other fields than identifier cannot be invalidated precisely.
+	notFoundTTL = time.Second * 5
+)
+
+// detached will return the context which is not canceled with the request, because the entry may be refreshed
+// in background after the request is finished (the queries are limited by timeouts of the storage repositories).
+func detached(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
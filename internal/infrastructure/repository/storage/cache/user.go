@@ -2,23 +2,22 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
-	"reflect"
-	"time"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/typed"
 )
 
 type UserRepository struct {
 	mongodbinterface.User
-	logger loggerinterface.Logger
-	cache  cacherinterface.Cacher
+	logger  loggerinterface.Logger
+	cache   cacherinterface.Cacher
+	byID    *typed.Cache[queryinterface.FindOneUserByID, *agg.User]
+	byEmail *typed.Cache[queryinterface.FindOneUserByEmail, *agg.User]
 }
 
 func NewUserRepository(serviceContainer diinterface.ServiceContainer) (*UserRepository, error) {
@@ -41,93 +40,98 @@ func NewUserRepository(serviceContainer diinterface.ServiceContainer) (*UserRepo
 		logger: loggerService,
 		cache:  cacheService,
 		User:   userMongoDbRepository,
+		byID: typed.NewCache[queryinterface.FindOneUserByID, *agg.User](
+			cacheService, "user:id", typed.JSONKey[queryinterface.FindOneUserByID],
+		),
+		byEmail: typed.NewCache[queryinterface.FindOneUserByEmail, *agg.User](
+			cacheService, "user:email", typed.JSONKey[queryinterface.FindOneUserByEmail],
+		),
 	}, nil
 }
 
 func (r *UserRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneUserByID) (*agg.User, error) {
-	// attempt to fetch data from cache
-	if user, err := r.findOneByID(ctx, q); err == nil {
-		return user, nil
+	// attempt to fetch data from cache (the not found result is cached as well)
+	userAgg, err := r.byID.Get(q, func(item cacherinterface.CacheItem) (*agg.User, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
+
+		userAgg, err := r.User.FindOneByID(detached(ctx), q)
+		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				item.SetNegativeTTL(notFoundTTL)
+				item.SetTags(userTag(q.GetID()))
+			}
+			return nil, r.logger.LogPropagate(err)
+		}
+		item.SetTags(userTag(userAgg.ID))
+
+		return userAgg, nil
+	})
+	if err == nil || errtype.IsEntityNotFoundError(err) {
+		return userAgg, err
 	}
 	// fetch data from storage if an error occurred
 	return r.User.FindOneByID(ctx, q)
 }
 
-func (r *UserRepository) findOneByID(ctx context.Context, q queryinterface.FindOneUserByID) (*agg.User, error) {
-	// building a cache key
-	p, err := json.Marshal(q)
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
+func (r *UserRepository) FindOneByEmail(ctx context.Context, q queryinterface.FindOneUserByEmail) (*agg.User, error) {
+	// attempt to fetch data from cache (the not found result is cached as well)
+	userAgg, err := r.byEmail.Get(q, func(item cacherinterface.CacheItem) (*agg.User, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
+
+		userAgg, err := r.User.FindOneByEmail(detached(ctx), q)
+		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				item.SetNegativeTTL(notFoundTTL)
+				item.SetTags(userEmailTag(q.GetEmail()))
+			}
+			return nil, r.logger.LogPropagate(err)
+		}
+		item.SetTags(userTag(userAgg.ID))
+
+		return userAgg, nil
+	})
+	if err == nil || errtype.IsEntityNotFoundError(err) {
+		return userAgg, err
 	}
-	cacheKey := helper.MD5(p)
-
-	// fetching data from cache/storage
-	userInterface, err := r.cache.Get(
-		cacheKey,
-		func(item cacherinterface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+	// fetch data from storage if an error occurred
+	return r.User.FindOneByEmail(ctx, q)
+}
 
-			userAgg, err := r.User.FindOneByID(ctx, q)
-			if err != nil {
-				return false, r.logger.LogPropagate(err)
-			}
-			return userAgg, nil
-		})
+// Insert will save the user into storage and drop the cached not found result of the lookup by email,
+// otherwise the registered user cannot be found by email until the result is expired.
+func (r *UserRepository) Insert(ctx context.Context, user *agg.User) (*agg.User, error) {
+	userAgg, err := r.User.Insert(ctx, user)
 	if err != nil {
 		return nil, r.logger.LogPropagate(err)
 	}
 
-	// casting found data to struct
-	userAgg, ok := userInterface.(*agg.User)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.User{}), reflect.TypeOf(userInterface),
-		)
-	}
+	r.cache.InvalidateTags(userEmailTag(user.Email))
 
 	return userAgg, nil
 }
 
-func (r *UserRepository) FindOneByEmail(ctx context.Context, q queryinterface.FindOneUserByEmail) (*agg.User, error) {
-	// attempt to fetch data from cache
-	if user, err := r.findOneByEmail(ctx, q); err == nil {
-		return user, nil
-	}
-	// fetch data from storage if an error occurred
-	return r.User.FindOneByEmail(ctx, q)
-}
-
-func (r *UserRepository) findOneByEmail(ctx context.Context, q queryinterface.FindOneUserByEmail) (user *agg.User, err error) {
-	// building a cache key
-	p, err := json.Marshal(q)
+// Update will update the user into storage and drop the cached entries of one,
+// otherwise changed credentials (or status) will not be applied until the entries are expired.
+func (r *UserRepository) Update(ctx context.Context, user *agg.User) (*agg.User, error) {
+	userAgg, err := r.User.Update(ctx, user)
 	if err != nil {
 		return nil, r.logger.LogPropagate(err)
 	}
-	cacheKey := helper.MD5(p)
 
-	// fetching data from cache/storage
-	userInterface, err := r.cache.Get(
-		cacheKey,
-		func(item cacherinterface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+	r.cache.InvalidateTags(userTag(user.ID), userEmailTag(user.Email))
 
-			userAgg, err := r.User.FindOneByEmail(ctx, q)
-			if err != nil {
-				return nil, r.logger.LogPropagate(err)
-			}
-			return userAgg, nil
-		})
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
-	}
+	return userAgg, nil
+}
 
-	// casting found data to struct
-	userAgg, ok := userInterface.(*agg.User)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.User{}), reflect.TypeOf(userInterface),
-		)
+// Remove will remove the user from storage and drop the cached entries of one.
+func (r *UserRepository) Remove(ctx context.Context, user *agg.User) error {
+	if err := r.User.Remove(ctx, user); err != nil {
+		return r.logger.LogPropagate(err)
 	}
 
-	return userAgg, nil
+	r.cache.InvalidateTags(userTag(user.ID))
+
+	return nil
 }
@@ -2,23 +2,30 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
 	mongodbinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/storage/mongodb/interface"
-	"reflect"
-	"time"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/typed"
 )
 
+// videoListPage is a cached page of the videos list.
+type videoListPage struct {
+	List  []*agg.Video
+	Total int64
+}
+
 type VideoRepository struct {
 	mongodbinterface.Video
-	logger loggerinterface.Logger
-	cache  cacherinterface.Cacher
+	logger       loggerinterface.Logger
+	cache        cacherinterface.Cacher
+	byID         *typed.Cache[queryinterface.FindOneVideoByID, *agg.Video]
+	byName       *typed.Cache[queryinterface.FindOneVideoByName, *agg.Video]
+	byResourceID *typed.Cache[queryinterface.FindOneVideoByResourceID, *agg.Video]
+	list         *typed.Cache[queryinterface.FindVideoList, videoListPage]
 }
 
 func NewVideoRepository(serviceContainer diinterface.ServiceContainer) (*VideoRepository, error) {
@@ -38,179 +45,151 @@ func NewVideoRepository(serviceContainer diinterface.ServiceContainer) (*VideoRe
 	}
 
 	return &VideoRepository{
-		cache:  cacheService,
 		logger: loggerService,
+		cache:  cacheService,
 		Video:  videoMongoDbRepository,
+		byID: typed.NewCache[queryinterface.FindOneVideoByID, *agg.Video](
+			cacheService, "video:id", typed.JSONKey[queryinterface.FindOneVideoByID],
+		),
+		byName: typed.NewCache[queryinterface.FindOneVideoByName, *agg.Video](
+			cacheService, "video:name", typed.JSONKey[queryinterface.FindOneVideoByName],
+		),
+		byResourceID: typed.NewCache[queryinterface.FindOneVideoByResourceID, *agg.Video](
+			cacheService, "video:resource", typed.JSONKey[queryinterface.FindOneVideoByResourceID],
+		),
+		list: typed.NewCache[queryinterface.FindVideoList, videoListPage](
+			cacheService, "video:list", typed.JSONKey[queryinterface.FindVideoList],
+		),
 	}, nil
 }
 
 func (r *VideoRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneVideoByID) (*agg.Video, error) {
-	// attempt to fetch data from cache
-	if video, err := r.findOneByID(ctx, q); err == nil {
-		return video, nil
-	}
-	// fetch data from storage if an error occurred
-	return r.Video.FindOneByID(ctx, q)
-}
-
-func (r *VideoRepository) findOneByID(ctx context.Context, q queryinterface.FindOneVideoByID) (*agg.Video, error) {
-	p, err := json.Marshal(q)
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
-	}
-	cacheKey := helper.MD5(p)
+	// attempt to fetch data from cache (the not found result is cached as well)
+	videoAgg, err := r.byID.Get(q, func(item cacherinterface.CacheItem) (*agg.Video, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
 
-	// fetching data from cache/storage
-	videoInterface, err := r.cache.Get(
-		cacheKey,
-		func(item cacherinterface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
-
-			videoAgg, err := r.Video.FindOneByID(ctx, q)
-			if err != nil {
-				return nil, r.logger.LogPropagate(err)
+		videoAgg, err := r.Video.FindOneByID(detached(ctx), q)
+		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				item.SetNegativeTTL(notFoundTTL)
+				item.SetTags(videoTag(q.GetID()), userVideosTag(q.GetUserID()))
 			}
-			return videoAgg, nil
-		})
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
-	}
+			return nil, r.logger.LogPropagate(err)
+		}
+		item.SetTags(videoTag(videoAgg.ID))
 
-	// casting found data to struct
-	videoAgg, ok := videoInterface.(*agg.Video)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.User{}), reflect.TypeOf(videoInterface),
-		)
+		return videoAgg, nil
+	})
+	if err == nil || errtype.IsEntityNotFoundError(err) {
+		return videoAgg, err
 	}
-
-	return videoAgg, nil
+	// fetch data from storage if an error occurred
+	return r.Video.FindOneByID(ctx, q)
 }
 
 func (r *VideoRepository) FindList(ctx context.Context, q queryinterface.FindVideoList) (list []*agg.Video, total int64, err error) {
 	// attempt to fetch data from cache
-	if list, total, err = r.findList(ctx, q); err == nil {
-		return list, total, nil
+	page, err := r.list.Get(q, func(item cacherinterface.CacheItem) (videoListPage, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
+
+		l, t, e := r.Video.FindList(detached(ctx), q)
+		if e != nil {
+			return videoListPage{}, r.logger.LogPropagate(e)
+		}
+		item.SetTags(userVideosTag(q.GetUserID()))
+
+		return videoListPage{List: l, Total: t}, nil
+	})
+	if err == nil {
+		return page.List, page.Total, nil
 	}
 	// fetch data from storage if an error occurred
 	return r.Video.FindList(ctx, q)
 }
 
-func (r *VideoRepository) findList(ctx context.Context, q queryinterface.FindVideoList) (list []*agg.Video, total int64, err error) {
-	p, err := json.Marshal(q)
-	if err != nil {
-		return nil, 0, r.logger.LogPropagate(err)
-	}
-	cacheKey := helper.MD5(p)
-
-	type response struct {
-		List  []*agg.Video
-		Total int64
-	}
-
-	responseInterface, err := r.cache.Get(
-		cacheKey,
-		func(item cacherinterface.CacheItem) (data interface{}, err error) {
-			item.SetTTL(time.Hour)
+func (r *VideoRepository) FindOneByName(ctx context.Context, q queryinterface.FindOneVideoByName) (*agg.Video, error) {
+	// attempt to fetch data from cache (the not found result is cached as well)
+	videoAgg, err := r.byName.Get(q, func(item cacherinterface.CacheItem) (*agg.Video, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
 
-			l, t, e := r.Video.FindList(ctx, q)
-			if err != nil {
-				return nil, r.logger.LogPropagate(e)
+		videoAgg, err := r.Video.FindOneByName(detached(ctx), q)
+		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				item.SetNegativeTTL(notFoundTTL)
+				item.SetTags(userVideosTag(q.GetUserID()))
 			}
+			return nil, r.logger.LogPropagate(err)
+		}
+		item.SetTags(videoTag(videoAgg.ID))
 
-			return response{List: l, Total: t}, nil
-		},
-	)
-	if err != nil {
-		return nil, 0, r.logger.LogPropagate(err)
-	}
-
-	listResponse, ok := responseInterface.(response)
-	if !ok {
-		return nil, 0, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(response{}), reflect.TypeOf(responseInterface),
-		)
-	}
-
-	return listResponse.List, listResponse.Total, nil
-}
-
-func (r *VideoRepository) FindOneByName(ctx context.Context, q queryinterface.FindOneVideoByName) (*agg.Video, error) {
-	// attempt to fetch data from cache
-	if video, err := r.findOneByName(ctx, q); err == nil {
-		return video, nil
+		return videoAgg, nil
+	})
+	if err == nil || errtype.IsEntityNotFoundError(err) {
+		return videoAgg, err
 	}
 	// fetch data from storage if an error occurred
 	return r.Video.FindOneByName(ctx, q)
 }
 
-func (r *VideoRepository) findOneByName(ctx context.Context, q queryinterface.FindOneVideoByName) (*agg.Video, error) {
-	p, err := json.Marshal(q)
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
-	}
-	cacheKey := helper.MD5(p)
-
-	videoInterface, err := r.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
-		item.SetTTL(time.Hour)
+func (r *VideoRepository) FindOneByResourceID(ctx context.Context, q queryinterface.FindOneVideoByResourceID) (*agg.Video, error) {
+	// attempt to fetch data from cache (the not found result is cached as well)
+	videoAgg, err := r.byResourceID.Get(q, func(item cacherinterface.CacheItem) (*agg.Video, error) {
+		item.SetTTL(entryTTL)
+		item.SetSoftTTL(entrySoftTTL)
 
-		videoAgg, err := r.Video.FindOneByName(ctx, q)
+		videoAgg, err := r.Video.FindOneByResourceID(detached(ctx), q)
 		if err != nil {
+			if errtype.IsEntityNotFoundError(err) {
+				item.SetNegativeTTL(notFoundTTL)
+				item.SetTags(userVideosTag(q.GetUserID()))
+			}
 			return nil, r.logger.LogPropagate(err)
 		}
+		item.SetTags(videoTag(videoAgg.ID))
 
 		return videoAgg, nil
 	})
-	if err != nil {
-		return nil, r.logger.LogPropagate(err)
-	}
-
-	videoAgg, ok := videoInterface.(*agg.Video)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.Video{}), reflect.TypeOf(videoInterface),
-		)
-	}
-
-	return videoAgg, nil
-}
-
-func (r *VideoRepository) FindOneByResourceID(ctx context.Context, q queryinterface.FindOneVideoByResourceID) (*agg.Video, error) {
-	// attempt to fetch data from cache
-	if video, err := r.findOneByResourceID(ctx, q); err == nil {
-		return video, nil
+	if err == nil || errtype.IsEntityNotFoundError(err) {
+		return videoAgg, err
 	}
 	// fetch data from storage if an error occurred
 	return r.Video.FindOneByResourceID(ctx, q)
 }
 
-func (r *VideoRepository) findOneByResourceID(ctx context.Context, q queryinterface.FindOneVideoByResourceID) (*agg.Video, error) {
-	p, err := json.Marshal(q)
+// Insert will save the video into storage and drop the cached list pages of the owner.
+func (r *VideoRepository) Insert(ctx context.Context, video *agg.Video) (*agg.Video, error) {
+	videoAgg, err := r.Video.Insert(ctx, video)
 	if err != nil {
 		return nil, r.logger.LogPropagate(err)
 	}
-	cacheKey := helper.MD5(p)
 
-	videoInterface, err := r.cache.Get(cacheKey, func(item cacherinterface.CacheItem) (data interface{}, err error) {
-		item.SetTTL(time.Hour)
+	r.cache.InvalidateTags(userVideosTag(video.UserID))
 
-		videoAgg, err := r.Video.FindOneByResourceID(ctx, q)
-		if err != nil {
-			return nil, r.logger.LogPropagate(err)
-		}
+	return videoAgg, nil
+}
 
-		return videoAgg, nil
-	})
+// Update will update the video into storage and drop the cached entries of one with the list pages of the owner.
+func (r *VideoRepository) Update(ctx context.Context, video *agg.Video) (*agg.Video, error) {
+	videoAgg, err := r.Video.Update(ctx, video)
 	if err != nil {
 		return nil, r.logger.LogPropagate(err)
 	}
 
-	videoAgg, ok := videoInterface.(*agg.Video)
-	if !ok {
-		return nil, errtype.NewCachedDataTypeWasNotMatchedError(
-			cacheKey, reflect.TypeOf(&agg.Video{}), reflect.TypeOf(videoInterface),
-		)
-	}
+	r.cache.InvalidateTags(videoTag(video.ID), userVideosTag(video.UserID))
 
 	return videoAgg, nil
 }
+
+// Remove will remove the video from storage and drop the cached entries of one with the list pages of the owner.
+func (r *VideoRepository) Remove(ctx context.Context, video *agg.Video) error {
+	if err := r.Video.Remove(ctx, video); err != nil {
+		return r.logger.LogPropagate(err)
+	}
+
+	r.cache.InvalidateTags(videoTag(video.ID), userVideosTag(video.UserID))
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const ActionTokenCollection = "actionTokens"
+
+var (
+	ActionTokenNotFoundByHashError  = errtype.NewEntityNotFoundError("action token", "hash")
+	ActionTokenInsertingFailedError = errtype.NewInternalRepositoryError("unable to store 'action token' or get inserted 'id'")
+)
+
+type ActionTokenRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewActionTokenRepository(serviceContainer diinterface.ServiceContainer) (*ActionTokenRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &ActionTokenRepository{
+		db:      mongodb.Collection(ActionTokenCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *ActionTokenRepository) Insert(ctx context.Context, token *agg.ActionToken) (*agg.ActionToken, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, token, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		token.ID = vo.NewID(oid)
+		return token, nil
+	}
+
+	return nil, r.logger.CriticalPropagate(ActionTokenInsertingFailedError)
+}
+
+// Consume will find and remove the token in one operation, thus the one cannot be used twice.
+func (r *ActionTokenRepository) Consume(
+	ctx context.Context, q queryinterface.FindOneActionTokenByHash,
+) (*agg.ActionToken, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"hash":   q.GetHash(),
+		"action": q.GetAction(),
+	}
+
+	token := &agg.ActionToken{}
+	if err := r.db.FindOneAndDelete(qCtx, filter).Decode(token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(ActionTokenNotFoundByHashError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return token, nil
+}
+
+func (r *ActionTokenRepository) RemoveByUser(ctx context.Context, userID vo.ID, action string) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id": userID.Value,
+		"action":   action,
+	}
+
+	if _, err := r.db.DeleteMany(qCtx, filter); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that lookup by hash is served by index and expired tokens are removed by storage.
+func (r *ActionTokenRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user._id", Value: 1}, {Key: "action", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const ApiKeysCollection = "apiKeys"
+
+var (
+	ApiKeyNotFoundByIdError    = errtype.NewEntityNotFoundError("api key", "id")
+	ApiKeyNotFoundByHashError  = errtype.NewEntityNotFoundError("api key", "hash")
+	ApiKeyInsertingFailedError = errtype.NewInternalRepositoryError("unable to store 'api key' or get inserted 'id'")
+	ApiKeyWasNotDeletedError   = errtype.NewInternalValidationError("api key was not deleted")
+)
+
+type ApiKeyRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewApiKeyRepository(serviceContainer diinterface.ServiceContainer) (*ApiKeyRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &ApiKeyRepository{
+		db:      mongodb.Collection(ApiKeysCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *ApiKeyRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneApiKeyByID) (*agg.ApiKey, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":      q.GetID().Value,
+		"user._id": q.GetUserID().Value,
+	}
+
+	key := &agg.ApiKey{}
+	if err := r.db.FindOne(qCtx, filter).Decode(key); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(ApiKeyNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return key, nil
+}
+
+func (r *ApiKeyRepository) FindOneByHash(ctx context.Context, q queryinterface.FindOneApiKeyByHash) (*agg.ApiKey, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"hash": q.GetHash()}
+
+	key := &agg.ApiKey{}
+	if err := r.db.FindOne(qCtx, filter).Decode(key); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(ApiKeyNotFoundByHashError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return key, nil
+}
+
+func (r *ApiKeyRepository) FindList(ctx context.Context, q queryinterface.FindApiKeyList) (list []*agg.ApiKey, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list = []*agg.ApiKey{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+func (r *ApiKeyRepository) Insert(ctx context.Context, key *agg.ApiKey) (*agg.ApiKey, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, key, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		return r.FindOneByID(qCtx, dto.NewApiKeyGetRequestDTO(vo.NewID(oid), "", key.UserID))
+	}
+
+	return nil, r.logger.CriticalPropagate(ApiKeyInsertingFailedError)
+}
+
+// Touch will set up the last usage time of the key.
+func (r *ApiKeyRepository) Touch(ctx context.Context, key *agg.ApiKey) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.UpdateByID(qCtx, key.ID.Value, bson.M{"$set": bson.M{"lastUsedAt": key.LastUsedAt}}); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *ApiKeyRepository) Remove(ctx context.Context, key *agg.ApiKey) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": key.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the key is really deleted
+		return r.logger.CriticalPropagate(ApiKeyWasNotDeletedError)
+	}
+
+	return nil
+}
+
+// RemoveByUser will remove all keys of the user, for example when the password was reset.
+func (r *ApiKeyRepository) RemoveByUser(ctx context.Context, userID vo.ID) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.DeleteMany(qCtx, bson.M{"user._id": userID.Value}); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that lookup by hash (which is performed on each request) is served by index.
+func (r *ApiKeyRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "hash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user._id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
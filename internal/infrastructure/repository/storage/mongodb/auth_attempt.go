@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const AuthAttemptCollection = "authAttempts"
+
+var (
+	AuthAttemptNotFoundByKeyError = errtype.NewEntityNotFoundError("auth attempt", "key")
+)
+
+type AuthAttemptRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewAuthAttemptRepository(serviceContainer diinterface.ServiceContainer) (*AuthAttemptRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &AuthAttemptRepository{
+		db:      mongodb.Collection(AuthAttemptCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *AuthAttemptRepository) FindOneByKey(ctx context.Context, key string) (*agg.AuthAttempt, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// expired counters are removed by storage with a delay, so they are skipped explicitly
+	filter := bson.M{
+		"key":       key,
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+
+	attempt := &agg.AuthAttempt{}
+	if err := r.db.FindOne(qCtx, filter).Decode(attempt); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(AuthAttemptNotFoundByKeyError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return attempt, nil
+}
+
+// IncrementFailures will count a failed attempt by key in one operation, thus concurrent attempts are not lost.
+func (r *AuthAttemptRepository) IncrementFailures(
+	ctx context.Context, key string, expiresAt time.Time,
+) (*agg.AuthAttempt, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+
+	// the expired counter must not be incremented, counting is started from scratch
+	if _, err := r.db.DeleteOne(qCtx, bson.M{"key": key, "expiresAt": bson.M{"$lte": now}}); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	update := bson.M{
+		"$inc":         bson.M{"failures": 1},
+		"$max":         bson.M{"expiresAt": expiresAt},
+		"$set":         bson.M{"updatedAt": now},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	attempt := &agg.AuthAttempt{}
+	if err := r.db.FindOneAndUpdate(qCtx, bson.M{"key": key}, update, opts).Decode(attempt); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return attempt, nil
+}
+
+// Update will store the backoff and lockout of the counter (failures are changed by IncrementFailures only).
+func (r *AuthAttemptRepository) Update(ctx context.Context, attempt *agg.AuthAttempt) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"blockedUntil": attempt.BlockedUntil,
+			"lockedUntil":  attempt.LockedUntil,
+			"updatedAt":    time.Now(),
+		},
+		"$max": bson.M{"expiresAt": attempt.ExpiresAt},
+	}
+
+	if _, err := r.db.UpdateByID(qCtx, attempt.ID.Value, update); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+func (r *AuthAttemptRepository) RemoveByKey(ctx context.Context, key string) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.DeleteOne(qCtx, bson.M{"key": key}); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that each key has a single counter and expired counters are removed by storage.
+func (r *AuthAttemptRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
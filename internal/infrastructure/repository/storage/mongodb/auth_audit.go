@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const AuthAuditCollection = "authAudit"
+
+var (
+	AuthAuditInsertingFailedError = errtype.NewInternalRepositoryError("unable to store 'auth audit' or get inserted 'id'")
+)
+
+type AuthAuditRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewAuthAuditRepository(serviceContainer diinterface.ServiceContainer) (*AuthAuditRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &AuthAuditRepository{
+		db:      mongodb.Collection(AuthAuditCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *AuthAuditRepository) Insert(ctx context.Context, audit *agg.AuthAudit) (*agg.AuthAudit, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, audit, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		audit.ID = vo.NewID(oid)
+		return audit, nil
+	}
+
+	return nil, r.logger.CriticalPropagate(AuthAuditInsertingFailedError)
+}
+
+// createIndexes makes sure that entries are searchable by user, email and address and old entries are removed.
+func (r *AuthAuditRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "user._id", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "email", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "ip", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,6 +24,8 @@ type BlockedTokenRepository struct {
 	mu      *sync.Mutex
 	logger  loggerinterface.Logger
 	timeout time.Duration
+	// tokenLifetime is a max. lifetime of the token, used for expire the records which were stored without it.
+	tokenLifetime time.Duration
 }
 
 func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*BlockedTokenRepository, error) {
@@ -30,6 +34,11 @@ func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*
 		return nil, err
 	}
 
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	mongodb, err := serviceContainer.GetMongoDatabase()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -45,12 +54,23 @@ func NewBlockedTokenRepository(serviceContainer diinterface.ServiceContainer) (*
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	return &BlockedTokenRepository{
-		db:      mongodb.Collection(BlockedTokensCollection),
-		logger:  loggerService,
-		mu:      &sync.Mutex{},
-		timeout: timeout,
-	}, nil
+	r := &BlockedTokenRepository{
+		db:            mongodb.Collection(BlockedTokensCollection),
+		logger:        loggerService,
+		mu:            &sync.Mutex{},
+		timeout:       timeout,
+		tokenLifetime: time.Second * time.Duration(cfg.JwtTokenExpiresAfter),
+	}
+
+	if err = r.migrate(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
 }
 
 func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedToken) error {
@@ -73,11 +93,11 @@ func (r *BlockedTokenRepository) Insert(ctx context.Context, token *agg.BlockedT
 	return nil
 }
 
-func (r *BlockedTokenRepository) Has(ctx context.Context, token string) (found bool, err error) {
+func (r *BlockedTokenRepository) Has(ctx context.Context, hash string) (found bool, err error) {
 	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	filter := bson.M{"value": token}
+	filter := bson.M{"value": hash}
 
 	if err = r.db.FindOne(qCtx, filter).Decode(&agg.BlockedToken{}); err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -88,3 +108,117 @@ func (r *BlockedTokenRepository) Has(ctx context.Context, token string) (found b
 
 	return true, nil
 }
+
+// HasRevocation checks whether all tokens of the user which were issued before given time are revoked
+// (the revocation is stored as a blocked token without value, see agg.NewUserTokensRevocation).
+// Both times are in seconds, the token which was issued in the same second as the revocation is not revoked.
+func (r *BlockedTokenRepository) HasRevocation(ctx context.Context, userID vo.ID, issuedAt time.Time) (found bool, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  userID.Value,
+		"value":     "",
+		"blockedAt": bson.M{"$gt": issuedAt.Truncate(time.Second)},
+	}
+
+	if err = r.db.FindOne(qCtx, filter).Decode(&agg.BlockedToken{}); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+		return false, r.logger.ErrorPropagate(err)
+	}
+
+	return true, nil
+}
+
+// FindBlockedSince returns the not expired blocked tokens and revocations which were blocked since given time.
+func (r *BlockedTokenRepository) FindBlockedSince(ctx context.Context, since time.Time) ([]*agg.BlockedToken, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"blockedAt": bson.M{"$gte": since},
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetProjection(bson.M{"value": 1, "user": 1})
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	var tokens []*agg.BlockedToken
+	if err = c.All(qCtx, &tokens); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return tokens, nil
+}
+
+// migrate will hash the raw tokens and set up expiration time for the records which were stored
+// before these fields were introduced (the max. lifetime of token is used because the original one is unknown).
+func (r *BlockedTokenRepository) migrate(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	c, err := r.db.Find(qCtx, bson.M{"expiresAt": bson.M{"$exists": false}})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	for c.Next(qCtx) {
+		token := &agg.BlockedToken{}
+		if err = c.Decode(token); err != nil {
+			return r.logger.ErrorPropagate(err)
+		}
+
+		value := token.Value
+		if value != "" {
+			value = helper.SHA256([]byte(value))
+		}
+
+		update := bson.M{"$set": bson.M{
+			"value":     value,
+			"expiresAt": token.BlockedAt.Add(r.tokenLifetime),
+		}}
+		if _, err = r.db.UpdateByID(qCtx, token.ID.Value, update); err != nil {
+			return r.logger.ErrorPropagate(err)
+		}
+	}
+
+	if err = c.Err(); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that lookups of the hot path are served by indexes and expired records are removed.
+func (r *BlockedTokenRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "value", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "user._id", Value: 1}, {Key: "value", Value: 1}, {Key: "blockedAt", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "blockedAt", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
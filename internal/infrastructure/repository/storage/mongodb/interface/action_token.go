@@ -0,0 +1,16 @@
+package mongodbinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type ActionToken interface {
+	Insert(ctx context.Context, token *agg.ActionToken) (*agg.ActionToken, error)
+	// Consume will find and remove the token, thus the one cannot be used twice.
+	Consume(ctx context.Context, q queryinterface.FindOneActionTokenByHash) (*agg.ActionToken, error)
+	// RemoveByUser will remove all tokens of the user with given action.
+	RemoveByUser(ctx context.Context, userID vo.ID, action string) error
+}
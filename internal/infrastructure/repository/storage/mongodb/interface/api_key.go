@@ -0,0 +1,16 @@
+package mongodbinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type ApiKey interface {
+	FindOneByID(ctx context.Context, q queryinterface.FindOneApiKeyByID) (*agg.ApiKey, error)
+	FindOneByHash(ctx context.Context, q queryinterface.FindOneApiKeyByHash) (*agg.ApiKey, error)
+	FindList(ctx context.Context, q queryinterface.FindApiKeyList) (list []*agg.ApiKey, err error)
+	Insert(ctx context.Context, key *agg.ApiKey) (*agg.ApiKey, error)
+	Touch(ctx context.Context, key *agg.ApiKey) error
+	Remove(ctx context.Context, key *agg.ApiKey) error
+}
@@ -0,0 +1,16 @@
+package mongodbinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+)
+
+type Totp interface {
+	FindOneByUserID(ctx context.Context, q queryinterface.FindOneTotpByUserID) (*agg.Totp, error)
+	Insert(ctx context.Context, totp *agg.Totp) (*agg.Totp, error)
+	Update(ctx context.Context, totp *agg.Totp) (*agg.Totp, error)
+	UseStep(ctx context.Context, totp *agg.Totp, step int64) (used bool, err error)
+	UseRecoveryCode(ctx context.Context, totp *agg.Totp, hash string) (used bool, err error)
+	Remove(ctx context.Context, totp *agg.Totp) error
+}
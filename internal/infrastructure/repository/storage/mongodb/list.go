@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/sync/errgroup"
+)
+
+// listQuery is a query of the page of the documents and the total number of the documents matching the filter.
+type listQuery struct {
+	filter bson.M // filter of the documents (the total is counted by it)
+	cursor bson.M // additional filter of the page documents (nil when the page is not fetched by cursor)
+	sort   bson.D
+	skip   int64
+	limit  int64 // zero means unlimited
+}
+
+// pageFilter returns the filter of the page documents.
+func (q listQuery) pageFilter() bson.M {
+	if q.cursor == nil {
+		return q.filter
+	}
+	return bson.M{"$and": bson.A{q.filter, q.cursor}}
+}
+
+// findList will fetch the page of the documents and count the total. The queries run concurrently under
+// the errgroup, thus the failure of one of them cancels another and it's returned. When the facet is enabled,
+// the page and the total are fetched by one $facet aggregation, thus they are consistent with each other
+// (the page is sorted in memory in this case, therefore it's suitable for not so large lists).
+func findList[T any](ctx context.Context, db *mongo.Collection, q listQuery, facet bool) (list []*T, total int64, err error) {
+	if facet {
+		return findListByFacet[T](ctx, db, q)
+	}
+
+	list = []*T{}
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		opts := options.Find().SetSkip(q.skip).SetLimit(q.limit)
+		if len(q.sort) > 0 {
+			opts.SetSort(q.sort)
+		}
+
+		c, err := db.Find(gCtx, q.pageFilter(), opts)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = c.Close(gCtx) }()
+
+		return c.All(gCtx, &list)
+	})
+
+	g.Go(func() error {
+		count, err := db.CountDocuments(gCtx, q.filter)
+		if err != nil {
+			return err
+		}
+
+		total = count
+		return nil
+	})
+
+	if err = g.Wait(); err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// findListByFacet will fetch the page of the documents and count the total by one aggregation.
+func findListByFacet[T any](ctx context.Context, db *mongo.Collection, q listQuery) (list []*T, total int64, err error) {
+	page := bson.A{}
+	if q.cursor != nil {
+		page = append(page, bson.M{"$match": q.cursor})
+	}
+	if len(q.sort) > 0 {
+		page = append(page, bson.M{"$sort": q.sort})
+	}
+	if q.skip > 0 {
+		page = append(page, bson.M{"$skip": q.skip})
+	}
+	if q.limit > 0 {
+		page = append(page, bson.M{"$limit": q.limit})
+	}
+	if len(page) == 0 {
+		// the sub-pipeline of the facet cannot be empty
+		page = append(page, bson.M{"$match": bson.M{}})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: q.filter}},
+		{{Key: "$facet", Value: bson.M{
+			"list":  page,
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	}
+
+	c, err := db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = c.Close(ctx) }()
+
+	var result []struct {
+		List  []*T `bson:"list"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = c.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	list = []*T{}
+	if len(result) > 0 {
+		if result[0].List != nil {
+			list = result[0].List
+		}
+		if len(result[0].Total) > 0 {
+			total = result[0].Total[0].Count
+		}
+	}
+
+	return list, total, nil
+}
@@ -0,0 +1,151 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const PlaybackSessionCollection = "playbackSessions"
+
+var (
+	PlaybackSessionNotFoundByIdError    = errtype.NewEntityNotFoundError("playback session", "id")
+	PlaybackSessionInsertingFailedError = errtype.NewInternalRepositoryError("unable to store 'playback session' or get inserted 'id'")
+)
+
+type PlaybackSessionRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewPlaybackSessionRepository(serviceContainer diinterface.ServiceContainer) (*PlaybackSessionRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &PlaybackSessionRepository{
+		db:      mongodb.Collection(PlaybackSessionCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *PlaybackSessionRepository) Insert(
+	ctx context.Context, session *agg.PlaybackSession,
+) (*agg.PlaybackSession, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, session, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		session.ID = vo.NewID(oid)
+		return session, nil
+	}
+
+	return nil, r.logger.CriticalPropagate(PlaybackSessionInsertingFailedError)
+}
+
+func (r *PlaybackSessionRepository) FindOneByID(
+	ctx context.Context, id vo.ID, userID vo.ID,
+) (*agg.PlaybackSession, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// expired sessions are removed by storage with a delay, so they are skipped explicitly
+	filter := bson.M{
+		"_id":       id.Value,
+		"user._id":  userID.Value,
+		"expiresAt": bson.M{"$gt": time.Now()},
+	}
+
+	session := &agg.PlaybackSession{}
+	if err := r.db.FindOne(qCtx, filter).Decode(session); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(PlaybackSessionNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return session, nil
+}
+
+func (r *PlaybackSessionRepository) UpdatePosition(
+	ctx context.Context, id vo.ID, position float64, offset int64, expiresAt time.Time,
+) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"position":  position,
+			"offset":    offset,
+			"expiresAt": expiresAt,
+			"updatedAt": time.Now(),
+		},
+	}
+
+	if _, err := r.db.UpdateByID(qCtx, id.Value, update); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that expired sessions are removed by storage.
+func (r *PlaybackSessionRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateOne(qCtx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const PlaylistsCollection = "playlists"
+
+var (
+	PlaylistNotFoundByIdError    = errtype.NewEntityNotFoundError("playlist", "id")
+	PlaylistInsertingFailedError = errtype.NewInternalRepositoryError("unable to store 'playlist' or get inserted 'id'")
+	PlaylistWasNotDeletedError   = errtype.NewInternalValidationError("playlist was not deleted")
+)
+
+type PlaylistRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+	facet   bool // the list and the total are fetched by one $facet aggregation
+}
+
+func NewPlaylistRepository(serviceContainer diinterface.ServiceContainer) (*PlaylistRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &PlaylistRepository{
+		db:      mongodb.Collection(PlaylistsCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+		facet:   cfg.MongoListFacet,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *PlaylistRepository) FindOneByID(
+	ctx context.Context, q queryinterface.FindOnePlaylistByID,
+) (*agg.Playlist, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":      q.GetID().Value,
+		"user._id": q.GetUserID().Value,
+	}
+
+	playlist := &agg.Playlist{}
+	if err := r.db.FindOne(qCtx, filter).Decode(playlist); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(PlaylistNotFoundByIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) FindList(
+	ctx context.Context, q queryinterface.FindPlaylistList,
+) (list []*agg.Playlist, total int64, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"user._id": q.GetUserID().Value}
+
+	lq := listQuery{
+		filter: filter,
+		sort:   bson.D{{Key: "createdAt", Value: -1}},
+		limit:  int64(q.GetLimit()),
+	}
+	if q.GetPage() > 1 {
+		lq.skip = (int64(q.GetPage()) - 1) * int64(q.GetLimit())
+	}
+
+	list, total, err = findList[agg.Playlist](qCtx, r.db, lq, r.facet)
+	if err != nil {
+		return nil, 0, r.logger.ErrorPropagate(err)
+	}
+
+	return list, total, nil
+}
+
+func (r *PlaylistRepository) Insert(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, playlist, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if oid, ok := res.InsertedID.(primitive.ObjectID); ok {
+		return r.FindOneByID(qCtx, dto.NewPlaylistGetRequestDTO(vo.NewID(oid), playlist.UserID))
+	}
+
+	return nil, r.logger.CriticalPropagate(PlaylistInsertingFailedError)
+}
+
+func (r *PlaylistRepository) Update(ctx context.Context, playlist *agg.Playlist) (*agg.Playlist, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateByID(qCtx, playlist.ID.Value, bson.M{"$set": playlist})
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	// check the record is really updated
+	if res.ModifiedCount > 0 {
+		return r.FindOneByID(qCtx, dto.NewPlaylistGetRequestDTO(playlist.ID, playlist.UserID))
+	}
+
+	// if changes is not exists, then return the original data
+	return playlist, nil
+}
+
+func (r *PlaylistRepository) Remove(ctx context.Context, playlist *agg.Playlist) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": playlist.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the playlist is really deleted
+		return r.logger.CriticalPropagate(PlaylistWasNotDeletedError)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that the playlists of the user are served by index.
+func (r *PlaylistRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateOne(qCtx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user._id", Value: 1}, {Key: "createdAt", Value: -1}},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
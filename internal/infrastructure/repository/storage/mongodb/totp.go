@@ -0,0 +1,192 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const TotpCollection = "totps"
+
+var (
+	TotpNotFoundByUserIdError = errtype.NewEntityNotFoundError("totp", "user id")
+	TotpInsertingFailedError  = errtype.NewInternalRepositoryError("unable to store 'totp' or get inserted 'id'")
+	TotpWasNotDeletedError    = errtype.NewInternalValidationError("totp was not deleted")
+)
+
+type TotpRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewTotpRepository(serviceContainer diinterface.ServiceContainer) (*TotpRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &TotpRepository{
+		db:      mongodb.Collection(TotpCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *TotpRepository) FindOneByUserID(ctx context.Context, q queryinterface.FindOneTotpByUserID) (*agg.Totp, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	totp := &agg.Totp{}
+	if err := r.db.FindOne(qCtx, bson.M{"user._id": q.GetUserID().Value}).Decode(totp); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(TotpNotFoundByUserIdError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return totp, nil
+}
+
+func (r *TotpRepository) Insert(ctx context.Context, totp *agg.Totp) (*agg.Totp, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.InsertOne(qCtx, totp, options.InsertOne())
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	if _, ok := res.InsertedID.(primitive.ObjectID); ok {
+		return r.FindOneByUserID(qCtx, dto.NewTotpGetRequestDTO(totp.UserID))
+	}
+
+	return nil, r.logger.CriticalPropagate(TotpInsertingFailedError)
+}
+
+// Update will replace the whole document, because the emptied fields (like a pending secret) must be removed.
+func (r *TotpRepository) Update(ctx context.Context, totp *agg.Totp) (*agg.Totp, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.db.ReplaceOne(qCtx, bson.M{"_id": totp.ID.Value}, totp); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return totp, nil
+}
+
+// UseStep will store the step of used one-time password only if it is greater than the last used one,
+// thus the concurrent requests with the same code cannot pass both. Returns false if the step was already used.
+func (r *TotpRepository) UseStep(ctx context.Context, totp *agg.Totp, step int64) (used bool, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateOne(
+		qCtx,
+		bson.M{
+			"_id": totp.ID.Value,
+			"$or": bson.A{
+				bson.M{"lastUsedStep": bson.M{"$lt": step}},
+				bson.M{"lastUsedStep": bson.M{"$exists": false}},
+			},
+		},
+		bson.M{"$set": bson.M{"lastUsedStep": step, "updatedAt": time.Now()}},
+	)
+	if err != nil {
+		return false, r.logger.ErrorPropagate(err)
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+// UseRecoveryCode will remove the recovery code with given hash only if it is still unused,
+// thus the concurrent requests with the same code cannot pass both. Returns false if the code was not found.
+func (r *TotpRepository) UseRecoveryCode(ctx context.Context, totp *agg.Totp, hash string) (used bool, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateOne(
+		qCtx,
+		bson.M{"_id": totp.ID.Value, "recoveryCodes": hash},
+		bson.M{
+			"$pull": bson.M{"recoveryCodes": hash},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, r.logger.ErrorPropagate(err)
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+func (r *TotpRepository) Remove(ctx context.Context, totp *agg.Totp) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.DeleteOne(qCtx, bson.M{"_id": totp.ID.Value})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.DeletedCount == 0 { // checking the totp is really deleted
+		return r.logger.CriticalPropagate(TotpWasNotDeletedError)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that each user has only one second factor state.
+func (r *TotpRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateOne(qCtx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user._id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
@@ -2,8 +2,10 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
@@ -13,12 +15,21 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"slices"
 	"sync"
 	"time"
 )
 
 const VideosCollection = "videos"
 
+// videoSortPaths are the paths of the documents fields by the sort fields of the videos list.
+var videoSortPaths = map[string]string{
+	enum.VideoSortByCreatedAt: "createdAt",
+	enum.VideoSortByName:      "name",
+	enum.VideoSortByDuration:  "duration",
+	enum.VideoSortBySize:      "resource.filesize",
+}
+
 var (
 	VideoNotFoundByIdError         = errtype.NewEntityNotFoundError("video", "id")
 	VideoNotFoundByNameError       = errtype.NewEntityNotFoundError("video", "name")
@@ -32,6 +43,7 @@ type VideoRepository struct {
 	mu      *sync.Mutex
 	logger  loggerinterface.Logger
 	timeout time.Duration
+	facet   bool // the list and the total are fetched by one $facet aggregation
 }
 
 func NewVideoRepository(serviceContainer diinterface.ServiceContainer) (*VideoRepository, error) {
@@ -40,6 +52,11 @@ func NewVideoRepository(serviceContainer diinterface.ServiceContainer) (*VideoRe
 		return nil, err
 	}
 
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	mongodb, err := serviceContainer.GetMongoDatabase()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -55,12 +72,23 @@ func NewVideoRepository(serviceContainer diinterface.ServiceContainer) (*VideoRe
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	return &VideoRepository{
+	r := &VideoRepository{
 		db:      mongodb.Collection(VideosCollection),
 		logger:  loggerService,
 		mu:      &sync.Mutex{},
 		timeout: timeout,
-	}, nil
+		facet:   cfg.MongoListFacet,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = r.normalizeDuration(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
 }
 
 func (r *VideoRepository) FindOneByID(ctx context.Context, q queryinterface.FindOneVideoByID) (*agg.Video, error) {
@@ -112,45 +140,100 @@ func (r *VideoRepository) FindList(ctx context.Context, q queryinterface.FindVid
 		filter["createdAt"] = createdAtFilter
 	}
 
-	opts := options.Find().
-		SetSkip((int64(q.GetPage()) - 1) * int64(q.GetLimit())).
-		SetLimit(int64(q.GetLimit()))
+	sort := q.GetSort()
+	if sort == "" {
+		sort = enum.VideoDefaultSort
+	}
 
-	wg := sync.WaitGroup{}
-	wg.Add(2)
+	// the previous page is fetched in reverse order from the cursor and then it's reversed back
+	encodedCursor, backward := q.GetAfter(), false
+	if q.GetBefore() != "" {
+		encodedCursor, backward = q.GetBefore(), true
+	}
 
-	list = []*agg.Video{}
-	go func() {
-		defer wg.Done()
+	// the total is counted by the filter without the cursor
+	lq := listQuery{
+		filter: filter,
+		sort:   r.sortOrder(sort, backward),
+		limit:  int64(q.GetLimit()),
+	}
 
-		c, e := r.db.Find(qCtx, filter, opts)
-		if e != nil && e != mongo.ErrNoDocuments {
-			r.logger.Error(e)
-			return
+	if encodedCursor != "" {
+		cursor, e := vo.DecodeCursor(encodedCursor)
+		if e != nil {
+			return nil, 0, r.logger.LogPropagate(e)
 		}
-		defer func() { _ = c.Close(qCtx) }()
-
-		if e = c.All(qCtx, &list); e != nil {
-			r.logger.Error(e)
+		if cursor.Sort != sort || len(cursor.Values) != len(vo.ParseSort(sort)) {
+			return nil, 0, r.logger.LogPropagate(errtype.NewCursorIsInvalidError("cursor"))
 		}
-	}()
+		lq.cursor = bson.M{"$or": r.keysetFilter(sort, cursor, backward)}
+	} else if q.GetPage() > 1 {
+		// page-based pagination is kept for backward compatibility
+		lq.skip = (int64(q.GetPage()) - 1) * int64(q.GetLimit())
+	}
+
+	list, total, err = findList[agg.Video](qCtx, r.db, lq, r.facet)
+	if err != nil {
+		return nil, 0, r.logger.ErrorPropagate(err)
+	}
+
+	if backward {
+		slices.Reverse(list)
+	}
+
+	return list, total, nil
+}
+
+// sortOrder will make the sort of the documents by the sort of the list, the id is the last sort field
+// in the direction of the previous one (thus the order is stable and the index may be used).
+// The backward order is reversed for fetching the previous page.
+func (r *VideoRepository) sortOrder(sort string, backward bool) bson.D {
+	order := bson.D{}
+	descending := false
+	for _, field := range vo.ParseSort(sort) {
+		order = append(order, bson.E{Key: videoSortPaths[field.Name], Value: direction(field.Descending, backward)})
+		descending = field.Descending
+	}
+	return append(order, bson.E{Key: "_id", Value: direction(descending, backward)})
+}
+
+// keysetFilter will make the filter of the documents which follow the cursor in the sort order:
+// (f1 > v1) or (f1 = v1 and f2 > v2) or ... or (f1 = v1 and ... and fn = vn and _id > id).
+func (r *VideoRepository) keysetFilter(sort string, cursor vo.Cursor, backward bool) bson.A {
+	fields := vo.ParseSort(sort)
 
-	total = 0
-	go func() {
-		defer wg.Done()
+	or := bson.A{}
+	for i := 0; i <= len(fields); i++ {
+		cond := bson.M{}
+		for j := 0; j < i; j++ {
+			cond[videoSortPaths[fields[j].Name]] = cursor.Values[j]
+		}
 
-		c, e := r.db.CountDocuments(qCtx, filter)
-		if err != nil {
-			r.logger.Error(e)
-			return
+		if i < len(fields) {
+			cond[videoSortPaths[fields[i].Name]] = bson.M{comparison(fields[i].Descending, backward): cursor.Values[i]}
+		} else {
+			cond["_id"] = bson.M{comparison(fields[len(fields)-1].Descending, backward): cursor.ID}
 		}
 
-		total = c
-	}()
+		or = append(or, cond)
+	}
+	return or
+}
 
-	wg.Wait()
+// direction returns the mongo sort direction of the field.
+func direction(descending bool, backward bool) int {
+	if descending != backward {
+		return -1
+	}
+	return 1
+}
 
-	return list, total, nil
+// comparison returns the mongo operator which matches the values following the cursor value.
+func comparison(descending bool, backward bool) string {
+	if descending != backward {
+		return "$lt"
+	}
+	return "$gt"
 }
 
 func (r *VideoRepository) FindOneByName(ctx context.Context, q queryinterface.FindOneVideoByName) (*agg.Video, error) {
@@ -244,3 +327,41 @@ func (r *VideoRepository) Remove(ctx context.Context, video *agg.Video) error {
 
 	return nil
 }
+
+// createIndexes makes sure that the videos list of the user is served by index for each sort field
+// (the id is the last key, thus the cursor conditions are served by the same index).
+func (r *VideoRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	models := make([]mongo.IndexModel, 0, len(videoSortPaths))
+	for _, field := range enum.VideoSortFields {
+		models = append(models, mongo.IndexModel{
+			Keys: bson.D{{Key: "user._id", Value: 1}, {Key: videoSortPaths[field], Value: 1}, {Key: "_id", Value: 1}},
+		})
+	}
+
+	if _, err := r.db.Indexes().CreateMany(qCtx, models); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// normalizeDuration sets up the zero duration of the videos which were created before the duration was probed,
+// otherwise they would be out of the keyset pagination by duration (the missing field is not comparable with numbers).
+func (r *VideoRepository) normalizeDuration(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.db.UpdateMany(qCtx, bson.M{"duration": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"duration": 0}})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	if res.ModifiedCount > 0 {
+		r.logger.Info(fmt.Sprintf("the zero duration was set up for %d videos", res.ModifiedCount))
+	}
+
+	return nil
+}
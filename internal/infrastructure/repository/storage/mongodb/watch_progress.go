@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	queryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/repository/query/interface"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sync"
+	"time"
+)
+
+const WatchProgressCollection = "watchProgress"
+
+var WatchProgressNotFoundByVideoError = errtype.NewEntityNotFoundError("watch progress", "video")
+
+type WatchProgressRepository struct {
+	db      *mongo.Collection
+	mu      *sync.Mutex
+	logger  loggerinterface.Logger
+	timeout time.Duration
+}
+
+func NewWatchProgressRepository(serviceContainer diinterface.ServiceContainer) (*WatchProgressRepository, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	mongodb, err := serviceContainer.GetMongoDatabase()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	timeout, err := time.ParseDuration(cfg.MongoTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	r := &WatchProgressRepository{
+		db:      mongodb.Collection(WatchProgressCollection),
+		logger:  loggerService,
+		mu:      &sync.Mutex{},
+		timeout: timeout,
+	}
+
+	if err = r.createIndexes(ctx); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return r, nil
+}
+
+func (r *WatchProgressRepository) FindOneByVideo(
+	ctx context.Context, userID vo.ID, videoID vo.ID,
+) (*agg.WatchProgress, error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  userID.Value,
+		"video._id": videoID.Value,
+	}
+
+	progress := &agg.WatchProgress{}
+	if err := r.db.FindOne(qCtx, filter).Decode(progress); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, r.logger.InfoPropagate(WatchProgressNotFoundByVideoError)
+		}
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return progress, nil
+}
+
+func (r *WatchProgressRepository) FindList(
+	ctx context.Context, q queryinterface.FindWatchProgressList,
+) (list []*agg.WatchProgress, err error) {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  q.GetUserID().Value,
+		"completed": q.GetCompleted(),
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updatedAt", Value: -1}}).
+		SetSkip((int64(q.GetPage()) - 1) * int64(q.GetLimit())).
+		SetLimit(int64(q.GetLimit()))
+
+	c, err := r.db.Find(qCtx, filter, opts)
+	if err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+	defer func() { _ = c.Close(qCtx) }()
+
+	list = []*agg.WatchProgress{}
+	if err = c.All(qCtx, &list); err != nil {
+		return nil, r.logger.ErrorPropagate(err)
+	}
+
+	return list, nil
+}
+
+// Save will upsert the progress by the pair of user and video, thus the single document exists for them.
+func (r *WatchProgressRepository) Save(ctx context.Context, progress *agg.WatchProgress) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"user._id":  progress.UserID.Value,
+		"video._id": progress.VideoID.Value,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"position":  progress.Position,
+			"duration":  progress.Duration,
+			"completed": progress.Completed,
+			"updatedAt": progress.Timestamp.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"user":      progress.UserID,
+			"video":     progress.VideoID,
+			"createdAt": progress.Timestamp.UpdatedAt,
+		},
+	}
+
+	if _, err := r.db.UpdateOne(qCtx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
+
+// createIndexes makes sure that the progress is unique per user and video and the continue-watching
+// list of the user is served by index.
+func (r *WatchProgressRepository) createIndexes(ctx context.Context) error {
+	qCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	_, err := r.db.Indexes().CreateMany(qCtx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user._id", Value: 1}, {Key: "video._id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "user._id", Value: 1}, {Key: "completed", Value: 1}, {Key: "updatedAt", Value: -1}},
+		},
+	})
+	if err != nil {
+		return r.logger.ErrorPropagate(err)
+	}
+
+	return nil
+}
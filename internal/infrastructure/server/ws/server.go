@@ -2,25 +2,58 @@ package ws
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	accessorinterface "github.com/Borislavv/video-streaming/internal/domain/service/accessor/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	throttlerinterface "github.com/Borislavv/video-streaming/internal/domain/service/throttler/interface"
+	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	limiterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/limiter/interface"
+	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	streamerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/interface"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
 	"github.com/gorilla/websocket"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	// accessTokenProtocol is a name of the subprotocol which is followed by the token into
+	// Sec-WebSocket-Protocol header (browsers cannot set other headers of the handshake request).
+	accessTokenProtocol = "access-token"
+	// accessTokenQueryParam is a name of query parameter with the token.
+	accessTokenQueryParam = "token"
+	// anyOrigin allows the connections from any origin.
+	anyOrigin = "*"
+)
+
 type Server struct {
 	host           string // example: "0.0.0.0"
 	port           string // example: "9988"
 	transportProto string // example: "tcp"
+	allowedOrigins map[string]struct{}
+	compression    bool
+	compressLevel  int
 
-	streamer streamerinterface.Streamer
-	logger   loggerinterface.Logger
+	streamer     streamerinterface.Streamer
+	tokenizer    tokenizerinterface.Tokenizer
+	throttler    throttlerinterface.AuthThrottler
+	limiter      limiterinterface.StreamingLimiter
+	communicator protointerface.Communicator
+	registry     registryinterface.Registry
+	accessor     accessorinterface.Accessor
+	reader       readerinterface.FileReader
+	logger       loggerinterface.Logger
 }
 
 func NewWebSocketServer(serviceContainer diinterface.ServiceContainer) (*Server, error) {
@@ -34,16 +67,68 @@ func NewWebSocketServer(serviceContainer diinterface.ServiceContainer) (*Server,
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	tokenizerService, err := serviceContainer.GetTokenizerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	authThrottlerService, err := serviceContainer.GetAuthThrottlerService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	streamingLimiter, err := serviceContainer.GetStreamingLimiterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	connectionRegistry, err := serviceContainer.GetConnectionRegistryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	accessService, err := serviceContainer.GetAccessService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileReaderService, err := serviceContainer.GetFileReaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	cfg, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	allowedOrigins := map[string]struct{}{}
+	for _, origin := range strings.Split(cfg.StreamingAllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins[strings.ToLower(origin)] = struct{}{}
+		}
+	}
+
 	return &Server{
 		host:           cfg.StreamingHost,
 		port:           cfg.StreamingPort,
 		transportProto: cfg.StreamingTransport,
+		allowedOrigins: allowedOrigins,
+		compression:    cfg.StreamingCompressionEnabled,
+		compressLevel:  cfg.StreamingCompressionLevel,
 		streamer:       streamingService,
+		tokenizer:      tokenizerService,
+		throttler:      authThrottlerService,
+		limiter:        streamingLimiter,
+		communicator:   webSocketCommunicator,
+		registry:       connectionRegistry,
+		accessor:       accessService,
+		reader:         fileReaderService,
 		logger:         loggerService,
 	}, nil
 }
@@ -58,9 +143,14 @@ func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
 		return
 	}
 
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/connections", s.handleAdminConnections)
+	mux.HandleFunc("/admin/segment-cache", s.handleAdminSegmentCache)
+	mux.HandleFunc("/", s.handleConnection)
+
 	server := &http.Server{
 		Addr:    addr.String(),
-		Handler: http.HandlerFunc(s.handleConnection),
+		Handler: mux,
 	}
 
 	wg.Add(1)
@@ -91,10 +181,40 @@ func (s *Server) Listen(ctx context.Context, wg *sync.WaitGroup) {
 
 // handleConnection is method which handle each websocket connection
 func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
+	// the server is going away, the client should connect to another instance
+	if s.registry.IsDraining() {
+		http.Error(w, "server is going away", http.StatusServiceUnavailable)
+		return
+	}
+
+	// the origin is checked before the authentication, thus foreign sites cannot use the cookie of the user
+	if !s.isAllowedOrigin(r) {
+		s.logger.Info(fmt.Sprintf("[%v]: origin '%v' is not allowed", r.RemoteAddr, r.Header.Get("Origin")))
+		http.Error(w, errtype.NewAccessDeniedError("origin is not allowed").Error(), http.StatusForbidden)
+		return
+	}
+
+	// the request is authenticated before upgrade, thus unauthenticated sockets are never held open
+	userID, protocols, err := s.authenticate(r)
+	if err != nil {
+		s.reject(w, err)
+		return
+	}
+
+	// the slot is taken before upgrade, but the rejection is sent after it, thus the client receives the close code
+	release, limitErr := s.limiter.AcquireConnection(helper.RemoteHost(r.RemoteAddr), userID)
+	if limitErr == nil {
+		defer release()
+	}
+
 	upgrader := websocket.Upgrader{
+		// the origin was already checked above
 		CheckOrigin: func(r *http.Request) bool {
 			return true
 		},
+		Subprotocols: protocols,
+		// the permessage-deflate is negotiated if the client supports it
+		EnableCompression: s.compression,
 	}
 
 	conn, err := upgrader.Upgrade(w, r, nil)
@@ -103,13 +223,163 @@ func (s *Server) handleConnection(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer func() {
-		if err = conn.Close(); err != nil {
+		// the connection may be already closed by the streamer (on the streams limit exceeding or idle timeout)
+		if err = conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
 			s.logger.Error(err)
 			return
 		}
 	}()
 
-	s.logger.Info(fmt.Sprintf("[%v]: accpted a new connection", conn.RemoteAddr()))
+	if limitErr != nil {
+		s.close(conn, limitErr)
+		return
+	}
+
+	if s.compression {
+		if err = conn.SetCompressionLevel(s.compressLevel); err != nil {
+			s.logger.Error(err)
+			return
+		}
+	}
+
+	unregister := s.registry.Register(conn, userID)
+	defer unregister()
+
+	s.logger.Info(fmt.Sprintf("[%v]: accepted a new connection of user '%v'", conn.RemoteAddr(), userID.Value.Hex()))
 
-	s.streamer.HandleConn(conn)
+	s.streamer.HandleConn(conn, userID)
+}
+
+// handleAdminConnections is method which responds with the current connections and their streams for administrators.
+func (s *Server) handleAdminConnections(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Draining    bool        `json:"draining"`
+		Connections interface{} `json:"connections"`
+	}{
+		Draining:    s.registry.IsDraining(),
+		Connections: s.registry.Connections(),
+	}); err != nil {
+		s.logger.Error(err)
+	}
+}
+
+// handleAdminSegmentCache is method which responds with the counters of the hot segments cache for administrators.
+func (s *Server) handleAdminSegmentCache(w http.ResponseWriter, r *http.Request) {
+	if !s.authorizeAdmin(w, r) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.reader.SegmentCacheStats()); err != nil {
+		s.logger.Error(err)
+	}
+}
+
+// authorizeAdmin will check that the request is a GET one of the administrator, otherwise the request is rejected.
+func (s *Server) authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return false
+	}
+
+	userID, _, err := s.authenticate(r)
+	if err != nil {
+		s.reject(w, err)
+		return false
+	}
+
+	if err = s.accessor.IsAdmin(userID); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+// authenticate will verify the token of the handshake request and extract userID from it. Failed verifications
+// are counted against the same budget as failed authorizations of the client address. The protocols are
+// the subprotocols which must be selected by the upgrader (if the token was passed through them).
+func (s *Server) authenticate(r *http.Request) (userID vo.ID, protocols []string, err error) {
+	ip := helper.RemoteHost(r.RemoteAddr)
+
+	if err = s.throttler.Check("", ip); err != nil {
+		return vo.ID{}, nil, s.logger.LogPropagate(err)
+	}
+
+	token, protocols := s.extractToken(r)
+	if token == "" {
+		return vo.ID{}, nil, s.logger.LogPropagate(errtype.NewAccessTokenIsEmptyOrOmittedError())
+	}
+
+	userID, err = s.tokenizer.Verify(token)
+	if err != nil {
+		if _, ferr := s.throttler.Fail("", ip, err.Error()); ferr != nil {
+			return vo.ID{}, nil, s.logger.LogPropagate(ferr)
+		}
+		return vo.ID{}, nil, s.logger.LogPropagate(err)
+	}
+
+	return userID, protocols, nil
+}
+
+// extractToken will take the token from the subprotocols, the header, the cookie or the query parameter (by priority).
+func (s *Server) extractToken(r *http.Request) (token string, protocols []string) {
+	requested := websocket.Subprotocols(r)
+	for i, protocol := range requested {
+		if protocol == accessTokenProtocol && i+1 < len(requested) {
+			// the client must receive one of requested protocols in response, the token itself is not echoed
+			return requested[i+1], []string{accessTokenProtocol}
+		}
+	}
+
+	if token = r.Header.Get(enum.AccessTokenHeaderKey); token != "" {
+		return token, nil
+	}
+
+	if cookie, err := r.Cookie(enum.AccessTokenHeaderKey); err == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+
+	return r.URL.Query().Get(accessTokenQueryParam), nil
+}
+
+// isAllowedOrigin checks the Origin header by the allow-list (requests without the header are not from browsers).
+func (s *Server) isAllowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if _, any := s.allowedOrigins[anyOrigin]; any {
+		return true
+	}
+	_, allowed := s.allowedOrigins[strings.ToLower(origin)]
+	return allowed
+}
+
+// reject will respond with client error status of the error (unauthorized by default) instead of upgrade.
+func (s *Server) reject(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	if statusErr, ok := err.(interface{ Status() int }); ok &&
+		statusErr.Status() > http.StatusBadRequest && statusErr.Status() < http.StatusInternalServerError {
+		status = statusErr.Status()
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// close will send the close frame with a code by the limit error: the overloaded server asks to try again later,
+// the exceeded limits by the client address or by the user are the policy violations.
+func (s *Server) close(conn *websocket.Conn, err error) {
+	code := websocket.ClosePolicyViolation
+	if errtype.IsServerIsOverloadedError(err) {
+		code = websocket.CloseTryAgainLater
+	}
+
+	if cerr := s.communicator.Close(code, err.Error(), conn); cerr != nil {
+		s.logger.Error(cerr)
+	}
 }
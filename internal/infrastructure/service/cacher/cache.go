@@ -26,3 +26,11 @@ func (c *Cache) Get(key string, fn func(item domain_cacherinterface.CacheItem) (
 func (c *Cache) Delete(key string) {
 	c.storage.Delete(key)
 }
+
+func (c *Cache) InvalidateTags(tags ...string) {
+	c.storage.InvalidateTags(tags...)
+}
+
+func (c *Cache) Stats() domain_cacherinterface.CacheStats {
+	return c.storage.Stats()
+}
@@ -5,9 +5,14 @@ import (
 )
 
 type Item struct {
-	data      interface{}
-	addedAt   time.Time
-	expiresAt time.Time
+	data          interface{}
+	err           error // cached error of the computation (negative caching)
+	addedAt       time.Time
+	expiresAt     time.Time
+	softExpiresAt time.Time
+	negativeTTL   time.Duration
+	tags          []string
+	size          int64 // estimated size of the key and data
 }
 
 func NewCacheItem() *Item {
@@ -21,3 +26,29 @@ func NewCacheItem() *Item {
 func (i *Item) SetTTL(ttl time.Duration) {
 	i.expiresAt = time.Now().Add(ttl)
 }
+
+func (i *Item) SetSoftTTL(ttl time.Duration) {
+	i.softExpiresAt = time.Now().Add(ttl)
+}
+
+func (i *Item) SetNegativeTTL(ttl time.Duration) {
+	i.negativeTTL = ttl
+}
+
+func (i *Item) SetTags(tags ...string) {
+	i.tags = append(i.tags, tags...)
+}
+
+// isStale checks whether the soft TTL is exceeded (the item must be refreshed).
+func (i *Item) isStale() bool {
+	return !i.softExpiresAt.IsZero() && !i.softExpiresAt.After(time.Now())
+}
+
+// isExpired checks whether the TTL is exceeded (the item must not be served).
+func (i *Item) isExpired() bool {
+	return i.isExpiredAt(time.Now())
+}
+
+func (i *Item) isExpiredAt(t time.Time) bool {
+	return !i.expiresAt.IsZero() && !i.expiresAt.After(t)
+}
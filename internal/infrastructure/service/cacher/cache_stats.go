@@ -0,0 +1,29 @@
+package cacher
+
+type Stats struct {
+	hits      uint64
+	misses    uint64
+	evictions uint64
+	entries   int
+	bytes     int64
+}
+
+func (s *Stats) Hits() uint64 {
+	return s.hits
+}
+
+func (s *Stats) Misses() uint64 {
+	return s.misses
+}
+
+func (s *Stats) Evictions() uint64 {
+	return s.evictions
+}
+
+func (s *Stats) Entries() int {
+	return s.entries
+}
+
+func (s *Stats) Bytes() int64 {
+	return s.bytes
+}
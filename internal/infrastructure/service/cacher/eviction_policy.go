@@ -0,0 +1,33 @@
+package cacher
+
+import (
+	"fmt"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+)
+
+const (
+	LRUEvictionPolicy      = "lru"
+	LFUEvictionPolicy      = "lfu"
+	WTinyLFUEvictionPolicy = "wtinylfu"
+
+	// defaultPolicyCapacity is an expected number of items which is used for size the W-TinyLFU structures
+	// when the number of items is not limited (the storage is bounded by bytes only).
+	defaultPolicyCapacity = 10000
+)
+
+// NewEvictionPolicy is a constructor of the eviction policy by name. The capacity is an expected max. number of items.
+func NewEvictionPolicy(name string, capacity int) (cacherinterface.EvictionPolicy, error) {
+	switch name {
+	case LRUEvictionPolicy:
+		return NewLRUPolicy(), nil
+	case LFUEvictionPolicy:
+		return NewLFUPolicy(), nil
+	case WTinyLFUEvictionPolicy:
+		if capacity <= 0 {
+			capacity = defaultPolicyCapacity
+		}
+		return NewWTinyLFUPolicy(capacity), nil
+	default:
+		return nil, fmt.Errorf("unknown cache eviction policy '%v'", name)
+	}
+}
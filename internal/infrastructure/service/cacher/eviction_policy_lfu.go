@@ -0,0 +1,85 @@
+package cacher
+
+import "container/list"
+
+// LFUPolicy evicts the least frequently used item (the least recently used one among items with equal frequency).
+// All operations take O(1): the items are grouped into the buckets by frequency, the buckets are ordered ascending.
+type LFUPolicy struct {
+	buckets *list.List // of *lfuBucket
+	items   map[string]*lfuEntry
+}
+
+type lfuBucket struct {
+	freq    uint64
+	entries *list.List // keys from the most to the least recently used
+}
+
+type lfuEntry struct {
+	bucket *list.Element
+	entry  *list.Element
+}
+
+// NewLFUPolicy is a constructor of LFUPolicy structure.
+func NewLFUPolicy() *LFUPolicy {
+	return &LFUPolicy{
+		buckets: list.New(),
+		items:   map[string]*lfuEntry{},
+	}
+}
+
+func (p *LFUPolicy) Add(key string) {
+	if _, found := p.items[key]; found {
+		p.Access(key)
+		return
+	}
+
+	first := p.buckets.Front()
+	if first == nil || first.Value.(*lfuBucket).freq != 1 {
+		first = p.buckets.PushFront(&lfuBucket{freq: 1, entries: list.New()})
+	}
+	p.items[key] = &lfuEntry{
+		bucket: first,
+		entry:  first.Value.(*lfuBucket).entries.PushFront(key),
+	}
+}
+
+func (p *LFUPolicy) Access(key string) {
+	e, found := p.items[key]
+	if !found {
+		return
+	}
+
+	current := e.bucket.Value.(*lfuBucket)
+	next := e.bucket.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != current.freq+1 {
+		next = p.buckets.InsertAfter(&lfuBucket{freq: current.freq + 1, entries: list.New()}, e.bucket)
+	}
+
+	p.unlink(e)
+	e.bucket = next
+	e.entry = next.Value.(*lfuBucket).entries.PushFront(key)
+}
+
+func (p *LFUPolicy) Remove(key string) {
+	if e, found := p.items[key]; found {
+		p.unlink(e)
+		delete(p.items, key)
+	}
+}
+
+func (p *LFUPolicy) Victim() (key string, found bool) {
+	first := p.buckets.Front()
+	if first == nil {
+		return "", false
+	}
+	return first.Value.(*lfuBucket).entries.Back().Value.(string), true
+}
+
+// unlink will remove the entry from its bucket and the bucket itself when it becomes empty.
+func (p *LFUPolicy) unlink(e *lfuEntry) {
+	bucket := e.bucket.Value.(*lfuBucket)
+	bucket.entries.Remove(e.entry)
+	if bucket.entries.Len() == 0 {
+		p.buckets.Remove(e.bucket)
+	}
+}
@@ -0,0 +1,46 @@
+package cacher
+
+import "container/list"
+
+// LRUPolicy evicts the least recently used item.
+type LRUPolicy struct {
+	list  *list.List // keys from the most to the least recently used
+	items map[string]*list.Element
+}
+
+// NewLRUPolicy is a constructor of LRUPolicy structure.
+func NewLRUPolicy() *LRUPolicy {
+	return &LRUPolicy{
+		list:  list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+func (p *LRUPolicy) Add(key string) {
+	if el, found := p.items[key]; found {
+		p.list.MoveToFront(el)
+		return
+	}
+	p.items[key] = p.list.PushFront(key)
+}
+
+func (p *LRUPolicy) Access(key string) {
+	if el, found := p.items[key]; found {
+		p.list.MoveToFront(el)
+	}
+}
+
+func (p *LRUPolicy) Remove(key string) {
+	if el, found := p.items[key]; found {
+		p.list.Remove(el)
+		delete(p.items, key)
+	}
+}
+
+func (p *LRUPolicy) Victim() (key string, found bool) {
+	el := p.list.Back()
+	if el == nil {
+		return "", false
+	}
+	return el.Value.(string), true
+}
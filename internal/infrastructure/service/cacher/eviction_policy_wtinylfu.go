@@ -0,0 +1,224 @@
+package cacher
+
+import "container/list"
+
+const (
+	wtinylfuWindowPercent    = 1  // part of the capacity which is given to the admission window
+	wtinylfuProtectedPercent = 80 // part of the main space which is given to the protected segment
+	wtinylfuSketchDepth      = 4
+	wtinylfuSketchResetRatio = 10 // number of increments per the capacity after which the sketch counters are halved
+)
+
+const (
+	wtinylfuWindow = iota
+	wtinylfuProbation
+	wtinylfuProtected
+)
+
+// WTinyLFUPolicy is a Window-TinyLFU policy. The new items get into the small LRU window, the items which leave
+// the window compete with the victim of the main space (segmented LRU) by the frequency which is estimated by
+// the count-min sketch. Thus, the recent bursts are kept by the window and the one-hit items don't displace
+// the frequently used ones.
+type WTinyLFUPolicy struct {
+	windowCapacity    int
+	protectedCapacity int
+	window            *list.List // keys from the most to the least recently used
+	probation         *list.List
+	protected         *list.List
+	items             map[string]*wtinylfuEntry
+	sketch            *countMinSketch
+}
+
+type wtinylfuEntry struct {
+	segment int
+	element *list.Element
+}
+
+// NewWTinyLFUPolicy is a constructor of WTinyLFUPolicy structure. The capacity is an expected max. number of items.
+func NewWTinyLFUPolicy(capacity int) *WTinyLFUPolicy {
+	windowCapacity := capacity * wtinylfuWindowPercent / 100
+	if windowCapacity < 1 {
+		windowCapacity = 1
+	}
+
+	return &WTinyLFUPolicy{
+		windowCapacity:    windowCapacity,
+		protectedCapacity: (capacity - windowCapacity) * wtinylfuProtectedPercent / 100,
+		window:            list.New(),
+		probation:         list.New(),
+		protected:         list.New(),
+		items:             map[string]*wtinylfuEntry{},
+		sketch:            newCountMinSketch(capacity),
+	}
+}
+
+func (p *WTinyLFUPolicy) Add(key string) {
+	p.sketch.increment(key)
+
+	if _, found := p.items[key]; found {
+		p.Access(key)
+		return
+	}
+	p.items[key] = &wtinylfuEntry{
+		segment: wtinylfuWindow,
+		element: p.window.PushFront(key),
+	}
+}
+
+func (p *WTinyLFUPolicy) Access(key string) {
+	p.sketch.increment(key)
+
+	e, found := p.items[key]
+	if !found {
+		return
+	}
+
+	switch e.segment {
+	case wtinylfuWindow:
+		p.window.MoveToFront(e.element)
+	case wtinylfuProtected:
+		p.protected.MoveToFront(e.element)
+	case wtinylfuProbation:
+		// the item which was hit on probation is promoted to the protected segment,
+		// the least recently used protected item is demoted to probation when the segment is overflowed
+		p.probation.Remove(e.element)
+		e.segment = wtinylfuProtected
+		e.element = p.protected.PushFront(key)
+
+		if p.protected.Len() > p.protectedCapacity {
+			if back := p.protected.Back(); back != nil {
+				demoted := p.items[back.Value.(string)]
+				p.protected.Remove(back)
+				demoted.segment = wtinylfuProbation
+				demoted.element = p.probation.PushFront(back.Value.(string))
+			}
+		}
+	}
+}
+
+func (p *WTinyLFUPolicy) Remove(key string) {
+	e, found := p.items[key]
+	if !found {
+		return
+	}
+	p.segment(e.segment).Remove(e.element)
+	delete(p.items, key)
+}
+
+func (p *WTinyLFUPolicy) Victim() (key string, found bool) {
+	mainVictim := p.probation.Back()
+	if mainVictim == nil {
+		mainVictim = p.protected.Back()
+	}
+
+	// the window is not overflowed, evict from the main space
+	if p.window.Len() <= p.windowCapacity {
+		if mainVictim != nil {
+			return mainVictim.Value.(string), true
+		}
+		if back := p.window.Back(); back != nil {
+			return back.Value.(string), true
+		}
+		return "", false
+	}
+
+	// the candidate which leaves the window is admitted into the main space
+	// only when it's used more frequently than the main victim
+	candidate := p.window.Back()
+	if mainVictim == nil || p.sketch.estimate(candidate.Value.(string)) <= p.sketch.estimate(mainVictim.Value.(string)) {
+		return candidate.Value.(string), true
+	}
+
+	e := p.items[candidate.Value.(string)]
+	p.window.Remove(candidate)
+	e.segment = wtinylfuProbation
+	e.element = p.probation.PushFront(candidate.Value.(string))
+
+	return mainVictim.Value.(string), true
+}
+
+func (p *WTinyLFUPolicy) segment(segment int) *list.List {
+	switch segment {
+	case wtinylfuWindow:
+		return p.window
+	case wtinylfuProbation:
+		return p.probation
+	default:
+		return p.protected
+	}
+}
+
+// countMinSketch is an approximate frequency counter which takes a fixed amount of memory. The counters are halved
+// periodically, thus the frequencies of the items which are not used anymore are decreasing (aging).
+type countMinSketch struct {
+	rows      [wtinylfuSketchDepth][]uint8
+	mask      uint64
+	additions int
+	resetAt   int
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	width := 1
+	for width < capacity {
+		width <<= 1
+	}
+
+	s := &countMinSketch{
+		mask:    uint64(width - 1),
+		resetAt: capacity * wtinylfuSketchResetRatio,
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+func (s *countMinSketch) increment(key string) {
+	h := s.hash(key)
+	for i := range s.rows {
+		idx := s.index(h, i)
+		if s.rows[i][idx] < 255 {
+			s.rows[i][idx]++
+		}
+	}
+
+	s.additions++
+	if s.additions >= s.resetAt {
+		s.reset()
+	}
+}
+
+func (s *countMinSketch) estimate(key string) uint8 {
+	h := s.hash(key)
+	estimate := uint8(255)
+	for i := range s.rows {
+		if v := s.rows[i][s.index(h, i)]; v < estimate {
+			estimate = v
+		}
+	}
+	return estimate
+}
+
+func (s *countMinSketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+func (s *countMinSketch) hash(key string) uint64 {
+	// inlined FNV-1a, it doesn't allocate unlike hash/fnv
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// index will derive the index of the row from one hash (double hashing).
+func (s *countMinSketch) index(h uint64, row int) uint64 {
+	return (h + uint64(row)*((h>>32)|1)) & s.mask
+}
@@ -0,0 +1,16 @@
+package cacher
+
+import "sync"
+
+// flight is an in-flight computation of the item, its result is shared by all callers of the key.
+type flight struct {
+	wg   *sync.WaitGroup
+	data interface{}
+	err  error
+}
+
+func newFlight() *flight {
+	f := &flight{wg: &sync.WaitGroup{}}
+	f.wg.Add(1)
+	return f
+}
@@ -0,0 +1,14 @@
+package cacherinterface
+
+// EvictionPolicy decides which item must be evicted from the bounded storage.
+// Implementations are not safe for concurrent use, the storage calls them under its lock.
+type EvictionPolicy interface {
+	// Add will register the stored key.
+	Add(key string)
+	// Access will register the hit of the key.
+	Access(key string)
+	// Remove will unregister the key which was removed from the storage (deleted, expired or evicted).
+	Remove(key string)
+	// Victim will return the key which must be evicted first (the key is still registered until Remove).
+	Victim() (key string, found bool)
+}
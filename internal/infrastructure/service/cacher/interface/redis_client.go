@@ -0,0 +1,13 @@
+package cacherinterface
+
+import "context"
+
+// RedisClient is a client of the Redis protocol which is used by the remote storage
+// (it may be replaced by an in-process fake).
+type RedisClient interface {
+	// Do will send the command and return the reply.
+	Do(ctx context.Context, args ...string) (interface{}, error)
+	// Subscribe will call the handler for each message of the channel until the context is done
+	// or the connection is broken.
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error
+}
@@ -5,5 +5,7 @@ import cacherinterface "github.com/Borislavv/video-streaming/internal/domain/ser
 type Storage interface {
 	Get(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) (data interface{}, err error)
 	Delete(key string)
+	InvalidateTags(tags ...string)
 	Displace()
+	Stats() cacherinterface.CacheStats
 }
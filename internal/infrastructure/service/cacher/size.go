@@ -0,0 +1,101 @@
+package cacher
+
+import "reflect"
+
+// sizeOf will estimate the number of bytes which are retained by the value: the headers of values, the contents
+// of strings, slices and maps, and the pointed values (the shared pointers are counted once).
+func sizeOf(v interface{}) int64 {
+	if v == nil {
+		return 0
+	}
+	return sizeOfValue(reflect.ValueOf(v), map[uintptr]struct{}{})
+}
+
+func sizeOfValue(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	return int64(v.Type().Size()) + sizeOfReferenced(v, seen)
+}
+
+// sizeOfReferenced will estimate the number of bytes which are referenced by the value (excluding the value itself).
+func sizeOfReferenced(v reflect.Value, seen map[uintptr]struct{}) int64 {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || isSeen(v.Pointer(), seen) {
+			return 0
+		}
+		return sizeOfValue(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return sizeOfValue(v.Elem(), seen)
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || isSeen(v.Pointer(), seen) {
+			return 0
+		}
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		// the elements of []byte and other flat slices reference nothing, thus they are not walked through
+		if !hasReferences(v.Type().Elem()) {
+			return size
+		}
+		for i := 0; i < v.Len(); i++ {
+			size += sizeOfReferenced(v.Index(i), seen)
+		}
+		return size
+	case reflect.Array:
+		if !hasReferences(v.Type().Elem()) {
+			return 0
+		}
+		var size int64
+		for i := 0; i < v.Len(); i++ {
+			size += sizeOfReferenced(v.Index(i), seen)
+		}
+		return size
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += sizeOfReferenced(v.Field(i), seen)
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || isSeen(v.Pointer(), seen) {
+			return 0
+		}
+		size := int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			size += sizeOfReferenced(iter.Key(), seen) + sizeOfReferenced(iter.Value(), seen)
+		}
+		return size
+	default:
+		return 0
+	}
+}
+
+// hasReferences will check that the values of the type may reference the data which is counted by sizeOfReferenced.
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.String, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func isSeen(ptr uintptr, seen map[uintptr]struct{}) bool {
+	if _, found := seen[ptr]; found {
+		return true
+	}
+	seen[ptr] = struct{}{}
+	return false
+}
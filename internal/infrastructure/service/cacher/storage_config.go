@@ -0,0 +1,69 @@
+package cacher
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	infrastructure_cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/redis"
+	"time"
+)
+
+const (
+	LocalCacheBackend  = "local"
+	RedisCacheBackend  = "redis"
+	TieredCacheBackend = "tiered"
+)
+
+// NewStorageByConfig is a constructor of the storage which is chosen by the cache backend of the config.
+func NewStorageByConfig(serviceContainer diinterface.ServiceContainer) (infrastructure_cacherinterface.Storage, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if cfg.CacheBackend != LocalCacheBackend && cfg.CacheBackend != RedisCacheBackend && cfg.CacheBackend != TieredCacheBackend {
+		return nil, loggerService.LogPropagate(fmt.Errorf("unknown cache backend '%v'", cfg.CacheBackend))
+	}
+
+	var local infrastructure_cacherinterface.Storage
+	if cfg.CacheBackend != RedisCacheBackend {
+		local, err = NewCacheStorage(ctx, cfg.CacheShards, cfg.CacheEvictionPolicy, cfg.CacheMaxEntries, cfg.CacheMaxBytes)
+		if err != nil {
+			return nil, loggerService.LogPropagate(err)
+		}
+		if cfg.CacheBackend == LocalCacheBackend {
+			return local, nil
+		}
+	}
+
+	timeout, err := time.ParseDuration(cfg.RedisTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+	if cfg.RedisPoolSize <= 0 {
+		return nil, loggerService.LogPropagate(fmt.Errorf("redis pool size must be positive, '%d' given", cfg.RedisPoolSize))
+	}
+
+	remote := NewRedisCacheStorage(
+		ctx,
+		redis.NewClient(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, cfg.RedisPoolSize, timeout),
+		loggerService,
+		cfg.CacheRedisPrefix,
+		cfg.CacheInvalidationChannel,
+	)
+	if cfg.CacheBackend == RedisCacheBackend {
+		return remote, nil
+	}
+
+	return NewTieredCacheStorage(ctx, local, remote, loggerService), nil
+}
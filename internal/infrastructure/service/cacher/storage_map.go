@@ -3,7 +3,9 @@ package cacher
 import (
 	"context"
 	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	infrastructure_cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,19 +13,39 @@ type MapCacheStorage struct {
 	*mapCacheStorage
 }
 type mapCacheStorage struct {
-	ctx      context.Context
-	mu       sync.RWMutex
-	storage  map[string]*Item
-	capacity int64
+	ctx        context.Context
+	mu         sync.Mutex
+	storage    map[string]*Item
+	tags       map[string]map[string]struct{} // keys of items by tags
+	flights    map[string]*flight             // in-flight computations by keys
+	epoch      uint64                         // number of invalidations
+	policy     infrastructure_cacherinterface.EvictionPolicy
+	maxEntries int   // max. number of items (0 means unlimited)
+	maxBytes   int64 // max. estimated size of items (0 means unlimited)
+	bytes      int64 // estimated size of stored items
+	hits       uint64
+	misses     uint64
+	evictions  uint64
 }
 
-// NewMapCacheStorage is a constructor of MapCacheStorage structure.
-func NewMapCacheStorage(ctx context.Context) *MapCacheStorage {
+// NewMapCacheStorage is a constructor of MapCacheStorage structure. When the number of items or their size exceeds
+// the limits, the items are evicted in order which is defined by the policy.
+func NewMapCacheStorage(
+	ctx context.Context,
+	policy infrastructure_cacherinterface.EvictionPolicy,
+	maxEntries int,
+	maxBytes int64,
+) *MapCacheStorage {
 	return &MapCacheStorage{
 		mapCacheStorage: &mapCacheStorage{
-			ctx:     ctx,
-			mu:      sync.RWMutex{},
-			storage: map[string]*Item{},
+			ctx:        ctx,
+			mu:         sync.Mutex{},
+			storage:    map[string]*Item{},
+			tags:       map[string]map[string]struct{}{},
+			flights:    map[string]*flight{},
+			policy:     policy,
+			maxEntries: maxEntries,
+			maxBytes:   maxBytes,
 		},
 	}
 }
@@ -31,66 +53,222 @@ func NewMapCacheStorage(ctx context.Context) *MapCacheStorage {
 func (c *MapCacheStorage) Get(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) (data interface{}, err error) {
 	item, found := c.get(key)
 	if found {
-		return item.data, nil
+		atomic.AddUint64(&c.hits, 1)
+		if item.isStale() {
+			c.revalidate(key, item, fn)
+		}
+		return item.data, item.err
 	}
+	atomic.AddUint64(&c.misses, 1)
 
-	item, err = c.compute(fn)
-	if err != nil {
-		return nil, err
+	call, epoch, leader := c.join(key)
+	if leader {
+		c.run(key, call, nil, fn, epoch)
+	} else {
+		call.wg.Wait()
 	}
 
-	return c.set(key, item), nil
+	return call.data, call.err
 }
 
 func (c *MapCacheStorage) get(key string) (item *Item, found bool) {
-	defer c.mu.RUnlock()
-	c.mu.RLock()
+	defer c.mu.Unlock()
+	c.mu.Lock()
 	item, found = c.storage[key]
+	if !found || item.isExpired() {
+		return nil, false
+	}
+	c.policy.Access(key)
 	return item, found
 }
 
+// join will return the in-flight computation of the key, or it will register a new one when there is no
+// such computation (the leader computes the item, the rest of callers wait for its result).
+func (c *MapCacheStorage) join(key string) (call *flight, epoch uint64, leader bool) {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+	if call, found := c.flights[key]; found {
+		return call, c.epoch, false
+	}
+	call = newFlight()
+	// the item may be stored by another computation after the miss
+	if item, found := c.storage[key]; found && !item.isExpired() {
+		call.data, call.err = item.data, item.err
+		call.wg.Done()
+		return call, c.epoch, false
+	}
+	c.flights[key] = call
+	return call, c.epoch, true
+}
+
+// revalidate will refresh the stale item in background, the stale data is served meanwhile.
+func (c *MapCacheStorage) revalidate(key string, stale *Item, fn func(cacherinterface.CacheItem) (data interface{}, err error)) {
+	c.mu.Lock()
+	if _, found := c.flights[key]; found {
+		c.mu.Unlock()
+		return
+	}
+	call := newFlight()
+	c.flights[key] = call
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	go c.run(key, call, stale, fn, epoch)
+}
+
+// run will compute the item and share the result with the callers which are waiting for the flight.
+// When the refresh of the stale item failed, the stale data is shared and kept until the item is expired.
+func (c *MapCacheStorage) run(key string, call *flight, stale *Item, fn func(cacherinterface.CacheItem) (data interface{}, err error), epoch uint64) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.flights, key)
+		c.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	item, err := c.compute(fn)
+	if err != nil {
+		if stale != nil {
+			call.data, call.err = stale.data, stale.err
+			return
+		}
+		call.err = err
+		return
+	}
+	item.size = sizeOf(key) + sizeOf(item.data)
+
+	call.data, call.err = c.set(key, item, epoch)
+}
+
+// compute will call the fn for build the item. The error is cached as a result as well when the fn has set
+// the negative TTL, otherwise it's returned.
 func (c *MapCacheStorage) compute(fn func(cacherinterface.CacheItem) (data interface{}, err error)) (item *Item, err error) {
 	item = NewCacheItem()
 	data, err := fn(item)
 	if err != nil {
-		return nil, err
+		if item.negativeTTL <= 0 {
+			return nil, err
+		}
+		item.data = nil
+		item.err = err
+		item.expiresAt = time.Now().Add(item.negativeTTL)
+		item.softExpiresAt = time.Time{}
+		return item, nil
 	}
 	item.data = data
 	item.addedAt = time.Now()
 	return item, nil
 }
 
-func (c *MapCacheStorage) set(key string, item *Item) (data interface{}) {
+// set will store the computed item instead of the previous one. The item which was computed while an invalidation
+// happened is not stored, because it may be built from the data which was changed (it's returned to the caller only).
+func (c *MapCacheStorage) set(key string, item *Item, epoch uint64) (data interface{}, err error) {
 	defer c.mu.Unlock()
 	c.mu.Lock()
-	cacheItem, found := c.storage[key]
-	if found {
-		return cacheItem.data
+	if c.epoch != epoch {
+		return item.data, item.err
 	}
+	c.delete(key)
 	c.storage[key] = item
-	return item.data
+	c.bytes += item.size
+	c.policy.Add(key)
+	for _, tag := range item.tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = map[string]struct{}{}
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	c.evict()
+	return item.data, item.err
+}
+
+// evict will remove the items chosen by the policy while the limits are exceeded. Must be called under the lock.
+func (c *MapCacheStorage) evict() {
+	for (c.maxEntries > 0 && len(c.storage) > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		key, found := c.policy.Victim()
+		if !found {
+			return
+		}
+		c.delete(key)
+		c.evictions++
+	}
 }
 
 func (c *MapCacheStorage) Delete(key string) {
 	defer c.mu.Unlock()
 	c.mu.Lock()
+	c.delete(key)
+}
+
+func (c *MapCacheStorage) InvalidateTags(tags ...string) {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+	c.epoch++
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			c.delete(key)
+		}
+	}
+}
+
+// delete will remove the item with its keys from the tags index and the policy. Must be called under the lock.
+func (c *MapCacheStorage) delete(key string) {
+	item, found := c.storage[key]
+	if !found {
+		return
+	}
 	delete(c.storage, key)
+	c.bytes -= item.size
+	c.policy.Remove(key)
+	for _, tag := range item.tags {
+		if keys, ok := c.tags[tag]; ok {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(c.tags, tag)
+			}
+		}
+	}
 }
 
 func (c *MapCacheStorage) Displace() {
-	var keys []string
+	defer c.mu.Unlock()
+	c.mu.Lock()
+	now := time.Now()
+	for key, item := range c.storage {
+		if item.isExpiredAt(now) {
+			c.delete(key)
+		}
+	}
+}
 
-	c.mu.RLock()
+// displaceSample will check up to n items (in order of the map iteration, which is random) and remove the expired ones.
+func (c *MapCacheStorage) displaceSample(n int) (sampled int, expired int) {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+	now := time.Now()
 	for key, item := range c.storage {
-		if !item.expiresAt.IsZero() && item.expiresAt.UnixNano() <= time.Now().UnixNano() {
-			keys = append(keys, key)
+		if sampled == n {
+			break
+		}
+		sampled++
+		if item.isExpiredAt(now) {
+			c.delete(key)
+			expired++
 		}
 	}
-	c.mu.RUnlock()
+	return sampled, expired
+}
 
+func (c *MapCacheStorage) Stats() cacherinterface.CacheStats {
+	defer c.mu.Unlock()
 	c.mu.Lock()
-	for _, key := range keys {
-		delete(c.storage, key)
+	return &Stats{
+		hits:      atomic.LoadUint64(&c.hits),
+		misses:    atomic.LoadUint64(&c.misses),
+		evictions: c.evictions,
+		entries:   len(c.storage),
+		bytes:     c.bytes,
 	}
-	c.mu.Unlock()
 }
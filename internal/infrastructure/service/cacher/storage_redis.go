@@ -0,0 +1,355 @@
+package cacher
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	infrastructure_cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RedisCacheStorage is a storage which keeps the items in Redis, thus they are shared by all instances of
+// the application. The data is encoded by gob, so the concrete types of data must be registered (gob.Register).
+// The errors of computations are not stored (the negative caching works in the local storage only).
+type RedisCacheStorage struct {
+	ctx     context.Context
+	client  infrastructure_cacherinterface.RedisClient
+	logger  loggerinterface.Logger
+	prefix  string // prefix of all keys of the storage
+	channel string // pub/sub channel of invalidations
+	mu      sync.Mutex
+	flights map[string]*flight // in-flight computations by keys
+	hits    uint64
+	misses  uint64
+}
+
+// redisEntry is a stored item with the data which is needed for apply it to the local item.
+type redisEntry struct {
+	Data          interface{}
+	ExpiresAt     time.Time
+	SoftExpiresAt time.Time
+	Tags          []string
+	// NegativeTTL is set only for the error of computation (it's not stored).
+	NegativeTTL time.Duration
+}
+
+// invalidation is a message which is broadcast to the instances when the items were removed.
+type invalidation struct {
+	Tags []string `json:"tags,omitempty"`
+	Keys []string `json:"keys,omitempty"`
+}
+
+// NewRedisCacheStorage is a constructor of RedisCacheStorage structure.
+func NewRedisCacheStorage(
+	ctx context.Context,
+	client infrastructure_cacherinterface.RedisClient,
+	logger loggerinterface.Logger,
+	prefix string,
+	channel string,
+) *RedisCacheStorage {
+	return &RedisCacheStorage{
+		ctx:     ctx,
+		client:  client,
+		logger:  logger,
+		prefix:  prefix,
+		channel: channel,
+		flights: map[string]*flight{},
+	}
+}
+
+func (s *RedisCacheStorage) Get(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) (data interface{}, err error) {
+	entry, err := s.entry(key, fn)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Data, nil
+}
+
+// entry will return the stored entry, or it will compute and store a new one. When the computation failed, the entry
+// is returned with the error as well (it contains the negative TTL and tags which were set by the fn).
+func (s *RedisCacheStorage) entry(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) (*redisEntry, error) {
+	reply, err := s.client.Do(s.ctx, "GET", s.itemKey(key))
+	if err != nil {
+		// the storage is unavailable, the item is computed without storing
+		s.logger.Log(err)
+		atomic.AddUint64(&s.misses, 1)
+		return s.compute(fn)
+	}
+
+	if raw, ok := reply.([]byte); ok {
+		entry, err := s.decode(raw)
+		if err == nil {
+			atomic.AddUint64(&s.hits, 1)
+			if !entry.SoftExpiresAt.IsZero() && !entry.SoftExpiresAt.After(time.Now()) {
+				s.revalidate(key, fn)
+			}
+			return entry, nil
+		}
+		s.logger.Log(err)
+	}
+	atomic.AddUint64(&s.misses, 1)
+
+	s.mu.Lock()
+	if call, found := s.flights[key]; found {
+		s.mu.Unlock()
+		call.wg.Wait()
+		return s.result(call)
+	}
+	call := newFlight()
+	s.flights[key] = call
+	s.mu.Unlock()
+
+	s.run(key, call, fn)
+
+	return s.result(call)
+}
+
+// revalidate will refresh the stale entry in background, the stale entry is served meanwhile.
+func (s *RedisCacheStorage) revalidate(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) {
+	s.mu.Lock()
+	if _, found := s.flights[key]; found {
+		s.mu.Unlock()
+		return
+	}
+	call := newFlight()
+	s.flights[key] = call
+	s.mu.Unlock()
+
+	go s.run(key, call, fn)
+}
+
+// run will compute and store the entry, the result is shared with the callers which are waiting for the flight.
+func (s *RedisCacheStorage) run(key string, call *flight, fn func(cacherinterface.CacheItem) (data interface{}, err error)) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.flights, key)
+		s.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	// the entry which was computed while an invalidation happened is not stored
+	epoch, epochErr := s.client.Do(s.ctx, "GET", s.epochKey())
+
+	entry, err := s.compute(fn)
+	call.data, call.err = entry, err
+	if err != nil {
+		return
+	}
+	if epochErr != nil {
+		s.logger.Log(epochErr)
+		return
+	}
+
+	if changed, err := s.epochChanged(epoch); err != nil || changed {
+		if err != nil {
+			s.logger.Log(err)
+		}
+		return
+	}
+
+	if err = s.store(key, entry); err != nil {
+		s.logger.Log(err)
+	}
+
+	// the invalidation may happen between the check and the store, then the tags sets could be already removed
+	// without the key, thus the stored entry is removed by itself (the epoch is incremented before the removing)
+	if changed, err := s.epochChanged(epoch); err != nil || changed {
+		if err != nil {
+			s.logger.Log(err)
+		}
+		if _, err = s.client.Do(s.ctx, "DEL", s.itemKey(key)); err != nil {
+			s.logger.Log(err)
+		}
+	}
+}
+
+// epochChanged will check that the invalidation happened since the given epoch was read.
+func (s *RedisCacheStorage) epochChanged(epoch interface{}) (bool, error) {
+	current, err := s.client.Do(s.ctx, "GET", s.epochKey())
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(toBytes(current), toBytes(epoch)), nil
+}
+
+func (s *RedisCacheStorage) result(call *flight) (*redisEntry, error) {
+	entry, _ := call.data.(*redisEntry)
+	return entry, call.err
+}
+
+func (s *RedisCacheStorage) compute(fn func(cacherinterface.CacheItem) (data interface{}, err error)) (*redisEntry, error) {
+	item := NewCacheItem()
+	data, err := fn(item)
+
+	entry := &redisEntry{
+		Data:          data,
+		ExpiresAt:     item.expiresAt,
+		SoftExpiresAt: item.softExpiresAt,
+		Tags:          item.tags,
+	}
+	if err != nil {
+		entry.Data = nil
+		entry.NegativeTTL = item.negativeTTL
+		return entry, err
+	}
+	return entry, nil
+}
+
+// store will save the entry and add its key to the sets of tags.
+func (s *RedisCacheStorage) store(key string, entry *redisEntry) error {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(entry); err != nil {
+		return err
+	}
+
+	args := []string{"SET", s.itemKey(key), buf.String()}
+	ttl := time.Duration(0)
+	if !entry.ExpiresAt.IsZero() {
+		if ttl = time.Until(entry.ExpiresAt); ttl <= 0 {
+			return nil
+		}
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds()+1, 10))
+	}
+	if _, err := s.client.Do(s.ctx, args...); err != nil {
+		return err
+	}
+
+	for _, tag := range entry.Tags {
+		if err := s.addToTag(tag, key, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addToTag will add the item key to the set of tag. The set lives as long as the longest living item of the tag,
+// thus its expiry is only extended by the TTL of the item (zero TTL means the item never expires). The expiry
+// is read before the adding, because the created set has no expiry as well as the set of a never expiring item.
+func (s *RedisCacheStorage) addToTag(tag string, key string, ttl time.Duration) error {
+	reply, err := s.client.Do(s.ctx, "PTTL", s.tagKey(tag))
+	if err != nil {
+		return err
+	}
+	// -2 means the set does not exist, -1 means the set has no expiry
+	current, _ := reply.(int64)
+
+	if _, err = s.client.Do(s.ctx, "SADD", s.tagKey(tag), s.itemKey(key)); err != nil {
+		return err
+	}
+
+	if ttl <= 0 {
+		if current >= 0 {
+			_, err = s.client.Do(s.ctx, "PERSIST", s.tagKey(tag))
+		}
+		return err
+	}
+	if ms := ttl.Milliseconds() + 1; current == -2 || current >= 0 && ms > current {
+		_, err = s.client.Do(s.ctx, "PEXPIRE", s.tagKey(tag), strconv.FormatInt(ms, 10))
+	}
+	return err
+}
+
+func (s *RedisCacheStorage) decode(raw []byte) (*redisEntry, error) {
+	entry := &redisEntry{}
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *RedisCacheStorage) Delete(key string) {
+	if _, err := s.client.Do(s.ctx, "DEL", s.itemKey(key)); err != nil {
+		s.logger.Log(err)
+	}
+	s.publish(invalidation{Keys: []string{key}})
+}
+
+// InvalidateTags will remove the items of tags and broadcast the invalidation to the instances.
+func (s *RedisCacheStorage) InvalidateTags(tags ...string) {
+	if _, err := s.client.Do(s.ctx, "INCR", s.epochKey()); err != nil {
+		s.logger.Log(err)
+	}
+
+	for _, tag := range tags {
+		reply, err := s.client.Do(s.ctx, "SMEMBERS", s.tagKey(tag))
+		if err != nil {
+			s.logger.Log(err)
+			continue
+		}
+
+		args := []string{"DEL", s.tagKey(tag)}
+		members, _ := reply.([]interface{})
+		for _, member := range members {
+			args = append(args, string(toBytes(member)))
+		}
+		if _, err = s.client.Do(s.ctx, args...); err != nil {
+			s.logger.Log(err)
+		}
+	}
+
+	s.publish(invalidation{Tags: tags})
+}
+
+// Subscribe will call the handler for each invalidation which was broadcast by any instance (including this one).
+// It blocks until the context is done or the connection is broken.
+func (s *RedisCacheStorage) Subscribe(ctx context.Context, handler func(tags []string, keys []string)) error {
+	return s.client.Subscribe(ctx, s.channel, func(payload []byte) {
+		msg := invalidation{}
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			s.logger.Log(err)
+			return
+		}
+		handler(msg.Tags, msg.Keys)
+	})
+}
+
+func (s *RedisCacheStorage) publish(msg invalidation) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		s.logger.Log(err)
+		return
+	}
+	if _, err = s.client.Do(s.ctx, "PUBLISH", s.channel, string(payload)); err != nil {
+		s.logger.Log(err)
+	}
+}
+
+// Displace does nothing, the items are expired by Redis.
+func (s *RedisCacheStorage) Displace() {}
+
+// Stats will return the hits and misses of this instance (the size of the remote storage is not reported).
+func (s *RedisCacheStorage) Stats() cacherinterface.CacheStats {
+	return &Stats{
+		hits:   atomic.LoadUint64(&s.hits),
+		misses: atomic.LoadUint64(&s.misses),
+	}
+}
+
+func (s *RedisCacheStorage) itemKey(key string) string {
+	return s.prefix + "item:" + key
+}
+
+func (s *RedisCacheStorage) tagKey(tag string) string {
+	return s.prefix + "tag:" + tag
+}
+
+func (s *RedisCacheStorage) epochKey() string {
+	return s.prefix + "epoch"
+}
+
+func toBytes(reply interface{}) []byte {
+	switch v := reply.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,428 @@
+package cacher
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/logger"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/redis"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRedisCacheStorageGet(t *testing.T) {
+	server := newFakeRedis(t)
+	storage := newTestRedisStorage(t, server)
+
+	computed := 0
+	fn := func(item cacherinterface.CacheItem) (interface{}, error) {
+		computed++
+		item.SetTTL(time.Minute)
+		item.SetTags("video:1")
+		return "data", nil
+	}
+
+	for i := 0; i < 2; i++ {
+		data, err := storage.Get("key", fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data != "data" {
+			t.Fatalf("expected 'data', got '%v'", data)
+		}
+	}
+
+	if computed != 1 {
+		t.Fatalf("expected the item is computed once, computed %d times", computed)
+	}
+	if !server.exists("test:item:key") {
+		t.Fatal("expected the item is stored")
+	}
+	if !server.isMember("test:tag:video:1", "test:item:key") {
+		t.Fatal("expected the item key is added to the tag set")
+	}
+	if stats := storage.Stats(); stats.Hits() != 1 || stats.Misses() != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got %d hits and %d misses", stats.Hits(), stats.Misses())
+	}
+}
+
+func TestRedisCacheStorageInvalidateTags(t *testing.T) {
+	server := newFakeRedis(t)
+	storage := newTestRedisStorage(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan []string, 1)
+	subscribed := make(chan error, 1)
+	go func() {
+		subscribed <- storage.Subscribe(ctx, func(tags []string, keys []string) {
+			received <- tags
+		})
+	}()
+	server.awaitSubscribers(t, 1)
+
+	if _, err := storage.Get("key", func(item cacherinterface.CacheItem) (interface{}, error) {
+		item.SetTags("video:1")
+		return "data", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	storage.InvalidateTags("video:1")
+
+	if server.exists("test:item:key") || server.exists("test:tag:video:1") {
+		t.Fatal("expected the item and the tag set are removed")
+	}
+
+	select {
+	case tags := <-received:
+		if len(tags) != 1 || tags[0] != "video:1" {
+			t.Fatalf("expected the invalidation of 'video:1', got %v", tags)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the invalidation was not received")
+	}
+
+	cancel()
+	if err := <-subscribed; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the subscription is canceled, got %v", err)
+	}
+}
+
+func TestRedisCacheStorageDoesNotKeepItemInvalidatedWhileStoring(t *testing.T) {
+	server := newFakeRedis(t)
+	storage := newTestRedisStorage(t, server)
+
+	// the invalidation happens between the epoch check and the adding of the key to the tag set
+	server.before("SADD", func() {
+		storage.InvalidateTags("video:1")
+	})
+
+	data, err := storage.Get("key", func(item cacherinterface.CacheItem) (interface{}, error) {
+		item.SetTags("video:1")
+		return "data", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data != "data" {
+		t.Fatalf("expected 'data', got '%v'", data)
+	}
+
+	if server.exists("test:item:key") {
+		t.Fatal("expected the item which was invalidated while storing is removed")
+	}
+}
+
+func TestRedisCacheStorageDoesNotStoreItemInvalidatedWhileComputing(t *testing.T) {
+	server := newFakeRedis(t)
+	storage := newTestRedisStorage(t, server)
+
+	if _, err := storage.Get("key", func(item cacherinterface.CacheItem) (interface{}, error) {
+		storage.InvalidateTags("video:1")
+		return "data", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.exists("test:item:key") {
+		t.Fatal("expected the item which was invalidated while computing is not stored")
+	}
+}
+
+func TestRedisCacheStorageOnlyExtendsTagExpiry(t *testing.T) {
+	server := newFakeRedis(t)
+	storage := newTestRedisStorage(t, server)
+
+	// the item with the shorter TTL is stored after the longer one
+	for _, ttl := range []time.Duration{time.Hour, time.Minute} {
+		if _, err := storage.Get(ttl.String(), func(item cacherinterface.CacheItem) (interface{}, error) {
+			item.SetTTL(ttl)
+			item.SetTags("video:1")
+			return "data", nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ttl := server.expiry("test:tag:video:1"); ttl <= time.Minute.Milliseconds()+1 {
+		t.Fatalf("expected the expiry of the tag set is not shortened, got %dms", ttl)
+	}
+
+	if _, err := storage.Get("endless", func(item cacherinterface.CacheItem) (interface{}, error) {
+		item.SetTags("video:1")
+		return "data", nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if ttl := server.expiry("test:tag:video:1"); ttl != -1 {
+		t.Fatalf("expected the tag set of the never expiring item has no expiry, got %dms", ttl)
+	}
+}
+
+func newTestRedisStorage(t *testing.T, server *fakeRedis) *RedisCacheStorage {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	loggerService, closeLogger := logger.NewStdErr(ctx, 16, 16)
+	t.Cleanup(closeLogger)
+
+	client := redis.NewClient(server.addr(), "", 0, 4, time.Second)
+	return NewRedisCacheStorage(ctx, client, loggerService, "test:", "test:invalidations")
+}
+
+// fakeRedis is an in-process server of the Redis protocol which supports the commands of the storage.
+type fakeRedis struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	strings     map[string]string
+	sets        map[string]map[string]struct{}
+	expires     map[string]int64
+	subscribers map[string][]*bufio.Writer
+	hooks       map[string]func()
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeRedis{
+		listener:    listener,
+		strings:     map[string]string{},
+		sets:        map[string]map[string]struct{}{},
+		expires:     map[string]int64{},
+		subscribers: map[string][]*bufio.Writer{},
+		hooks:       map[string]func(){},
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeRedis) addr() string {
+	return s.listener.Addr().String()
+}
+
+// before will call the hook once before the next command with the given name is executed.
+func (s *fakeRedis) before(command string, hook func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[command] = hook
+}
+
+func (s *fakeRedis) exists(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, isString := s.strings[key]
+	_, isSet := s.sets[key]
+	return isString || isSet
+}
+
+func (s *fakeRedis) isMember(key string, member string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, found := s.sets[key][member]
+	return found
+}
+
+func (s *fakeRedis) expiry(key string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pttl(key)
+}
+
+// pttl returns the expiry of the key in milliseconds, -1 if the key has no expiry and -2 if it does not exist
+// (the lock is held by the caller).
+func (s *fakeRedis) pttl(key string) int64 {
+	_, isString := s.strings[key]
+	_, isSet := s.sets[key]
+	if !isString && !isSet {
+		return -2
+	}
+	if ms, found := s.expires[key]; found {
+		return ms
+	}
+	return -1
+}
+
+func (s *fakeRedis) awaitSubscribers(t *testing.T, n int) {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		s.mu.Lock()
+		subscribers := 0
+		for _, ws := range s.subscribers {
+			subscribers += len(ws)
+		}
+		s.mu.Unlock()
+		if subscribers >= n {
+			return
+		}
+	}
+	t.Fatalf("%d subscribers were not connected", n)
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(args[0])
+		s.mu.Lock()
+		hook := s.hooks[command]
+		delete(s.hooks, command)
+		s.mu.Unlock()
+		if hook != nil {
+			hook()
+		}
+
+		s.mu.Lock()
+		reply := s.execute(command, args[1:], w)
+		_, err = w.WriteString(reply)
+		if err == nil {
+			err = w.Flush()
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// execute will apply the command and return the encoded reply (the lock is held by the caller).
+func (s *fakeRedis) execute(command string, args []string, w *bufio.Writer) string {
+	switch command {
+	case "GET":
+		if v, found := s.strings[args[0]]; found {
+			return bulk(v)
+		}
+		return "$-1\r\n"
+	case "SET":
+		// the expiration (PX) is not applied, the tests don't wait for it
+		s.strings[args[0]] = args[1]
+		return "+OK\r\n"
+	case "INCR":
+		n, _ := strconv.ParseInt(s.strings[args[0]], 10, 64)
+		s.strings[args[0]] = strconv.FormatInt(n+1, 10)
+		return integer(n + 1)
+	case "DEL":
+		removed := 0
+		for _, key := range args {
+			_, isString := s.strings[key]
+			_, isSet := s.sets[key]
+			if isString || isSet {
+				removed++
+			}
+			delete(s.strings, key)
+			delete(s.sets, key)
+			delete(s.expires, key)
+		}
+		return integer(int64(removed))
+	case "SADD":
+		set, found := s.sets[args[0]]
+		if !found {
+			set = map[string]struct{}{}
+			s.sets[args[0]] = set
+		}
+		added := 0
+		for _, member := range args[1:] {
+			if _, found = set[member]; !found {
+				set[member] = struct{}{}
+				added++
+			}
+		}
+		return integer(int64(added))
+	case "SMEMBERS":
+		reply := fmt.Sprintf("*%d\r\n", len(s.sets[args[0]]))
+		for member := range s.sets[args[0]] {
+			reply += bulk(member)
+		}
+		return reply
+	case "PTTL":
+		return integer(s.pttl(args[0]))
+	case "PEXPIRE":
+		// the expiration is not applied (the tests don't wait for it), only the expiry is stored
+		ms, _ := strconv.ParseInt(args[1], 10, 64)
+		s.expires[args[0]] = ms
+		return integer(1)
+	case "PERSIST":
+		if _, found := s.expires[args[0]]; !found {
+			return integer(0)
+		}
+		delete(s.expires, args[0])
+		return integer(1)
+	case "PUBLISH":
+		message := "*3\r\n" + bulk("message") + bulk(args[0]) + bulk(args[1])
+		for _, sw := range s.subscribers[args[0]] {
+			_, _ = sw.WriteString(message)
+			_ = sw.Flush()
+		}
+		return integer(int64(len(s.subscribers[args[0]])))
+	case "SUBSCRIBE":
+		s.subscribers[args[0]] = append(s.subscribers[args[0]], w)
+		return "*3\r\n" + bulk("subscribe") + bulk(args[0]) + integer(1)
+	default:
+		return fmt.Sprintf("-ERR unknown command '%v'\r\n", command)
+	}
+}
+
+// readCommand will read the command which is sent as an array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("malformed command %q", line)
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, length+2)
+		if _, err = io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		args[i] = string(b[:length])
+	}
+	return args, nil
+}
+
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+func integer(n int64) string {
+	return fmt.Sprintf(":%d\r\n", n)
+}
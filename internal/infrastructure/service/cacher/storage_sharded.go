@@ -0,0 +1,132 @@
+package cacher
+
+import (
+	"context"
+	"fmt"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	infrastructure_cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"time"
+)
+
+const (
+	// displaceSampleSize is a number of items which are checked for expiration by one step of the shard displacement.
+	displaceSampleSize = 64
+	// displaceRepeatRatio is a min. part of expired items of the sample (in percents) when the next sample
+	// of the shard is checked, otherwise the rest of expired items are left for the next displacement.
+	displaceRepeatRatio = 25
+	// displaceShardBudget is a max. duration of displacement of one shard.
+	displaceShardBudget = time.Millisecond
+)
+
+// ShardedCacheStorage is a storage which distributes the items over the shards by hash of keys, each shard
+// has own lock, limits and eviction policy, thus the concurrent requests of different keys don't contend.
+type ShardedCacheStorage struct {
+	shards []*MapCacheStorage
+	mask   uint32
+}
+
+// NewShardedCacheStorage is a constructor of ShardedCacheStorage structure. The number of shards is rounded up to
+// the power of two, the limits of items are divided between the shards.
+func NewShardedCacheStorage(
+	ctx context.Context,
+	shards int,
+	policy string,
+	maxEntries int,
+	maxBytes int64,
+) (*ShardedCacheStorage, error) {
+	if shards <= 0 {
+		return nil, fmt.Errorf("number of cache shards must be positive, '%d' given", shards)
+	}
+
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+
+	shardMaxEntries := (maxEntries + n - 1) / n
+	shardMaxBytes := (maxBytes + int64(n) - 1) / int64(n)
+
+	s := &ShardedCacheStorage{
+		shards: make([]*MapCacheStorage, n),
+		mask:   uint32(n - 1),
+	}
+	for i := range s.shards {
+		p, err := NewEvictionPolicy(policy, shardMaxEntries)
+		if err != nil {
+			return nil, err
+		}
+		s.shards[i] = NewMapCacheStorage(ctx, p, shardMaxEntries, shardMaxBytes)
+	}
+
+	return s, nil
+}
+
+func (s *ShardedCacheStorage) Get(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) (data interface{}, err error) {
+	return s.shard(key).Get(key, fn)
+}
+
+func (s *ShardedCacheStorage) Delete(key string) {
+	s.shard(key).Delete(key)
+}
+
+func (s *ShardedCacheStorage) InvalidateTags(tags ...string) {
+	for _, shard := range s.shards {
+		shard.InvalidateTags(tags...)
+	}
+}
+
+// Displace will remove the expired items shard by shard. Each shard is checked by samples (the lock is held only
+// while one sample is checked) until the part of expired items in the sample is small or the budget is exceeded.
+// The expired items which were not removed are not served anyway.
+func (s *ShardedCacheStorage) Displace() {
+	for _, shard := range s.shards {
+		deadline := time.Now().Add(displaceShardBudget)
+		for {
+			sampled, expired := shard.displaceSample(displaceSampleSize)
+			if sampled == 0 || expired*100 < sampled*displaceRepeatRatio || time.Now().After(deadline) {
+				break
+			}
+		}
+	}
+}
+
+func (s *ShardedCacheStorage) Stats() cacherinterface.CacheStats {
+	stats := &Stats{}
+	for _, shard := range s.shards {
+		shardStats := shard.Stats()
+		stats.hits += shardStats.Hits()
+		stats.misses += shardStats.Misses()
+		stats.evictions += shardStats.Evictions()
+		stats.entries += shardStats.Entries()
+		stats.bytes += shardStats.Bytes()
+	}
+	return stats
+}
+
+func (s *ShardedCacheStorage) shard(key string) *MapCacheStorage {
+	// inlined FNV-1a, it doesn't allocate unlike hash/fnv
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return s.shards[h&s.mask]
+}
+
+// NewCacheStorage is a constructor of the storage by the number of shards: one shard means the plain map storage.
+func NewCacheStorage(
+	ctx context.Context,
+	shards int,
+	policy string,
+	maxEntries int,
+	maxBytes int64,
+) (infrastructure_cacherinterface.Storage, error) {
+	if shards == 1 {
+		p, err := NewEvictionPolicy(policy, maxEntries)
+		if err != nil {
+			return nil, err
+		}
+		return NewMapCacheStorage(ctx, p, maxEntries, maxBytes), nil
+	}
+	return NewShardedCacheStorage(ctx, shards, policy, maxEntries, maxBytes)
+}
@@ -0,0 +1,123 @@
+package cacher
+
+import (
+	"context"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	infrastructure_cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// The storages are compared under concurrent load:
+//
+//	go test ./internal/infrastructure/service/cacher -run '^$' -bench Storage -cpu 8
+const (
+	benchShards = 32
+	benchKeys   = 100000
+	benchPolicy = WTinyLFUEvictionPolicy
+)
+
+func BenchmarkStorageHits(b *testing.B) {
+	benchmarkStorages(b, func(b *testing.B, storage infrastructure_cacherinterface.Storage, keys []string) {
+		fn := compute(time.Hour)
+		b.RunParallel(func(pb *testing.PB) {
+			rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+			for pb.Next() {
+				_, _ = storage.Get(keys[rnd.Intn(len(keys))], fn)
+			}
+		})
+	})
+}
+
+func BenchmarkStorageMixed(b *testing.B) {
+	benchmarkStorages(b, func(b *testing.B, storage infrastructure_cacherinterface.Storage, keys []string) {
+		fn := compute(time.Hour)
+		var misses uint64
+		b.RunParallel(func(pb *testing.PB) {
+			rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+			for pb.Next() {
+				// 90% hits, 10% misses
+				if rnd.Intn(10) == 0 {
+					_, _ = storage.Get("miss:"+strconv.FormatUint(atomic.AddUint64(&misses, 1), 10), fn)
+					continue
+				}
+				_, _ = storage.Get(keys[rnd.Intn(len(keys))], fn)
+			}
+		})
+	})
+}
+
+func BenchmarkStorageHitsWhileDisplacing(b *testing.B) {
+	benchmarkStorages(b, func(b *testing.B, storage infrastructure_cacherinterface.Storage, keys []string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			for ctx.Err() == nil {
+				storage.Displace()
+				fill(storage, keys[:len(keys)/10])
+			}
+		}()
+
+		fn := compute(time.Hour)
+		b.RunParallel(func(pb *testing.PB) {
+			rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+			for pb.Next() {
+				_, _ = storage.Get(keys[rnd.Intn(len(keys))], fn)
+			}
+		})
+	})
+}
+
+// benchmarkStorages will run the benchmark against the plain map storage and the sharded one which are filled by keys.
+func benchmarkStorages(
+	b *testing.B,
+	run func(b *testing.B, storage infrastructure_cacherinterface.Storage, keys []string),
+) {
+	keys := make([]string, benchKeys)
+	for i := range keys {
+		keys[i] = "key:" + strconv.Itoa(i)
+	}
+
+	for _, shards := range []int{1, benchShards} {
+		name := "map"
+		if shards > 1 {
+			name = "sharded/" + strconv.Itoa(shards)
+		}
+
+		b.Run(name, func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			storage, err := NewCacheStorage(ctx, shards, benchPolicy, benchKeys, 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+			fill(storage, keys)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			run(b, storage, keys)
+		})
+	}
+}
+
+// fill will store all keys with TTL of one hour (the sample of them is expired for the displacement benchmark).
+func fill(storage infrastructure_cacherinterface.Storage, keys []string) {
+	for i, key := range keys {
+		ttl := time.Hour
+		if i%10 == 0 {
+			ttl = time.Nanosecond
+		}
+		_, _ = storage.Get(key, compute(ttl))
+	}
+}
+
+func compute(ttl time.Duration) func(cacherinterface.CacheItem) (interface{}, error) {
+	return func(item cacherinterface.CacheItem) (interface{}, error) {
+		item.SetTTL(ttl)
+		return struct{}{}, nil
+	}
+}
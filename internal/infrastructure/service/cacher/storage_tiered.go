@@ -0,0 +1,119 @@
+package cacher
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	infrastructure_cacherinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher/interface"
+	"time"
+)
+
+const (
+	// tieredResubscribeInterval is a delay before the next subscription attempt when the connection was broken.
+	tieredResubscribeInterval = time.Second
+	// tieredStaleRefreshInterval is a soft TTL of the local item which was built from the stale remote entry
+	// (the remote entry is refreshed in background meanwhile).
+	tieredStaleRefreshInterval = time.Second
+)
+
+// TieredCacheStorage is a two-tier storage: the local storage (L1) of the instance is backed by the remote one (L2)
+// which is shared by all instances. The invalidations are broadcast through the remote storage, thus each instance
+// drops the invalidated items of its local storage. When the broadcast is missed (e.g. the connection was broken),
+// the local items are still refreshed from the remote storage after their soft TTL.
+type TieredCacheStorage struct {
+	ctx    context.Context
+	local  infrastructure_cacherinterface.Storage
+	remote *RedisCacheStorage
+	logger loggerinterface.Logger
+}
+
+// NewTieredCacheStorage is a constructor of TieredCacheStorage structure, it subscribes to the invalidations
+// until the context is done.
+func NewTieredCacheStorage(
+	ctx context.Context,
+	local infrastructure_cacherinterface.Storage,
+	remote *RedisCacheStorage,
+	logger loggerinterface.Logger,
+) *TieredCacheStorage {
+	s := &TieredCacheStorage{
+		ctx:    ctx,
+		local:  local,
+		remote: remote,
+		logger: logger,
+	}
+	go s.listen()
+	return s
+}
+
+func (s *TieredCacheStorage) Get(key string, fn func(cacherinterface.CacheItem) (data interface{}, err error)) (data interface{}, err error) {
+	return s.local.Get(key, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		entry, err := s.remote.entry(key, fn)
+		if entry != nil {
+			s.apply(entry, item)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return entry.Data, nil
+	})
+}
+
+// apply will set the TTLs and tags of the remote entry to the local item.
+func (s *TieredCacheStorage) apply(entry *redisEntry, item cacherinterface.CacheItem) {
+	if !entry.ExpiresAt.IsZero() {
+		item.SetTTL(time.Until(entry.ExpiresAt))
+	}
+	if !entry.SoftExpiresAt.IsZero() {
+		softTTL := time.Until(entry.SoftExpiresAt)
+		if softTTL < tieredStaleRefreshInterval {
+			softTTL = tieredStaleRefreshInterval
+		}
+		item.SetSoftTTL(softTTL)
+	}
+	if entry.NegativeTTL > 0 {
+		item.SetNegativeTTL(entry.NegativeTTL)
+	}
+	item.SetTags(entry.Tags...)
+}
+
+func (s *TieredCacheStorage) Delete(key string) {
+	s.remote.Delete(key)
+	s.local.Delete(key)
+}
+
+// InvalidateTags will remove the items from the remote storage first, thus the local storage cannot be refilled
+// by the invalidated remote entries.
+func (s *TieredCacheStorage) InvalidateTags(tags ...string) {
+	s.remote.InvalidateTags(tags...)
+	s.local.InvalidateTags(tags...)
+}
+
+func (s *TieredCacheStorage) Displace() {
+	s.local.Displace()
+}
+
+// Stats will return the counters of the local storage.
+func (s *TieredCacheStorage) Stats() cacherinterface.CacheStats {
+	return s.local.Stats()
+}
+
+// listen will apply the invalidations of other instances to the local storage.
+func (s *TieredCacheStorage) listen() {
+	for {
+		err := s.remote.Subscribe(s.ctx, func(tags []string, keys []string) {
+			if len(tags) > 0 {
+				s.local.InvalidateTags(tags...)
+			}
+			for _, key := range keys {
+				s.local.Delete(key)
+			}
+		})
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(tieredResubscribeInterval):
+			s.logger.Log(err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package typed
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	cacherinterface "github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"reflect"
+)
+
+// Cache is a type-safe front-end of the cacher: the items are computed by the typed loaders and returned as values
+// of type V. The keys are prefixed by the namespace, thus the caches which share one cacher never read
+// the items of each other (the type of data cannot be mismatched unless the namespace is reused with other type).
+type Cache[K any, V any] struct {
+	cacher    cacherinterface.Cacher
+	namespace string
+	key       func(K) (string, error)
+}
+
+// NewCache is a constructor of Cache structure. The key function builds the key of the item from the given key K.
+func NewCache[K any, V any](cacher cacherinterface.Cacher, namespace string, key func(K) (string, error)) *Cache[K, V] {
+	// the concrete type of values is registered for the remote storage which encodes the data by gob
+	if reflect.TypeOf((*V)(nil)).Elem().Kind() != reflect.Interface {
+		var zero V
+		gob.Register(zero)
+	}
+
+	return &Cache[K, V]{
+		cacher:    cacher,
+		namespace: namespace,
+		key:       key,
+	}
+}
+
+// Get will return the cached value by the key, or it will compute the value by the loader when it's not cached.
+func (c *Cache[K, V]) Get(key K, load func(item cacherinterface.CacheItem) (V, error)) (V, error) {
+	var zero V
+
+	k, err := c.key(key)
+	if err != nil {
+		return zero, err
+	}
+	k = c.namespace + ":" + k
+
+	data, err := c.cacher.Get(k, func(item cacherinterface.CacheItem) (interface{}, error) {
+		return load(item)
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	value, ok := data.(V)
+	if !ok {
+		return zero, errtype.NewCachedDataTypeWasNotMatchedError(k, reflect.TypeOf((*V)(nil)).Elem(), reflect.TypeOf(data))
+	}
+
+	return value, nil
+}
+
+// InvalidateTags will remove the items which are marked by any of the given tags (through all namespaces).
+func (c *Cache[K, V]) InvalidateTags(tags ...string) {
+	c.cacher.InvalidateTags(tags...)
+}
+
+// JSONKey is a key function which hashes the JSON representation of the key (e.g. of the query).
+func JSONKey[K any](key K) (string, error) {
+	p, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return helper.MD5(p), nil
+}
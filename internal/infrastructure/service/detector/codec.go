@@ -61,3 +61,48 @@ func (d *ResourceCodecs) Detect(
 
 	return audioCodec, videoCodec, nil
 }
+
+// DetectBitrate will determine the average bitrate of target resource by the file size and the media duration.
+func (d *ResourceCodecs) DetectBitrate(resource entity.Resource) (bytesPerSecond float64, e error) {
+	file, err := os.Open(resource.GetFilepath())
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+
+	data, err := ffprobe.ProbeReader(d.ctx, file)
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+
+	if data.Format == nil || data.Format.DurationSeconds <= 0 {
+		return 0, nil
+	}
+
+	return float64(stat.Size()) / data.Format.DurationSeconds, nil
+}
+
+// DetectDuration will determine the duration of target resource in seconds by the media format.
+func (d *ResourceCodecs) DetectDuration(resource entity.Resource) (seconds float64, e error) {
+	file, err := os.Open(resource.GetFilepath())
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := ffprobe.ProbeReader(d.ctx, file)
+	if err != nil {
+		return 0, d.logger.LogPropagate(err)
+	}
+
+	if data.Format == nil || data.Format.DurationSeconds <= 0 {
+		return 0, nil
+	}
+
+	return data.Format.DurationSeconds, nil
+}
@@ -4,4 +4,9 @@ import "github.com/Borislavv/video-streaming/internal/domain/entity"
 
 type Codecs interface {
 	Detect(resource entity.Resource) (audioCodec string, videoCodec string, err error)
+	// DetectBitrate will determine the average bitrate of target resource in bytes per second
+	// (zero means the duration of the resource is unknown).
+	DetectBitrate(resource entity.Resource) (bytesPerSecond float64, err error)
+	// DetectDuration will determine the duration of target resource in seconds (zero means it's unknown).
+	DetectDuration(resource entity.Resource) (seconds float64, err error)
 }
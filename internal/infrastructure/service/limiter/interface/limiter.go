@@ -0,0 +1,12 @@
+package limiterinterface
+
+import "github.com/Borislavv/video-streaming/internal/domain/vo"
+
+type StreamingLimiter interface {
+	// AcquireConnection will take a slot of the connection by the client address and the user.
+	// The release func must be called when the connection is closed.
+	AcquireConnection(ip string, userID vo.ID) (release func(), err error)
+	// AcquireStream will take a slot of the stream by the user.
+	// The release func must be called when the stream is finished.
+	AcquireStream(userID vo.ID) (release func(), err error)
+}
@@ -0,0 +1,113 @@
+package limiter
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"sync"
+)
+
+// unlimited is a value of limit which disables it.
+const unlimited = 0
+
+// StreamingLimiter counts the connections and the streams of the current instance in memory.
+type StreamingLimiter struct {
+	logger loggerinterface.Logger
+
+	maxConnections        int
+	maxConnectionsPerIP   int
+	maxConnectionsPerUser int
+	maxStreamsPerUser     int
+
+	mu                 *sync.Mutex
+	connections        int
+	connectionsPerIP   map[string]int
+	connectionsPerUser map[vo.ID]int
+	streamsPerUser     map[vo.ID]int
+}
+
+func NewStreamingLimiter(serviceContainer diinterface.ServiceContainer) (*StreamingLimiter, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &StreamingLimiter{
+		logger:                loggerService,
+		maxConnections:        cfg.StreamingMaxConnections,
+		maxConnectionsPerIP:   cfg.StreamingMaxConnectionsPerIP,
+		maxConnectionsPerUser: cfg.StreamingMaxConnectionsPerUser,
+		maxStreamsPerUser:     cfg.StreamingMaxStreamsPerUser,
+		mu:                    &sync.Mutex{},
+		connectionsPerIP:      make(map[string]int),
+		connectionsPerUser:    make(map[vo.ID]int),
+		streamsPerUser:        make(map[vo.ID]int),
+	}, nil
+}
+
+func (l *StreamingLimiter) AcquireConnection(ip string, userID vo.ID) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxConnections != unlimited && l.connections >= l.maxConnections {
+		return nil, l.logger.LogPropagate(errtype.NewServerIsOverloadedError())
+	}
+	if l.maxConnectionsPerIP != unlimited && l.connectionsPerIP[ip] >= l.maxConnectionsPerIP {
+		return nil, l.logger.LogPropagate(errtype.NewTooManyConnectionsError("client address", l.maxConnectionsPerIP))
+	}
+	if l.maxConnectionsPerUser != unlimited && l.connectionsPerUser[userID] >= l.maxConnectionsPerUser {
+		return nil, l.logger.LogPropagate(errtype.NewTooManyConnectionsError("user", l.maxConnectionsPerUser))
+	}
+
+	l.connections++
+	l.connectionsPerIP[ip]++
+	l.connectionsPerUser[userID]++
+
+	return l.once(func() {
+		l.connections--
+		decrement(l.connectionsPerIP, ip)
+		decrement(l.connectionsPerUser, userID)
+	}), nil
+}
+
+func (l *StreamingLimiter) AcquireStream(userID vo.ID) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxStreamsPerUser != unlimited && l.streamsPerUser[userID] >= l.maxStreamsPerUser {
+		return nil, l.logger.LogPropagate(errtype.NewTooManyStreamsError(l.maxStreamsPerUser))
+	}
+
+	l.streamsPerUser[userID]++
+
+	return l.once(func() {
+		decrement(l.streamsPerUser, userID)
+	}), nil
+}
+
+// once wraps the release func, thus the slot cannot be released twice.
+func (l *StreamingLimiter) once(release func()) func() {
+	o := &sync.Once{}
+	return func() {
+		o.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			release()
+		})
+	}
+}
+
+// decrement will decrease the counter by key and remove it when it's zero (the maps must not grow infinitely).
+func decrement[K comparable](counters map[K]int, key K) {
+	if counters[key] <= 1 {
+		delete(counters, key)
+		return
+	}
+	counters[key]--
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+const (
+	SmtpMailerType   = "smtp"
+	OutboxMailerType = "outbox"
+)
+
+// headerSanitizer removes line breaks from header values, otherwise arbitrary headers may be injected.
+var headerSanitizer = strings.NewReplacer("\r", "", "\n", "")
+
+// buildMessage will build a plain text RFC 5322 message.
+func buildMessage(from string, to string, subject string, body string) []byte {
+	b := bytes.Buffer{}
+	b.WriteString(fmt.Sprintf("From: %v\r\n", headerSanitizer.Replace(from)))
+	b.WriteString(fmt.Sprintf("To: %v\r\n", headerSanitizer.Replace(to)))
+	b.WriteString(fmt.Sprintf("Subject: %v\r\n", mime.QEncoding.Encode("utf-8", headerSanitizer.Replace(subject))))
+	b.WriteString(fmt.Sprintf("Date: %v\r\n", time.Now().Format(time.RFC1123Z)))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return b.Bytes()
+}
@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// OutboxMailer writes letters as .eml files into the outbox directory instead of sending them,
+// it's useful for local development and testing (files may be opened by any mail client).
+type OutboxMailer struct {
+	logger loggerinterface.Logger
+	dir    string
+	from   string
+}
+
+func NewOutboxMailer(serviceContainer diinterface.ServiceContainer) (*OutboxMailer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	dir, err := helper.MailOutboxDir()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &OutboxMailer{
+		logger: loggerService,
+		dir:    dir,
+		from:   cfg.MailFromAddress,
+	}, nil
+}
+
+// Send will write a plain text letter into the outbox directory.
+func (m *OutboxMailer) Send(to string, subject string, body string) error {
+	filename := filepath.Join(m.dir, fmt.Sprintf("%d_%v.eml", time.Now().UnixNano(), helper.MD5([]byte(to))))
+
+	if err := os.WriteFile(filename, buildMessage(m.from, to, subject, body), 0644); err != nil {
+		return m.logger.LogPropagate(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"net"
+	"net/smtp"
+)
+
+// SmtpMailer delivers letters through the configured SMTP server.
+type SmtpMailer struct {
+	logger loggerinterface.Logger
+	addr   string
+	auth   smtp.Auth
+	from   string
+}
+
+func NewSmtpMailer(serviceContainer diinterface.ServiceContainer) (*SmtpMailer, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	// authentication is optional, for example, a local relay may not require it
+	var auth smtp.Auth
+	if cfg.SmtpUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SmtpUsername, cfg.SmtpPassword, cfg.SmtpHost)
+	}
+
+	return &SmtpMailer{
+		logger: loggerService,
+		addr:   net.JoinHostPort(cfg.SmtpHost, cfg.SmtpPort),
+		auth:   auth,
+		from:   cfg.MailFromAddress,
+	}, nil
+}
+
+// Send will deliver a plain text letter to given address.
+func (m *SmtpMailer) Send(to string, subject string, body string) error {
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, buildMessage(m.from, to, subject, body)); err != nil {
+		return m.logger.LogPropagate(err)
+	}
+	return nil
+}
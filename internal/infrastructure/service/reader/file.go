@@ -3,12 +3,14 @@ package reader
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	diinterface "github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
 	"math"
 	"os"
 	"sync"
+	"time"
 )
 
 const (
@@ -16,10 +18,21 @@ const (
 	readingThreads = 5
 )
 
+const (
+	// PreadMode means that each chunk is read from the file.
+	PreadMode = "pread"
+	// MmapMode means that the file is mapped into memory and the chunks are copied from the mapping.
+	MmapMode = "mmap"
+)
+
 type FileReaderService struct {
 	ctx       context.Context
 	logger    loggerinterface.Logger
 	chunkSize int
+	mmap      bool
+	buffers   *sync.Pool
+	segments  *SegmentCache
+	prefetch  int64
 }
 
 func NewFileReaderService(serviceContainer diinterface.ServiceContainer) (*FileReaderService, error) {
@@ -38,14 +51,36 @@ func NewFileReaderService(serviceContainer diinterface.ServiceContainer) (*FileR
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	return &FileReaderService{
+	if cfg.FileReaderMode != PreadMode && cfg.FileReaderMode != MmapMode {
+		return nil, loggerService.LogPropagate(fmt.Errorf("unknown file reader mode '%v' given", cfg.FileReaderMode))
+	}
+
+	r := &FileReaderService{
 		ctx:       ctx,
 		logger:    loggerService,
 		chunkSize: cfg.StreamingChunkSize,
-	}, nil
+		mmap:      cfg.FileReaderMode == MmapMode,
+		prefetch:  int64(cfg.SegmentCachePrefetchSegments),
+	}
+	r.buffers = &sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, r.chunkSize)
+			return &buf
+		},
+	}
+
+	if cfg.SegmentCacheMaxBytes > 0 {
+		window, err := time.ParseDuration(cfg.SegmentCacheAdmissionWindow)
+		if err != nil {
+			return nil, loggerService.LogPropagate(err)
+		}
+		r.segments = NewSegmentCache(ctx, cfg.SegmentCacheMaxBytes, cfg.SegmentCacheAdmissionThreshold, window)
+	}
+
+	return r, nil
 }
 
-// ReadAll - reads a whole file in a single chunk.
+// ReadAll - reads a whole file in a single chunk (the chunks are read concurrently right into the target buffer).
 func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 	r.logger.Info(fmt.Sprintf("reading all file '%v' started", file.Name()))
 
@@ -67,7 +102,6 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 	wg := &sync.WaitGroup{}
 
 	taskCh := make(chan *struct {
-		num    int64
 		offset int64
 		length int64
 	}, threads)
@@ -87,63 +121,62 @@ func (r *FileReaderService) ReadAll(file *os.File) *model.Chunk {
 			}
 
 			taskCh <- &struct {
-				num    int64
 				offset int64
 				length int64
 			}{
-				num:    chk,
 				offset: offset,
 				length: length,
 			}
 		}
 	}()
 
-	// map of file chunks by int64 serial number
-	fileMap := make(map[int64][]byte, chunks)
-	// make a mutex for concurrently write into the file map
+	// the whole file is read into the one chunk, each consumer fills its own part of the buffer
+	chunk := model.NewChunk(stat.Size(), stat.Size())
+	failed := false
 	mu := &sync.Mutex{}
 
 	// consumer
 	wg.Add(int(threads))
-	go func() {
-		for thrd := int64(0); thrd < threads; thrd++ {
-			go func(thrd int64) {
-				defer wg.Done()
-
-				for task := range taskCh {
-					buff := make([]byte, task.length)
-					_, err := file.ReadAt(buff, task.offset)
-					if err != nil {
-						r.logger.Critical(
-							fmt.Sprintf("reading all file '%v' error: %v at %d thread", file.Name(), err, thrd),
-						)
-						return
-					}
+	for thrd := int64(0); thrd < threads; thrd++ {
+		go func(thrd int64) {
+			defer wg.Done()
 
+			for task := range taskCh {
+				if _, err := file.ReadAt(chunk.Data[task.offset:task.offset+task.length], task.offset); err != nil {
+					r.logger.Critical(
+						fmt.Sprintf("reading all file '%v' error: %v at %d thread", file.Name(), err, thrd),
+					)
 					mu.Lock()
-					fileMap[task.num] = buff
+					failed = true
 					mu.Unlock()
+					// the remaining tasks are drained, thus the provider is not blocked
+					for range taskCh {
+					}
+					return
 				}
-			}(thrd)
-		}
-	}()
+			}
+		}(thrd)
+	}
 
 	// awaiting while whole file will be read
 	wg.Wait()
 
-	defer r.logger.Info(fmt.Sprintf("reading all file '%v' finished properly", file.Name()))
-
-	// collect the entire file into the one chunk
-	chunk := model.NewChunk(0, stat.Size())
-	for i := int64(0); i < int64(len(fileMap)); i++ {
-		chunk.Data = append(chunk.Data, fileMap[i]...)
+	if failed {
+		return nil
 	}
+
+	r.logger.Info(fmt.Sprintf("reading all file '%v' finished properly", file.Name()))
 	return chunk
 }
 
 // ReadByChunks - reads a file by separated chunks
 // and passed it into the channel (chunk size is setting up through env. configuration).
 func (r *FileReaderService) ReadByChunks(file *os.File, offset int64) chan *model.Chunk {
+	return r.readByChunks(r.ctx, file, offset)
+}
+
+// readByChunks - reads a file by chunks until the given context is done.
+func (r *FileReaderService) readByChunks(ctx context.Context, file *os.File, offset int64) chan *model.Chunk {
 	r.logger.Info(fmt.Sprintf("reading file '%v' by chunks started", file.Name()))
 
 	stat, err := file.Stat()
@@ -152,12 +185,20 @@ func (r *FileReaderService) ReadByChunks(file *os.File, offset int64) chan *mode
 		return nil
 	}
 
+	if r.mmap && stat.Size() > 0 {
+		data, err := mmap(file, stat.Size())
+		if err == nil {
+			return r.readMappedByChunks(ctx, file, data, offset)
+		}
+		r.logger.Warning(fmt.Sprintf("mapping file '%v' failed, it will be read by chunks: %v", file.Name(), err))
+	}
+
 	ch := make(chan *model.Chunk, chunksChBuffer)
 	go func() {
 		defer close(ch)
 		for {
 			select {
-			case <-r.ctx.Done():
+			case <-ctx.Done():
 				r.logger.Info(fmt.Sprintf("reading file '%v' by chunks interrupted", file.Name()))
 				return
 			default:
@@ -171,27 +212,151 @@ func (r *FileReaderService) ReadByChunks(file *os.File, offset int64) chan *mode
 					return
 				}
 
-				// make a new chunk with appropriate buffer
-				chunk := model.NewChunk(currentChunkSize, currentChunkSize)
+				// take a buffer from the pool, it will be returned back when the consumer releases the chunk
+				buf := r.buffers.Get().(*[]byte)
+				chunk := model.NewPooledChunk((*buf)[:currentChunkSize], func() { r.buffers.Put(buf) })
 
 				// read the current batch of bites
 				length, err := file.ReadAt(chunk.Data, offset)
 				if err != nil {
+					chunk.Release()
 					r.logger.Error(err)
 					r.logger.Info(fmt.Sprintf("reading file '%v' by chunks finished with errors", file.Name()))
 					return
 				}
 				offset += int64(length)
 
-				// cut the last chunk to its real length
-				if chunk.GetLen() < r.chunkSize {
-					chunk.SetData(chunk.GetData()[:chunk.GetLen()])
+				// sent the chunk to consumer
+				select {
+				case ch <- chunk:
+				case <-ctx.Done():
+					chunk.Release()
+					r.logger.Info(fmt.Sprintf("reading file '%v' by chunks interrupted", file.Name()))
+					return
 				}
+			}
+		}
+	}()
+	return ch
+}
 
-				// sent the chunk to consumer
-				ch <- chunk
+// ReadResourceByChunks - reads a resource file by chunks like ReadByChunks, but the chunks of hot resources
+// are served from the in-memory segment cache (segments are aligned to the chunk size) and the next segments
+// are read ahead of the stream. The chunks of hot resources are shared, thus they must not be modified.
+// The reading (and the read ahead) is stopped when the given context of the stream is done, because
+// the cached segments are served without touching the file.
+func (r *FileReaderService) ReadResourceByChunks(
+	ctx context.Context,
+	resource entity.Resource,
+	file *os.File,
+	offset int64,
+) chan *model.Chunk {
+	if r.segments == nil || !r.segments.admit(resource.GetID()) {
+		return r.readByChunks(ctx, file, offset)
+	}
+
+	r.logger.Info(fmt.Sprintf("reading hot file '%v' by segments started", file.Name()))
+
+	stat, err := file.Stat()
+	if err != nil {
+		r.logger.Info(fmt.Sprintf("reading file '%v' by segments file stat with errors: %v", file.Name(), err))
+		return nil
+	}
+
+	ch := make(chan *model.Chunk, chunksChBuffer)
+	go func() {
+		defer close(ch)
+
+		chunkSize := int64(r.chunkSize)
+		// the first segment is cut when the offset is not aligned to the chunk size
+		skip := offset % chunkSize
+		prefetched := offset / chunkSize
+
+		for segment := offset / chunkSize; segment*chunkSize < stat.Size(); segment++ {
+			select {
+			case <-ctx.Done():
+				r.logger.Info(fmt.Sprintf("reading file '%v' by segments interrupted", file.Name()))
+				return
+			default:
+			}
+
+			segmentOffset, length := r.segmentBounds(segment, stat.Size())
+			data, err := r.segments.get(resource.GetID(), segmentOffset, length, func() ([]byte, error) {
+				return r.readSegment(file, segmentOffset, length)
+			})
+			if err != nil {
+				r.logger.Error(err)
+				r.logger.Info(fmt.Sprintf("reading file '%v' by segments finished with errors", file.Name()))
+				return
+			}
+
+			// read ahead the next segments when the stream reached the already prefetched ones
+			if r.prefetch > 0 && segment >= prefetched {
+				prefetched = segment + r.prefetch
+				go r.prefetchSegments(ctx, resource, stat.Size(), segment+1, prefetched)
+			}
+
+			select {
+			case ch <- &model.Chunk{Data: data[skip:]}:
+			case <-ctx.Done():
+				r.logger.Info(fmt.Sprintf("reading file '%v' by segments interrupted", file.Name()))
+				return
 			}
+			skip = 0
 		}
+
+		r.logger.Info(fmt.Sprintf("reading file '%v' by segments finished properly", file.Name()))
 	}()
 	return ch
 }
+
+// SegmentCacheStats - returns the counters of the segment cache (zero values when the cache is disabled).
+func (r *FileReaderService) SegmentCacheStats() model.SegmentCacheStats {
+	if r.segments == nil {
+		return model.SegmentCacheStats{}
+	}
+	return r.segments.Stats()
+}
+
+// prefetchSegments will read the segments from the range [from, to] into the cache by a separate file handler
+// until the context of the stream is done.
+func (r *FileReaderService) prefetchSegments(ctx context.Context, resource entity.Resource, size int64, from int64, to int64) {
+	file, err := os.Open(resource.GetFilepath())
+	if err != nil {
+		r.logger.Error(err)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	for segment := from; segment <= to && segment*int64(r.chunkSize) < size; segment++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		segmentOffset, length := r.segmentBounds(segment, size)
+		if err = r.segments.warm(resource.GetID(), segmentOffset, length, func() ([]byte, error) {
+			return r.readSegment(file, segmentOffset, length)
+		}); err != nil {
+			r.logger.Error(err)
+			return
+		}
+	}
+}
+
+// segmentBounds will return the offset and the length of the segment (the last one may be shorter).
+func (r *FileReaderService) segmentBounds(segment int64, size int64) (offset int64, length int64) {
+	offset = segment * int64(r.chunkSize)
+	length = int64(r.chunkSize)
+	if length > size-offset {
+		length = size - offset
+	}
+	return offset, length
+}
+
+func (r *FileReaderService) readSegment(file *os.File, offset int64, length int64) ([]byte, error) {
+	data := make([]byte, length)
+	if _, err := file.ReadAt(data, offset); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -1,6 +1,8 @@
 package readerinterface
 
 import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
 	"os"
 )
@@ -10,4 +12,9 @@ type FileReader interface {
 	ReadAll(file *os.File) *model.Chunk
 	// ReadByChunks - reads a file by separated chunks and passed it into the channel.
 	ReadByChunks(file *os.File, offset int64) chan *model.Chunk
+	// ReadResourceByChunks - reads a resource file by chunks, the chunks of hot resources are served from memory.
+	// The reading is stopped when the given context of the stream is done.
+	ReadResourceByChunks(ctx context.Context, resource entity.Resource, file *os.File, offset int64) chan *model.Chunk
+	// SegmentCacheStats - returns the counters of the in-memory segment cache.
+	SegmentCacheStats() model.SegmentCacheStats
 }
@@ -0,0 +1,55 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	"os"
+)
+
+// readMappedByChunks - copies the mapped file by chunks into the pooled buffers and passes them into the channel.
+// The file is unmapped when the reading is finished, the chunks are not related to the mapping.
+func (r *FileReaderService) readMappedByChunks(ctx context.Context, file *os.File, data []byte, offset int64) chan *model.Chunk {
+	ch := make(chan *model.Chunk, chunksChBuffer)
+	go func() {
+		defer close(ch)
+		defer func() {
+			if err := munmap(data); err != nil {
+				r.logger.Error(err)
+			}
+		}()
+
+		for offset < int64(len(data)) {
+			select {
+			case <-ctx.Done():
+				r.logger.Info(fmt.Sprintf("reading mapped file '%v' by chunks interrupted", file.Name()))
+				return
+			default:
+			}
+
+			// the mapping is still readable when the file is closed, thus the stream interruption is checked by stat
+			if _, err := file.Stat(); err != nil {
+				r.logger.Info(fmt.Sprintf("reading mapped file '%v' by chunks interrupted: %v", file.Name(), err))
+				return
+			}
+
+			end := min(offset+int64(r.chunkSize), int64(len(data)))
+
+			buf := r.buffers.Get().(*[]byte)
+			n := copy(*buf, data[offset:end])
+			offset += int64(n)
+
+			chunk := model.NewPooledChunk((*buf)[:n], func() { r.buffers.Put(buf) })
+			select {
+			case ch <- chunk:
+			case <-ctx.Done():
+				chunk.Release()
+				r.logger.Info(fmt.Sprintf("reading mapped file '%v' by chunks interrupted", file.Name()))
+				return
+			}
+		}
+
+		r.logger.Info(fmt.Sprintf("reading mapped file '%v' by chunks finished properly", file.Name()))
+	}()
+	return ch
+}
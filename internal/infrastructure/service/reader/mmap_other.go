@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !freebsd
+
+package reader
+
+import (
+	"errors"
+	"os"
+)
+
+var errMmapUnsupported = errors.New("mmap is not supported on this platform")
+
+// mmap is not supported, thus the files are read by chunks.
+func mmap(file *os.File, size int64) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+// munmap is not supported.
+func munmap(data []byte) error {
+	return errMmapUnsupported
+}
@@ -0,0 +1,18 @@
+//go:build linux || darwin || freebsd
+
+package reader
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmap will map the file into memory for reading.
+func mmap(file *os.File, size int64) ([]byte, error) {
+	return syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// munmap will unmap the file mapped by mmap.
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -3,14 +3,20 @@ package model
 import "io"
 
 type Chunk struct {
-	Data []byte
-	Err  error
+	Data    []byte
+	Err     error
+	release func()
 }
 
 func NewChunk(length int64, capacity int64) *Chunk {
 	return &Chunk{Data: make([]byte, length, capacity)}
 }
 
+// NewPooledChunk is a constructor of the chunk whose buffer is returned to the pool by the release function.
+func NewPooledChunk(data []byte, release func()) *Chunk {
+	return &Chunk{Data: data, release: release}
+}
+
 func (c *Chunk) Read(p []byte) (n int, err error) {
 	if c.Data == nil || len(c.Data) == 0 {
 		return 0, io.EOF
@@ -34,3 +40,12 @@ func (c *Chunk) GetError() error {
 func (c *Chunk) SetError(err error) {
 	c.Err = err
 }
+
+// Release will return the buffer of the chunk into the pool (if it's pooled), the chunk must not be used after.
+func (c *Chunk) Release() {
+	if c.release != nil {
+		c.release()
+		c.release = nil
+	}
+	c.Data = nil
+}
@@ -0,0 +1,19 @@
+package model
+
+// SegmentCacheStats is a snapshot of the counters of the segment cache.
+type SegmentCacheStats struct {
+	// Hits is a number of segments which were streamed from memory.
+	Hits uint64 `json:"hits"`
+	// Misses is a number of segments of the hot resources which were read from disk.
+	Misses uint64 `json:"misses"`
+	// HitRatio is a part of hits of all segments of the hot resources.
+	HitRatio float64 `json:"hitRatio"`
+	// ServedBytes is a number of bytes which were streamed from memory.
+	ServedBytes uint64 `json:"servedBytes"`
+	// Segments is a number of cached segments.
+	Segments int `json:"segments"`
+	// Bytes is an estimated size of cached segments.
+	Bytes int64 `json:"bytes"`
+	// HotResources is a number of resources which are admitted into the cache now.
+	HotResources int `json:"hotResources"`
+}
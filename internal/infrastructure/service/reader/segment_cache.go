@@ -0,0 +1,153 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/service/cacher/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/cacher"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SegmentCache keeps the segments of the hot resources in memory, thus the popular videos are streamed to all
+// connections without reading the disk. A resource becomes hot when it's streamed the threshold number of times
+// within the admission window, it stays hot during the window after the last time the threshold was reached.
+// The segments are evicted by LRU when the size of the cache exceeds the limit.
+type SegmentCache struct {
+	storage     *cacher.MapCacheStorage
+	threshold   int
+	window      time.Duration
+	mu          sync.Mutex
+	resources   map[vo.ID]*resourceStreams
+	sweptAt     time.Time
+	hits        uint64
+	misses      uint64
+	servedBytes uint64
+}
+
+type resourceStreams struct {
+	count       int // number of streams within the current window
+	windowStart time.Time
+	hotUntil    time.Time
+}
+
+// NewSegmentCache is a constructor of SegmentCache structure.
+func NewSegmentCache(ctx context.Context, maxBytes int64, threshold int, window time.Duration) *SegmentCache {
+	return &SegmentCache{
+		storage:   cacher.NewMapCacheStorage(ctx, cacher.NewLRUPolicy(), 0, maxBytes),
+		threshold: threshold,
+		window:    window,
+		resources: map[vo.ID]*resourceStreams{},
+		sweptAt:   time.Now(),
+	}
+}
+
+// admit will register the stream of the resource and check whether the resource is hot.
+func (c *SegmentCache) admit(resourceID vo.ID) bool {
+	defer c.mu.Unlock()
+	c.mu.Lock()
+
+	now := time.Now()
+	c.sweep(now)
+
+	r, found := c.resources[resourceID]
+	if !found {
+		r = &resourceStreams{windowStart: now}
+		c.resources[resourceID] = r
+	}
+	if now.Sub(r.windowStart) > c.window {
+		r.count = 0
+		r.windowStart = now
+	}
+
+	r.count++
+	if r.count >= c.threshold {
+		r.hotUntil = now.Add(c.window)
+	}
+
+	return now.Before(r.hotUntil)
+}
+
+// sweep will forget the resources which were not streamed during the window. Must be called under the lock.
+func (c *SegmentCache) sweep(now time.Time) {
+	if now.Sub(c.sweptAt) < c.window {
+		return
+	}
+	for id, r := range c.resources {
+		if now.Sub(r.windowStart) > c.window && now.After(r.hotUntil) {
+			delete(c.resources, id)
+		}
+	}
+	c.sweptAt = now
+}
+
+// get will return the segment from memory, or it will read the segment and cache it. The returned data is shared
+// by all streams and must not be modified.
+func (c *SegmentCache) get(resourceID vo.ID, offset int64, length int64, read func() ([]byte, error)) ([]byte, error) {
+	loaded := false
+	data, err := c.load(resourceID, offset, length, func() ([]byte, error) {
+		loaded = true
+		return read()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if loaded {
+		atomic.AddUint64(&c.misses, 1)
+	} else {
+		atomic.AddUint64(&c.hits, 1)
+		atomic.AddUint64(&c.servedBytes, uint64(len(data)))
+	}
+
+	return data, nil
+}
+
+// warm will read the segment into the cache if it's not cached yet (the counters are not changed).
+func (c *SegmentCache) warm(resourceID vo.ID, offset int64, length int64, read func() ([]byte, error)) error {
+	_, err := c.load(resourceID, offset, length, read)
+	return err
+}
+
+func (c *SegmentCache) load(resourceID vo.ID, offset int64, length int64, read func() ([]byte, error)) ([]byte, error) {
+	key := fmt.Sprintf("%v:%d:%d", resourceID.Value.Hex(), offset, length)
+
+	data, err := c.storage.Get(key, func(item cacherinterface.CacheItem) (data interface{}, err error) {
+		return read()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data.([]byte), nil
+}
+
+// Stats will return the snapshot of the counters of the cache.
+func (c *SegmentCache) Stats() model.SegmentCacheStats {
+	storageStats := c.storage.Stats()
+
+	stats := model.SegmentCacheStats{
+		Hits:        atomic.LoadUint64(&c.hits),
+		Misses:      atomic.LoadUint64(&c.misses),
+		ServedBytes: atomic.LoadUint64(&c.servedBytes),
+		Segments:    storageStats.Entries(),
+		Bytes:       storageStats.Bytes(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	for _, r := range c.resources {
+		if now.Before(r.hotUntil) {
+			stats.HotResources++
+		}
+	}
+	c.mu.Unlock()
+
+	return stats
+}
@@ -0,0 +1,249 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Error is an error reply of the server (the connection is still usable after it).
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Client is a minimal client of the Redis protocol (RESP2) with a pool of connections.
+// The replies are returned as: string (simple string), []byte (bulk string), nil (null), int64 (integer),
+// []interface{} (array) or Error.
+type Client struct {
+	addr     string
+	password string
+	db       int
+	timeout  time.Duration
+	sem      chan struct{} // limits the number of opened connections
+	idle     chan *conn
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// NewClient is a constructor of Client structure. The timeout limits the dialing and each command.
+func NewClient(addr string, password string, db int, poolSize int, timeout time.Duration) *Client {
+	return &Client{
+		addr:     addr,
+		password: password,
+		db:       db,
+		timeout:  timeout,
+		sem:      make(chan struct{}, poolSize),
+		idle:     make(chan *conn, poolSize),
+	}
+}
+
+// Do will send the command and return the reply.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-c.sem }()
+
+	var cn *conn
+	select {
+	case cn = <-c.idle:
+	default:
+		var err error
+		if cn, err = c.dial(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := cn.do(c.deadline(ctx), args...)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// the state of the connection is unknown after the network or protocol error
+		_ = cn.Close()
+		return nil, err
+	}
+
+	select {
+	case c.idle <- cn:
+	default:
+		_ = cn.Close()
+	}
+
+	return reply, err
+}
+
+// Subscribe will subscribe to the channel by the dedicated connection and call the handler for each message.
+// It blocks until the context is done or the connection is broken (the error is returned then).
+func (c *Client) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	cn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = cn.Close() }()
+
+	if _, err = cn.do(c.deadline(ctx), "SUBSCRIBE", channel); err != nil {
+		return err
+	}
+
+	// the blocking read is interrupted by closing of the connection
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = cn.Close()
+		case <-done:
+		}
+	}()
+
+	if err = cn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	for {
+		reply, err := cn.read()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		message, ok := reply.([]interface{})
+		if !ok || len(message) != 3 {
+			continue
+		}
+		if kind, _ := message[0].([]byte); string(kind) != "message" {
+			continue
+		}
+		if payload, ok := message[2].([]byte); ok {
+			handler(payload)
+		}
+	}
+}
+
+func (c *Client) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		return d
+	}
+	return deadline
+}
+
+// dial will open the connection, authenticate and select the database.
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cn := &conn{
+		Conn: netConn,
+		r:    bufio.NewReader(netConn),
+		w:    bufio.NewWriter(netConn),
+	}
+
+	if c.password != "" {
+		if _, err = cn.do(c.deadline(ctx), "AUTH", c.password); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if _, err = cn.do(c.deadline(ctx), "SELECT", strconv.Itoa(c.db)); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+
+	return cn, nil
+}
+
+func (cn *conn) do(deadline time.Time, args ...string) (interface{}, error) {
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if err := cn.write(args); err != nil {
+		return nil, err
+	}
+
+	reply, err := cn.read()
+	if err != nil {
+		return nil, err
+	}
+	if replyErr, ok := reply.(Error); ok {
+		return nil, replyErr
+	}
+	return reply, nil
+}
+
+// write will send the command as an array of bulk strings.
+func (cn *conn) write(args []string) error {
+	_, _ = fmt.Fprintf(cn.w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		_, _ = fmt.Fprintf(cn.w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return cn.w.Flush()
+}
+
+func (cn *conn) read() (interface{}, error) {
+	line, err := cn.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply line %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return Error(payload), nil
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		b := make([]byte, n+2)
+		if _, err = io.ReadFull(cn.r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		array := make([]interface{}, n)
+		for i := range array {
+			if array[i], err = cn.read(); err != nil {
+				return nil, err
+			}
+		}
+		return array, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,114 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/enum"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// totpSecretLength is a number of random bytes of the secret (160 bits are recommended by RFC 4226).
+const totpSecretLength = 20
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Totp is an implementation of time-based one-time passwords (RFC 6238) with HMAC-SHA1,
+// which is the only algorithm supported by the most of authenticator apps.
+type Totp struct {
+	logger loggerinterface.Logger
+	issuer string
+	skew   int64
+}
+
+func NewTotp(serviceContainer diinterface.ServiceContainer) (*Totp, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &Totp{
+		logger: loggerService,
+		issuer: cfg.TotpIssuer,
+		skew:   cfg.TotpAllowedSkewSteps,
+	}, nil
+}
+
+// GenerateSecret will generate a new random base32 encoded secret.
+func (s *Totp) GenerateSecret() (secret string, err error) {
+	b := make([]byte, totpSecretLength)
+	if _, err = rand.Read(b); err != nil {
+		return "", s.logger.LogPropagate(err)
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI will build the otpauth:// URI which is understood by authenticator apps.
+func (s *Totp) ProvisioningURI(secret string, account string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", s.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(enum.TotpDigits))
+	query.Set("period", strconv.Itoa(enum.TotpPeriod))
+
+	// spaces must be encoded as '%20' (not '+'), otherwise some apps will show them as is
+	return fmt.Sprintf(
+		"otpauth://totp/%v:%v?%v",
+		url.PathEscape(s.issuer), url.PathEscape(account), strings.ReplaceAll(query.Encode(), "+", "%20"),
+	)
+}
+
+// Validate will check the code against the steps around given time (the clock skew is configured)
+// and return the matched step, it must be stored for prevent replaying of the same code.
+func (s *Totp) Validate(secret string, code string, at time.Time) (step int64, valid bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		s.logger.Log(err)
+		return 0, false
+	}
+
+	current := at.Unix() / enum.TotpPeriod
+	for step = current - s.skew; step <= current+s.skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(s.generate(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// generate will compute the HOTP value (RFC 4226) for given counter.
+func (s *Totp) generate(key []byte, counter int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < enum.TotpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", enum.TotpDigits, value%mod)
+}
@@ -3,6 +3,24 @@ package enum
 const (
 	StreamByID           Actions = "ID"
 	StreamByIDWithOffset Actions = "ID_WITH_OFFSET"
+	BufferStatus         Actions = "BUFFER_STATUS"
+	ResumeSession        Actions = "RESUME_SESSION"
+	Playlist             Actions = "PLAYLIST"
+	// Next, Prev, Shuffle and Repeat are controls of the current playlist.
+	Next    Actions = "NEXT"
+	Prev    Actions = "PREV"
+	Shuffle Actions = "SHUFFLE"
+	Repeat  Actions = "REPEAT"
+	// Publish starts the live broadcast, the media data is sent by binary messages after it.
+	Publish   Actions = "PUBLISH"
+	Unpublish Actions = "UNPUBLISH"
+	WatchLive Actions = "WATCH_LIVE"
+	// PartyCreate, PartyJoin, PartyLeave, PartyState and PartyTransfer are actions of the watch party.
+	PartyCreate   Actions = "PARTY_CREATE"
+	PartyJoin     Actions = "PARTY_JOIN"
+	PartyLeave    Actions = "PARTY_LEAVE"
+	PartyState    Actions = "PARTY_STATE"
+	PartyTransfer Actions = "PARTY_TRANSFER"
 )
 
 type Actions string
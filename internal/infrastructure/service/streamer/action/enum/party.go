@@ -0,0 +1,19 @@
+package enum
+
+// PartyEvent is a type of the watch party message which is sent to the members.
+type PartyEvent string
+
+const (
+	// PartyCreatedEvent is sent to the host of the created party (with the invite token).
+	PartyCreatedEvent PartyEvent = "created"
+	// PartyJoinedEvent is sent to the joined member (with the current playback state).
+	PartyJoinedEvent PartyEvent = "joined"
+	// PartyMembersEvent is sent to all members when somebody joins or leaves.
+	PartyMembersEvent PartyEvent = "members"
+	// PartyStateEvent is sent to the members when the host changes the playback state.
+	PartyStateEvent PartyEvent = "state"
+	// PartySyncEvent is sent to the member whose playhead drifts from the host one.
+	PartySyncEvent PartyEvent = "sync"
+	// PartyHostEvent is sent to all members when the host is changed.
+	PartyHostEvent PartyEvent = "host"
+)
@@ -0,0 +1,17 @@
+package enum
+
+// RepeatMode tells what is played when the current item of the playlist is finished.
+type RepeatMode string
+
+const (
+	// RepeatNone means the playlist is stopped after the last item.
+	RepeatNone RepeatMode = ""
+	// RepeatAll means the playlist is started again after the last item.
+	RepeatAll RepeatMode = "all"
+	// RepeatOne means the current item is played again.
+	RepeatOne RepeatMode = "one"
+)
+
+func (m RepeatMode) IsValid() bool {
+	return m == RepeatNone || m == RepeatAll || m == RepeatOne
+}
@@ -52,6 +52,9 @@ func (h *WebSocketActionsHandler) Handle(wg *sync.WaitGroup, actionsCh <-chan mo
 					}
 				}
 			}
+			if action.Done != nil {
+				action.Done()
+			}
 		}
 	}()
 }
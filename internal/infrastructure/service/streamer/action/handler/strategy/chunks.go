@@ -0,0 +1,72 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"github.com/gorilla/websocket"
+	"os"
+)
+
+// send will write the chunks to the client by the pace of the flow. Returns true when the flow is interrupted
+// (the stream is stopped or skipped), or the error of writing. The reading is discarded in both cases.
+func send(
+	logger loggerinterface.Logger,
+	communicator protointerface.Communicator,
+	flow shaperinterface.Flow,
+	conn *websocket.Conn,
+	resource entity.Resource,
+	stopReading context.CancelFunc,
+	file *os.File,
+	chunks <-chan *model.Chunk,
+) (interrupted bool, err error) {
+	for chunk := range chunks {
+		n := chunk.GetLen()
+		// await while the chunk may be sent (the lead buffer is filled, or the player is paused)
+		if err = flow.Wait(n); err != nil {
+			chunk.Release()
+			logger.Info(fmt.Sprintf("[%v]: streaming of '%v' is interrupted", conn.RemoteAddr(), resource.GetName()))
+			discard(stopReading, file, chunks)
+			return true, nil
+		}
+
+		err = communicator.Send(chunk, conn)
+		// the chunk is already written, thus its buffer may be reused
+		chunk.Release()
+		if err != nil {
+			discard(stopReading, file, chunks)
+			return false, err
+		}
+
+		logger.Info(
+			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
+				conn.RemoteAddr(), n, resource.GetName(),
+			),
+		)
+	}
+
+	return false, nil
+}
+
+// discard will stop the reading of the file when the stream is interrupted. The reading is stopped by its context
+// (the hot segments are served from memory without touching the file) and the file is closed, the remaining chunks
+// are drained and released, thus the reader is not blocked on sending forever.
+func discard(stopReading context.CancelFunc, file *os.File, chunks <-chan *model.Chunk) {
+	stopReading()
+	_ = file.Close()
+	for chunk := range chunks {
+		chunk.Release()
+	}
+}
+
+// duration will compute the approximate duration of the resource in seconds by its average bitrate.
+func duration(resource entity.Resource, bytesPerSecond float64) float64 {
+	if bytesPerSecond <= 0 {
+		return 0
+	}
+	return float64(resource.GetFilesize()) / bytesPerSecond
+}
@@ -0,0 +1,304 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	queueinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+)
+
+type PlaylistActionStrategy struct {
+	logger             loggerinterface.Logger
+	videoRepository    repositoryinterface.Video
+	playlistRepository repositoryinterface.Playlist
+	reader             readerinterface.FileReader
+	codecInfo          detectorinterface.Codecs
+	communicator       protointerface.Communicator
+	shaper             shaperinterface.Shaper
+	registry           registryinterface.Registry
+	sessions           sessioninterface.Sessions
+	queues             queueinterface.Queues
+}
+
+func NewPlaylistActionStrategy(serviceContainer diinterface.ServiceContainer) (*PlaylistActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playlistRepository, err := serviceContainer.GetPlaylistRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileReader, err := serviceContainer.GetFileReaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	streamShaper, err := serviceContainer.GetStreamShaperService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	connectionRegistry, err := serviceContainer.GetConnectionRegistryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playbackSessions, err := serviceContainer.GetPlaybackSessionsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playbackQueues, err := serviceContainer.GetPlaybackQueuesService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PlaylistActionStrategy{
+		logger:             loggerService,
+		videoRepository:    videoRepository,
+		playlistRepository: playlistRepository,
+		reader:             fileReader,
+		codecInfo:          codecsDetector,
+		communicator:       webSocketCommunicator,
+		shaper:             streamShaper,
+		registry:           connectionRegistry,
+		sessions:           playbackSessions,
+		queues:             playbackQueues,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *PlaylistActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.Playlist
+}
+
+// Do - will be streaming the items of a target playlist back to back.
+func (s *PlaylistActionStrategy) Do(action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.PlaylistData)
+	if !ok {
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'playlist' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+	if !data.Repeat.IsValid() {
+		return s.logger.LogPropagate(fmt.Errorf("repeat mode '%v' is not supported", data.Repeat))
+	}
+
+	// parse the given playlist identifier
+	oid, err := primitive.ObjectIDFromHex(data.ID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// find the target playlist
+	playlist, err := s.playlistRepository.FindOneByID(action.Ctx, dto.NewPlaylistGetRequestDTO(vo.NewID(oid), action.UserID))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+		return s.logger.LogPropagate(err)
+	}
+	s.logger.Info(fmt.Sprintf("[%v]: streaming 'playlist':'%v'", action.Conn.RemoteAddr(), playlist.Name))
+
+	// the queue receives the controls of the client (next, prev, shuffle, repeat) from the listener
+	q := s.queues.Start(action.Conn, playlist.VideoIDs, data.Index, data.Shuffle, data.Repeat)
+	defer q.Close()
+
+	// the count of items in a row which cannot be played, when it reaches the length of the playlist,
+	// the whole cycle of the queue is unplayable and the repeating of it would never end
+	unplayable := 0
+
+	for {
+		index, videoID, itemCtx, hasNext := q.Next(action.Ctx)
+		if !hasNext {
+			s.logger.Info(fmt.Sprintf("[%v]: 'playlist':'%v' is over", action.Conn.RemoteAddr(), playlist.Name))
+			return nil
+		}
+
+		result := itemUnplayable
+
+		// find the target resource (the removed videos are skipped)
+		v, err := s.videoRepository.FindOneByID(action.Ctx, dto.NewVideoGetRequestDTO(videoID, "", vo.ID{}, action.UserID))
+		if err != nil && !errtype.IsEntityNotFoundError(err) {
+			return s.logger.LogPropagate(err)
+		}
+		if err == nil {
+			if result, err = s.stream(action.Ctx, itemCtx, action.UserID, index, v, action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+
+		switch result {
+		case itemUnplayable:
+			if unplayable++; unplayable >= len(playlist.VideoIDs) {
+				err = errtype.NewPlaylistHasNoPlayableItemsError(playlist.Name)
+				if cerr := s.communicator.Error(err, action.Conn); cerr != nil {
+					return s.logger.LogPropagate(cerr)
+				}
+				return s.logger.LogPropagate(err)
+			}
+			// the unplayable item must not be repeated, thus the queue moves forward even in the 'one' repeat mode
+			q.Skip(false)
+		case itemAborted:
+			return nil
+		default:
+			unplayable = 0
+		}
+	}
+}
+
+// itemResult is an outcome of the playlist item streaming.
+type itemResult int
+
+const (
+	// itemCompleted - the item was streamed till the end.
+	itemCompleted itemResult = iota
+	// itemSkipped - the item was interrupted by the user, the next one must be played.
+	itemSkipped
+	// itemUnplayable - the item cannot be played (its file or codecs are broken), nothing was sent to the client.
+	itemUnplayable
+	// itemAborted - the client is gone or the server is stopping.
+	itemAborted
+)
+
+// stream - will stream the item of the playlist from the beginning. The playback session is started
+// and the client is notified only when the item is known to be playable.
+func (s *PlaylistActionStrategy) stream(
+	ctx context.Context,
+	itemCtx context.Context,
+	userID vo.ID,
+	index int,
+	v *agg.Video,
+	conn *websocket.Conn,
+) (itemResult, error) {
+	resource := v.Resource
+
+	// detect the audio and video codecs
+	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return itemUnplayable, nil
+	}
+
+	// detect the average bitrate for shape the stream by the playback speed
+	bitrate, err := s.codecInfo.DetectBitrate(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return itemUnplayable, nil
+	}
+
+	// the item may be skipped or the action may be replaced by the next one while the codecs were detected
+	if itemCtx.Err() != nil {
+		return itemSkipped, nil
+	}
+
+	// open the target resource file
+	file, err := os.Open(resource.GetFilepath())
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
+		return itemUnplayable, nil
+	}
+	defer func() { _ = file.Close() }()
+
+	// issue the playback session, thus the item can be resumed after reconnect as a single video
+	session, err := s.sessions.Start(userID, userID, v.ID)
+	if err != nil {
+		return itemAborted, err
+	}
+
+	// tell the client which item is played, thus the player is able to show it
+	if err = s.communicator.Item(index, v.ID.Value.Hex(), conn); err != nil {
+		return itemAborted, err
+	}
+
+	// send the initializing message to client side
+	if err = s.communicator.Start(audioCodec, videoCodec, session.ID.Value.Hex(), 0, conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return itemAborted, nil
+	}
+
+	// the flow is bound to the item, thus the skip of the user interrupts it
+	flow := s.shaper.Shape(itemCtx, conn, bitrate, 0)
+	defer flow.Close()
+
+	// the stream is tracked for the graceful drain on shutdown
+	finish := s.registry.Stream(conn, session.VideoID, flow)
+	defer finish()
+
+	// the position of the stream is saved into the session
+	stopTracking := s.sessions.Track(session, flow, bitrate, duration(resource, bitrate))
+	defer stopTracking()
+
+	// the reading is stopped by the stream interruption (the hot segments are served without touching the file)
+	readCtx, stopReading := context.WithCancel(itemCtx)
+	defer stopReading()
+
+	// read the target file by chunks
+	chunks := s.reader.ReadResourceByChunks(readCtx, resource, file, 0)
+	interrupted, err := send(s.logger, s.communicator, flow, conn, resource, stopReading, file, chunks)
+	if err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return itemAborted, nil
+	}
+
+	// the flow is stopped while the item was not skipped: the client is gone or the stream is drained
+	// on shutdown, thus nothing must be sent anymore and the rest of the playlist is not played
+	if interrupted && itemCtx.Err() == nil {
+		return itemAborted, nil
+	}
+
+	// stop the streaming by sending appropriate message to client side
+	if err = s.communicator.Stop(conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return itemAborted, nil
+	}
+
+	// the item which is interrupted by the user is skipped, otherwise the action is replaced by the next one
+	// or the server is stopping
+	if interrupted {
+		if ctx.Err() != nil {
+			return itemAborted, nil
+		}
+		return itemSkipped, nil
+	}
+
+	return itemCompleted, nil
+}
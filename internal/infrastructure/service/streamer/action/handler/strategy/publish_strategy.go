@@ -0,0 +1,94 @@
+package strategy
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+)
+
+type PublishActionStrategy struct {
+	logger       loggerinterface.Logger
+	communicator protointerface.Communicator
+	broadcasts   liveinterface.Broadcasts
+}
+
+func NewPublishActionStrategy(serviceContainer diinterface.ServiceContainer) (*PublishActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	liveBroadcasts, err := serviceContainer.GetLiveBroadcastsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &PublishActionStrategy{
+		logger:       loggerService,
+		communicator: webSocketCommunicator,
+		broadcasts:   liveBroadcasts,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *PublishActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.Publish
+}
+
+// Do - will be holding the live broadcast of the publisher until it's stopped. The media data itself is passed
+// to the broadcast by the listener, because the handler is busy by this action.
+func (s *PublishActionStrategy) Do(action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.PublishData)
+	if !ok {
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'publish' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	b, err := s.broadcasts.Publish(action.Conn, action.UserID, data.Name, data.MimeType, data.Record)
+	if err != nil {
+		if e := s.communicator.Error(err, action.Conn); e != nil {
+			return s.logger.LogPropagate(e)
+		}
+		return s.logger.LogPropagate(err)
+	}
+	s.logger.Info(fmt.Sprintf("[%v]: publishing 'broadcast':'%v'", action.Conn.RemoteAddr(), b.GetID().Value.Hex()))
+
+	// the publisher starts sending the media data after the acknowledgement
+	if err = s.communicator.Live(b.GetID().Value.Hex(), b.GetMimeType(), action.Conn); err != nil {
+		s.broadcasts.Unpublish(action.Conn)
+	}
+
+	// await the end of the broadcast (it's stopped by the publisher, or the publisher is gone)
+	select {
+	case <-b.Done():
+	case <-action.Ctx.Done():
+		s.broadcasts.Unpublish(action.Conn)
+	}
+	s.logger.Info(fmt.Sprintf("[%v]: 'broadcast':'%v' is stopped", action.Conn.RemoteAddr(), b.GetID().Value.Hex()))
+
+	if !b.IsRecorded() {
+		return nil
+	}
+
+	// the recording becomes a regular video of the publisher
+	video, err := s.broadcasts.Record(b)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	s.logger.Info(fmt.Sprintf("[%v]: 'broadcast':'%v' is recorded as 'video':'%v'",
+		action.Conn.RemoteAddr(), b.GetID().Value.Hex(), video.ID.Value.Hex(),
+	))
+
+	return nil
+}
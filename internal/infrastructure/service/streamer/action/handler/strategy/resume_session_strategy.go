@@ -0,0 +1,218 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/dto"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
+	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"github.com/gorilla/websocket"
+	"os"
+)
+
+type ResumeSessionActionStrategy struct {
+	logger          loggerinterface.Logger
+	videoRepository repositoryinterface.Video
+	reader          readerinterface.FileReader
+	codecInfo       detectorinterface.Codecs
+	communicator    protointerface.Communicator
+	shaper          shaperinterface.Shaper
+	registry        registryinterface.Registry
+	sessions        sessioninterface.Sessions
+	chunkSize       int64
+}
+
+func NewResumeSessionActionStrategy(serviceContainer diinterface.ServiceContainer) (*ResumeSessionActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileReader, err := serviceContainer.GetFileReaderService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	codecsDetector, err := serviceContainer.GetCodecsDetectorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	streamShaper, err := serviceContainer.GetStreamShaperService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	connectionRegistry, err := serviceContainer.GetConnectionRegistryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playbackSessions, err := serviceContainer.GetPlaybackSessionsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ResumeSessionActionStrategy{
+		logger:          loggerService,
+		videoRepository: videoRepository,
+		reader:          fileReader,
+		codecInfo:       codecsDetector,
+		communicator:    webSocketCommunicator,
+		shaper:          streamShaper,
+		registry:        connectionRegistry,
+		sessions:        playbackSessions,
+		chunkSize:       int64(cfg.StreamingChunkSize),
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *ResumeSessionActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.ResumeSession
+}
+
+// Do - will be streaming a target resource of the session from the last saved position.
+func (s *ResumeSessionActionStrategy) Do(action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.ResumeSessionData)
+	if !ok {
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'resume session' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	// find the session of the user (the session of another user is not found as well)
+	session, err := s.sessions.Find(action.UserID, data.SessionID)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+		return s.logger.LogPropagate(err)
+	}
+
+	// find the target resource (access may be revoked since the session was started), the video of the watch party
+	// host is found by its owner
+	q := dto.NewVideoGetRequestDTO(session.VideoID, "", vo.ID{}, session.GetOwnerID())
+	v, err := s.videoRepository.FindOneByID(action.Ctx, q)
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+		return s.logger.LogPropagate(err)
+	}
+	s.logger.Info(
+		fmt.Sprintf("[%v]: resuming 'resource':'%v' from %.3fs",
+			action.Conn.RemoteAddr(), v.Resource.Name, session.Position,
+		),
+	)
+
+	// video resource streaming
+	s.stream(action.Ctx, session, v.Resource, action.Conn)
+
+	return nil
+}
+
+func (s *ResumeSessionActionStrategy) stream(
+	ctx context.Context,
+	session *agg.PlaybackSession,
+	resource entity.Resource,
+	conn *websocket.Conn,
+) {
+	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	bitrate, err := s.codecInfo.DetectBitrate(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	// the action may be replaced by the next one while the codecs were detected
+	if ctx.Err() != nil {
+		return
+	}
+
+	// the stream is continued from the beginning of the chunk which contains the saved position
+	offset := session.Offset / s.chunkSize * s.chunkSize
+	from := session.Position
+	if bitrate > 0 {
+		from = float64(offset) / bitrate
+	}
+
+	// the same session is continued
+	if err = s.communicator.Start(audioCodec, videoCodec, session.ID.Value.Hex(), from, conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	file, err := os.Open(resource.GetFilepath())
+	if err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	flow := s.shaper.Shape(ctx, conn, bitrate, from)
+	defer flow.Close()
+
+	// the stream is tracked for the graceful drain on shutdown
+	finish := s.registry.Stream(conn, session.VideoID, flow)
+	defer finish()
+
+	// the position of the stream is saved into the session
+	stopTracking := s.sessions.Track(session, flow, bitrate, duration(resource, bitrate))
+	defer stopTracking()
+
+	// the reading is stopped by the stream interruption (the hot segments are served without touching the file)
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+
+	chunks := s.reader.ReadResourceByChunks(readCtx, resource, file, offset)
+	interrupted, err := send(s.logger, s.communicator, flow, conn, resource, stopReading, file, chunks)
+	if interrupted {
+		return
+	}
+	if err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	if err = s.communicator.Stop(conn); err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+}
@@ -3,34 +3,39 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
-	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	partyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
 	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"os"
 )
 
-const zeroOffset = 0
-
 type StreamByIDActionStrategy struct {
-	ctx             context.Context
 	logger          loggerinterface.Logger
 	videoRepository repositoryinterface.Video
 	reader          readerinterface.FileReader
 	codecInfo       detectorinterface.Codecs
 	communicator    protointerface.Communicator
-	tokenizer       tokenizerinterface.Tokenizer
+	shaper          shaperinterface.Shaper
+	registry        registryinterface.Registry
+	sessions        sessioninterface.Sessions
+	parties         partyinterface.Parties
+	chunkSize       int64
 }
 
 func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer) (*StreamByIDActionStrategy, error) {
@@ -39,11 +44,6 @@ func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer)
 		return nil, err
 	}
 
-	ctx, err := serviceContainer.GetCtx()
-	if err != nil {
-		return nil, loggerService.LogPropagate(err)
-	}
-
 	videoRepository, err := serviceContainer.GetVideoRepository()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
@@ -64,19 +64,42 @@ func NewStreamByIDActionStrategy(serviceContainer diinterface.ServiceContainer)
 		return nil, loggerService.LogPropagate(err)
 	}
 
-	tokenizerService, err := serviceContainer.GetTokenizerService()
+	streamShaper, err := serviceContainer.GetStreamShaperService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	connectionRegistry, err := serviceContainer.GetConnectionRegistryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playbackSessions, err := serviceContainer.GetPlaybackSessionsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchParties, err := serviceContainer.GetWatchPartiesService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
 	return &StreamByIDActionStrategy{
-		ctx:             ctx,
 		logger:          loggerService,
 		videoRepository: videoRepository,
 		reader:          fileReader,
 		codecInfo:       codecsDetector,
 		communicator:    webSocketCommunicator,
-		tokenizer:       tokenizerService,
+		shaper:          streamShaper,
+		registry:        connectionRegistry,
+		sessions:        playbackSessions,
+		parties:         watchParties,
+		chunkSize:       int64(cfg.StreamingChunkSize),
 	}, nil
 }
 
@@ -95,21 +118,21 @@ func (s *StreamByIDActionStrategy) Do(action model.Action) error {
 		)
 	}
 
-	// user authentication
-	userID, err := s.tokenizer.Verify(data.Token)
-	if err != nil {
-		return s.logger.LogPropagate(err)
-	}
-
 	// parse the given video resource identifier
 	oid, err := primitive.ObjectIDFromHex(data.ID)
 	if err != nil {
 		return s.logger.LogPropagate(err)
 	}
 
+	// the members of a watch party are able to stream the video of the host, which is owned by the host
+	ownerID := action.UserID
+	if hostID, isParty := s.parties.Host(action.Conn, data.ID); isParty {
+		ownerID = hostID
+	}
+
 	// find the target resource
-	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
-	v, err := s.videoRepository.FindOneByID(s.ctx, q)
+	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, ownerID)
+	v, err := s.videoRepository.FindOneByID(action.Ctx, q)
 	if err != nil {
 		if errtype.IsEntityNotFoundError(err) {
 			if err = s.communicator.Error(err, action.Conn); err != nil {
@@ -120,14 +143,40 @@ func (s *StreamByIDActionStrategy) Do(action model.Action) error {
 	}
 	s.logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
 
+	// the stream may be started from the requested position, or continued from the saved position of the unfinished video
+	var position float64
+	if data.From > 0 {
+		position = data.From
+	} else if data.Resume {
+		progress, err := s.sessions.Progress(action.UserID, v.ID)
+		if err != nil && !errtype.IsEntityNotFoundError(err) {
+			return s.logger.LogPropagate(err)
+		}
+		if err == nil && !progress.Completed {
+			position = progress.Position
+		}
+	}
+
+	// issue the playback session, thus the stream can be resumed after reconnect
+	session, err := s.sessions.Start(action.UserID, ownerID, v.ID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
 	// video resource streaming
-	s.stream(v.Resource, action.Conn)
+	s.stream(action.Ctx, session, v.Resource, position, action.Conn)
 
 	return nil
 }
 
-// stream - the method which composed all useful work of really streaming.
-func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websocket.Conn) {
+// stream - the method which composed all useful work of really streaming (from the given position in seconds).
+func (s *StreamByIDActionStrategy) stream(
+	ctx context.Context,
+	session *agg.PlaybackSession,
+	resource entity.Resource,
+	position float64,
+	conn *websocket.Conn,
+) {
 	// detect the audio and video codecs
 	audioCodec, videoCodec, err := s.codecInfo.Detect(resource)
 	if err != nil {
@@ -135,8 +184,27 @@ func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websoc
 		return
 	}
 
+	// detect the average bitrate for shape the stream by the playback speed
+	bitrate, err := s.codecInfo.DetectBitrate(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
+	// the action may be replaced by the next one while the codecs were detected
+	if ctx.Err() != nil {
+		return
+	}
+
+	// the stream is started from the beginning of the chunk which contains the position
+	offset := int64(position*bitrate) / s.chunkSize * s.chunkSize
+	var from float64
+	if bitrate > 0 {
+		from = float64(offset) / bitrate
+	}
+
 	// send the initializing message to client side
-	if err = s.communicator.Start(audioCodec, videoCodec, conn); err != nil {
+	if err = s.communicator.Start(audioCodec, videoCodec, session.ID.Value.Hex(), from, conn); err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
@@ -162,18 +230,29 @@ func (s *StreamByIDActionStrategy) stream(resource entity.Resource, conn *websoc
 	//	),
 	//)
 
-	// read the target file by chunks from zero offset
-	for chunk := range s.reader.ReadByChunks(file, zeroOffset) {
-		if err = s.communicator.Send(chunk, conn); err != nil {
-			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err))
-			break
-		}
+	flow := s.shaper.Shape(ctx, conn, bitrate, from)
+	defer flow.Close()
 
-		s.logger.Info(
-			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
-				conn.RemoteAddr(), chunk.GetLen(), resource.Name,
-			),
-		)
+	// the stream is tracked for the graceful drain on shutdown
+	finish := s.registry.Stream(conn, session.VideoID, flow)
+	defer finish()
+
+	// the position of the stream is saved into the session
+	stopTracking := s.sessions.Track(session, flow, bitrate, duration(resource, bitrate))
+	defer stopTracking()
+
+	// the reading is stopped by the stream interruption (the hot segments are served without touching the file)
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+
+	// read the target file by chunks from the offset
+	chunks := s.reader.ReadResourceByChunks(readCtx, resource, file, offset)
+	interrupted, err := send(s.logger, s.communicator, flow, conn, resource, stopReading, file, chunks)
+	if interrupted {
+		return
+	}
+	if err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
 	// stop the streaming by sending appropriate message to client side
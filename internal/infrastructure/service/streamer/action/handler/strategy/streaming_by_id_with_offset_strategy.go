@@ -3,18 +3,21 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
 	"github.com/Borislavv/video-streaming/internal/domain/dto"
 	"github.com/Borislavv/video-streaming/internal/domain/entity"
 	"github.com/Borislavv/video-streaming/internal/domain/errtype"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
-	tokenizerinterface "github.com/Borislavv/video-streaming/internal/domain/service/tokenizer/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	detectorinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/detector/interface"
 	readerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
+	sessioninterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/session/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
 	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"math"
@@ -28,7 +31,9 @@ type StreamByIDWithOffsetActionStrategy struct {
 	reader          readerinterface.FileReader
 	codecInfo       detectorinterface.Codecs
 	communicator    protointerface.Communicator
-	tokenizer       tokenizerinterface.Tokenizer
+	shaper          shaperinterface.Shaper
+	registry        registryinterface.Registry
+	sessions        sessioninterface.Sessions
 	chunkSize       int
 }
 
@@ -39,7 +44,9 @@ func NewStreamByIDWithOffsetActionStrategy(
 	reader readerinterface.FileReader,
 	codecInfo detectorinterface.Codecs,
 	communicator protointerface.Communicator,
-	tokenizer tokenizerinterface.Tokenizer,
+	shaper shaperinterface.Shaper,
+	registry registryinterface.Registry,
+	sessions sessioninterface.Sessions,
 	chunkSize int,
 ) *StreamByIDWithOffsetActionStrategy {
 	return &StreamByIDWithOffsetActionStrategy{
@@ -49,7 +56,9 @@ func NewStreamByIDWithOffsetActionStrategy(
 		reader:          reader,
 		codecInfo:       codecInfo,
 		communicator:    communicator,
-		tokenizer:       tokenizer,
+		shaper:          shaper,
+		registry:        registry,
+		sessions:        sessions,
 		chunkSize:       chunkSize,
 	}
 }
@@ -69,12 +78,6 @@ func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
 		)
 	}
 
-	// user authentication
-	userID, err := s.tokenizer.Verify(data.Token)
-	if err != nil {
-		return s.logger.LogPropagate(err)
-	}
-
 	// parse the given video resource identifier
 	oid, err := primitive.ObjectIDFromHex(data.ID)
 	if err != nil {
@@ -82,8 +85,8 @@ func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
 	}
 
 	// searching the requested video resource
-	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, userID)
-	v, err := s.videoRepository.FindOneByID(s.ctx, q)
+	q := dto.NewVideoGetRequestDTO(vo.NewID(oid), "", vo.ID{}, action.UserID)
+	v, err := s.videoRepository.FindOneByID(action.Ctx, q)
 	if err != nil {
 		if errtype.IsEntityNotFoundError(err) {
 			if err = s.communicator.Error(err, action.Conn); err != nil {
@@ -94,13 +97,21 @@ func (s *StreamByIDWithOffsetActionStrategy) Do(action model.Action) error {
 	}
 	s.logger.Info(fmt.Sprintf("[%v]: streaming 'resource':'%v'", action.Conn.RemoteAddr(), v.Resource.Name))
 
+	// issue the playback session, thus the stream can be resumed after reconnect
+	session, err := s.sessions.Start(action.UserID, action.UserID, v.ID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
 	// video resource streaming
-	s.stream(v.Resource, data, action.Conn)
+	s.stream(action.Ctx, session, v.Resource, data, action.Conn)
 
 	return nil
 }
 
 func (s *StreamByIDWithOffsetActionStrategy) stream(
+	ctx context.Context,
+	session *agg.PlaybackSession,
 	resource entity.Resource,
 	data *model.StreamByIdWithOffsetData,
 	conn *websocket.Conn,
@@ -111,11 +122,17 @@ func (s *StreamByIDWithOffsetActionStrategy) stream(
 		return
 	}
 
-	if err = s.communicator.Start(audioCodec, videoCodec, conn); err != nil {
+	if err = s.communicator.Start(audioCodec, videoCodec, session.ID.Value.Hex(), data.From, conn); err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 		return
 	}
 
+	bitrate, err := s.codecInfo.DetectBitrate(resource)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+		return
+	}
+
 	file, err := os.Open(resource.GetFilepath())
 	if err != nil {
 		s.logger.Critical(fmt.Sprintf("[%v]: error resource opening: %v", conn.RemoteAddr(), err.Error()))
@@ -137,17 +154,28 @@ func (s *StreamByIDWithOffsetActionStrategy) stream(
 
 	offset := int64((s.chunkSize * int(targetChunk)) - s.chunkSize)
 
-	for chunk := range s.reader.ReadByChunks(file, offset) {
-		if err = s.communicator.Send(chunk, conn); err != nil {
-			s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
-			break
-		}
+	flow := s.shaper.Shape(ctx, conn, bitrate, data.From)
+	defer flow.Close()
 
-		s.logger.Info(
-			fmt.Sprintf("[%v]: wrote %d bytes of '%v' to websocket",
-				conn.RemoteAddr(), chunk.GetLen(), resource.Name,
-			),
-		)
+	// the stream is tracked for the graceful drain on shutdown
+	finish := s.registry.Stream(conn, session.VideoID, flow)
+	defer finish()
+
+	// the position of the stream is saved into the session
+	stopTracking := s.sessions.Track(session, flow, bitrate, duration(resource, bitrate))
+	defer stopTracking()
+
+	// the reading is stopped by the stream interruption (the hot segments are served without touching the file)
+	readCtx, stopReading := context.WithCancel(ctx)
+	defer stopReading()
+
+	chunks := s.reader.ReadResourceByChunks(readCtx, resource, file, offset)
+	interrupted, err := send(s.logger, s.communicator, flow, conn, resource, stopReading, file, chunks)
+	if interrupted {
+		return
+	}
+	if err != nil {
+		s.logger.Critical(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
 	if err = s.communicator.Stop(conn); err != nil {
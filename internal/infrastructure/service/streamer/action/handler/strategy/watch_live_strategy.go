@@ -0,0 +1,127 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ViewerLagsBehindError = errors.New("the viewer lags behind the broadcast")
+
+type WatchLiveActionStrategy struct {
+	logger       loggerinterface.Logger
+	communicator protointerface.Communicator
+	broadcasts   liveinterface.Broadcasts
+}
+
+func NewWatchLiveActionStrategy(serviceContainer diinterface.ServiceContainer) (*WatchLiveActionStrategy, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	liveBroadcasts, err := serviceContainer.GetLiveBroadcastsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &WatchLiveActionStrategy{
+		logger:       loggerService,
+		communicator: webSocketCommunicator,
+		broadcasts:   liveBroadcasts,
+	}, nil
+}
+
+// IsAppropriate - method will tell the service architect that the strategy is acceptable.
+func (s *WatchLiveActionStrategy) IsAppropriate(action model.Action) bool {
+	return action.Do == enum.WatchLive
+}
+
+// Do - will be streaming the live broadcast to the viewer until the broadcast is over.
+func (s *WatchLiveActionStrategy) Do(action model.Action) error {
+	// check the data is eligible
+	data, ok := action.Data.(*model.WatchLiveData)
+	if !ok {
+		return s.logger.CriticalPropagate(
+			fmt.Errorf("'watch live' strategy cannot handle the given data '%+v'", data),
+		)
+	}
+
+	// parse the given broadcast identifier
+	oid, err := primitive.ObjectIDFromHex(data.ID)
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// subscribe to the target broadcast
+	b, backlog, chunks, err := s.broadcasts.Watch(action.Conn, vo.NewID(oid))
+	if err != nil {
+		if errtype.IsEntityNotFoundError(err) {
+			if err = s.communicator.Error(err, action.Conn); err != nil {
+				return s.logger.LogPropagate(err)
+			}
+		}
+		return s.logger.LogPropagate(err)
+	}
+	defer s.broadcasts.Unwatch(action.Conn)
+
+	// the action may be replaced by the next one before the subscription, thus it was not unwatched by the listener
+	if action.Ctx.Err() != nil {
+		return nil
+	}
+	s.logger.Info(fmt.Sprintf("[%v]: watching 'broadcast':'%v'", action.Conn.RemoteAddr(), data.ID))
+
+	// send the initializing message to client side
+	if err = s.communicator.Live(data.ID, b.GetMimeType(), action.Conn); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	// the late joiner receives the init segment and the DVR window first
+	for _, chunk := range backlog {
+		if err = s.communicator.Send(chunk, action.Conn); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+
+	// the channel is closed when the broadcast is over, the viewer lags behind or is gone
+	for chunk := range chunks {
+		if err = s.communicator.Send(chunk, action.Conn); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+	}
+
+	if b.Lagged(chunks) {
+		if err = s.communicator.Error(ViewerLagsBehindError, action.Conn); err != nil {
+			return s.logger.LogPropagate(err)
+		}
+		return s.logger.InfoPropagate(ViewerLagsBehindError)
+	}
+
+	// the viewer has left the broadcast (another action is requested, or the viewer is gone)
+	select {
+	case <-b.Done():
+	default:
+		s.logger.Info(fmt.Sprintf("[%v]: watching 'broadcast':'%v' is stopped", action.Conn.RemoteAddr(), data.ID))
+		return nil
+	}
+
+	// stop the streaming by sending appropriate message to client side
+	if err = s.communicator.Stop(action.Conn); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	return nil
+}
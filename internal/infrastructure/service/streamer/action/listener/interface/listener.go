@@ -1,11 +1,14 @@
 package listenerinterface
 
 import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"github.com/gorilla/websocket"
 	"sync"
 )
 
 type ActionsListener interface {
-	Listen(wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action
+	// Listen will read the actions of the connection. Any message of the client is signaled as the activity,
+	// because the party actions, the buffer statuses and the controls are applied without the handler.
+	Listen(wg *sync.WaitGroup, conn *websocket.Conn, userID vo.ID) (actions <-chan model.Action, activity <-chan struct{})
 }
@@ -1,25 +1,47 @@
 package listener
 
 import (
+	"context"
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	partyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party/interface"
 	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	queueinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
 	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net"
 	"sync"
+	"time"
 )
 
 var (
 	supportedActionsMap = map[enum.Actions]struct{}{
-		enum.StreamByID: {},
+		enum.StreamByID:    {},
+		enum.ResumeSession: {},
+		enum.Playlist:      {},
+		enum.Publish:       {},
+		enum.WatchLive:     {},
 	}
 )
 
 type WebSocketActionsListener struct {
+	ctx          context.Context
 	logger       loggerinterface.Logger
 	communicator protointerface.Communicator
+	shaper       shaperinterface.Shaper
+	queues       queueinterface.Queues
+	broadcasts   liveinterface.Broadcasts
+	parties      partyinterface.Parties
+	pingInterval time.Duration
+	pongTimeout  time.Duration
+	writeTimeout time.Duration
+	maxMsgSize   int64
 }
 
 func NewWebSocketActionsListener(serviceContainer diinterface.ServiceContainer) (*WebSocketActionsListener, error) {
@@ -28,24 +50,120 @@ func NewWebSocketActionsListener(serviceContainer diinterface.ServiceContainer)
 		return nil, err
 	}
 
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	webSocketCommunicatorService, err := serviceContainer.GetWebSocketCommunicatorService()
 	if err != nil {
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	streamShaperService, err := serviceContainer.GetStreamShaperService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playbackQueuesService, err := serviceContainer.GetPlaybackQueuesService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	liveBroadcastsService, err := serviceContainer.GetLiveBroadcastsService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchPartiesService, err := serviceContainer.GetWatchPartiesService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	pingInterval, err := time.ParseDuration(cfg.StreamingPingInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	pongTimeout, err := time.ParseDuration(cfg.StreamingPongTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	writeTimeout, err := time.ParseDuration(cfg.StreamingWriteTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if cfg.StreamingMaxMessageSize <= 0 {
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("max. message size '%d' must be greater than zero", cfg.StreamingMaxMessageSize),
+		)
+	}
+
+	if pongTimeout <= pingInterval {
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("pong timeout '%v' must be greater than ping interval '%v'", pongTimeout, pingInterval),
+		)
+	}
+
 	return &WebSocketActionsListener{
+		ctx:          ctx,
 		logger:       loggerService,
 		communicator: webSocketCommunicatorService,
+		shaper:       streamShaperService,
+		queues:       playbackQueuesService,
+		broadcasts:   liveBroadcastsService,
+		parties:      watchPartiesService,
+		pingInterval: pingInterval,
+		pongTimeout:  pongTimeout,
+		writeTimeout: writeTimeout,
+		maxMsgSize:   cfg.StreamingMaxMessageSize,
 	}, nil
 }
 
-func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Conn) <-chan model.Action {
+func (l *WebSocketActionsListener) Listen(
+	wg *sync.WaitGroup,
+	conn *websocket.Conn,
+	userID vo.ID,
+) (actions <-chan model.Action, activity <-chan struct{}) {
 	actionsCh := make(chan model.Action, 1)
+	activityCh := make(chan struct{}, 1)
+	stopPingCh := make(chan struct{})
+
+	// the current action is interrupted by its context, thus it's stopped even before the stream is shaped
+	cancelAction := context.CancelFunc(func() {})
+
+	// the messages are read into memory entirely, thus their size is limited
+	conn.SetReadLimit(l.maxMsgSize)
+
+	// the read deadline is extended by any received message (including pongs), thus the stalled client
+	// which does not respond to pings is disconnected
+	if err := l.extendReadDeadline(conn); err != nil {
+		l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+	conn.SetPongHandler(func(string) error {
+		return l.extendReadDeadline(conn)
+	})
+
+	wg.Add(1)
+	go l.ping(wg, conn, stopPingCh)
 
 	wg.Add(1)
 	go func() {
 		defer func() {
+			// the client is gone, thus the current stream must not be sent anymore
+			cancelAction()
+			l.shaper.Stop(conn)
+			l.broadcasts.Leave(conn)
+			close(stopPingCh)
 			close(actionsCh)
+			close(activityCh)
 			wg.Done()
 		}()
 
@@ -56,17 +174,70 @@ func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Co
 					l.logger.Info(fmt.Sprintf("[%v]: websocket connection has been closed", conn.RemoteAddr()))
 					return
 				}
+				if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+					l.logger.Info(fmt.Sprintf("[%v]: websocket connection is timed out", conn.RemoteAddr()))
+					return
+				}
 				l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 				return
 			}
+			if err = l.extendReadDeadline(conn); err != nil {
+				l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+				return
+			}
+			// the client is active, the pending signal is enough (the idle timer is reset by it anyway)
+			select {
+			case activityCh <- struct{}{}:
+			default:
+			}
+			// the media data of the live broadcast is passed to the viewers directly, because the handler
+			// is busy by the publish action
+			if t == websocket.BinaryMessage {
+				if !l.broadcasts.Push(conn, b) {
+					l.logger.Info(fmt.Sprintf("[%v]: binary message received, but broadcast is not published", conn.RemoteAddr()))
+				}
+				continue
+			}
 			if t == websocket.TextMessage {
 				do, data, perr := l.communicator.Parse(b)
 				if perr != nil {
 					l.logger.Error(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), perr.Error()))
 					return
 				}
+				// the acknowledgements are passed to the current stream directly, because the handler is busy by it
+				if status, isStatus := data.(*model.BufferStatusData); isStatus {
+					l.shaper.Ack(conn, status.Position)
+					l.parties.Report(conn, status.Position)
+					continue
+				}
+				// the watch party actions only notify the members, thus they don't wait for the handler
+				if l.isPartyAction(do) {
+					l.party(conn, userID, do, data)
+					continue
+				}
+				// the playlist controls are passed to the current queue directly by the same reason
+				if l.isControl(do) {
+					l.control(conn, do, data)
+					continue
+				}
+				if do == enum.Unpublish {
+					l.broadcasts.Unpublish(conn)
+					continue
+				}
 				if _, isSupported := supportedActionsMap[do]; isSupported {
-					actionsCh <- model.Action{Do: do, Data: data, Conn: conn}
+					// the new stream replaces the current one (the playing playlist, the watched or the published
+					// broadcast), otherwise the action awaits its end
+					if q, found := l.queues.Find(conn); found {
+						q.Close()
+					}
+					l.broadcasts.Unwatch(conn)
+					l.broadcasts.Unpublish(conn)
+					l.shaper.Stop(conn)
+					cancelAction()
+
+					var actionCtx context.Context
+					actionCtx, cancelAction = context.WithCancel(l.ctx)
+					actionsCh <- model.Action{Do: do, Data: data, Conn: conn, UserID: userID, Ctx: actionCtx}
 					l.logger.Info(fmt.Sprintf("action '%v' with data '%v' received", do, data))
 				} else {
 					l.logger.Critical(fmt.Sprintf("do: %+v, data: %+v received unsupport action", do, data))
@@ -75,5 +246,92 @@ func (l *WebSocketActionsListener) Listen(wg *sync.WaitGroup, conn *websocket.Co
 		}
 	}()
 
-	return actionsCh
+	return actionsCh, activityCh
+}
+
+func (l *WebSocketActionsListener) isControl(do enum.Actions) bool {
+	return do == enum.Next || do == enum.Prev || do == enum.Shuffle || do == enum.Repeat
+}
+
+// control will apply the playlist control to the current queue of the connection.
+func (l *WebSocketActionsListener) control(conn *websocket.Conn, do enum.Actions, data interface{}) {
+	q, found := l.queues.Find(conn)
+	if !found {
+		l.logger.Info(fmt.Sprintf("[%v]: action '%v' received, but playlist is not playing", conn.RemoteAddr(), do))
+		return
+	}
+
+	switch do {
+	case enum.Next:
+		q.Skip(false)
+	case enum.Prev:
+		q.Skip(true)
+	case enum.Shuffle:
+		if d, ok := data.(*model.PlaylistControlData); ok {
+			q.Shuffle(d.Enabled)
+		}
+	case enum.Repeat:
+		if d, ok := data.(*model.PlaylistControlData); ok && d.Mode.IsValid() {
+			q.Repeat(d.Mode)
+		}
+	}
+	l.logger.Info(fmt.Sprintf("[%v]: action '%v' with data '%v' applied", conn.RemoteAddr(), do, data))
+}
+
+func (l *WebSocketActionsListener) isPartyAction(do enum.Actions) bool {
+	return do == enum.PartyCreate || do == enum.PartyJoin || do == enum.PartyLeave ||
+		do == enum.PartyState || do == enum.PartyTransfer
+}
+
+// party will apply the watch party action, the failure is sent to the client.
+func (l *WebSocketActionsListener) party(conn *websocket.Conn, userID vo.ID, do enum.Actions, data interface{}) {
+	var err error
+	switch d := data.(type) {
+	case *model.PartyCreateData:
+		err = l.parties.Create(conn, userID)
+	case *model.PartyJoinData:
+		err = l.parties.Join(conn, userID, d.Token)
+	case *model.PartyLeaveData:
+		l.parties.Leave(conn)
+	case *model.PartyStateData:
+		err = l.parties.SetState(conn, d.VideoID, d.Position, d.Paused)
+	case *model.PartyTransferData:
+		var oid primitive.ObjectID
+		if oid, err = primitive.ObjectIDFromHex(d.UserID); err == nil {
+			err = l.parties.Transfer(conn, vo.NewID(oid))
+		}
+	}
+
+	if err != nil {
+		if e := l.communicator.Error(err, conn); e != nil {
+			l.logger.Error(e)
+		}
+		return
+	}
+	l.logger.Info(fmt.Sprintf("[%v]: action '%v' with data '%v' applied", conn.RemoteAddr(), do, data))
+}
+
+// ping will send the ping messages until the listener is stopped. The failed ping is not an error of the listener,
+// the connection will be closed by the expired read deadline.
+func (l *WebSocketActionsListener) ping(wg *sync.WaitGroup, conn *websocket.Conn, stopCh <-chan struct{}) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(l.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(l.writeTimeout)); err != nil {
+				l.logger.Info(fmt.Sprintf("[%v]: ping failed: %v", conn.RemoteAddr(), err.Error()))
+				return
+			}
+		}
+	}
+}
+
+func (l *WebSocketActionsListener) extendReadDeadline(conn *websocket.Conn) error {
+	return conn.SetReadDeadline(time.Now().Add(l.pongTimeout))
 }
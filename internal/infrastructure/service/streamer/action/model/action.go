@@ -1,6 +1,8 @@
 package model
 
 import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/gorilla/websocket"
 )
@@ -9,4 +11,10 @@ type Action struct {
 	Do   enum.Actions
 	Data interface{}
 	Conn *websocket.Conn
+	// UserID is an owner of the connection (authenticated while the handshake).
+	UserID vo.ID
+	// Ctx is cancelled when the action is replaced by the next one, the client is gone or the server is stopping.
+	Ctx context.Context
+	// Done is called by the handler when the action is handled (may be nil).
+	Done func()
 }
@@ -1,13 +1,82 @@
 package model
 
+import "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+
 type StreamByIdData struct {
-	ID    string `json:"id"`
-	Token string `json:"token"`
+	ID string `json:"id"`
+	// Resume means the stream must be continued from the saved watch progress (if the video is not completed).
+	Resume bool `json:"resume"`
+	// From is a position in seconds where the stream starts (e.g. the member of the watch party catches up the host),
+	// it takes precedence over Resume.
+	From float64 `json:"from"`
 }
 
 type StreamByIdWithOffsetData struct {
 	ID       string  `json:"id"`
-	Token    string  `json:"token"`
 	From     float64 `json:"from"`
 	Duration float64 `json:"duration"`
 }
+
+// BufferStatusData is an acknowledgement of the client which reports the playhead position of the player in seconds.
+type BufferStatusData struct {
+	Position float64 `json:"position"`
+}
+
+// ResumeSessionData is a request of continuation of the playback session from the last saved position.
+type ResumeSessionData struct {
+	SessionID string `json:"sessionID"`
+}
+
+// PlaylistData is a request of streaming the videos of the playlist back to back (starting from the item by index).
+type PlaylistData struct {
+	ID      string          `json:"id"`
+	Index   int             `json:"index"`
+	Shuffle bool            `json:"shuffle"`
+	Repeat  enum.RepeatMode `json:"repeat"`
+}
+
+// PlaylistControlData is a control of the current playlist (NEXT, PREV, SHUFFLE or REPEAT).
+// Enabled is used by SHUFFLE, Mode is used by REPEAT.
+type PlaylistControlData struct {
+	Enabled bool            `json:"enabled"`
+	Mode    enum.RepeatMode `json:"mode"`
+}
+
+// PublishData is a request of starting the live broadcast. MimeType is a type of the MediaRecorder data
+// (for example, 'video/webm; codecs="vp8, opus"'), Record means the broadcast must be saved as a video on stop.
+type PublishData struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+	Record   bool   `json:"record"`
+}
+
+// UnpublishData is a request of stopping the live broadcast of the connection.
+type UnpublishData struct{}
+
+// WatchLiveData is a request of watching the live broadcast by ID.
+type WatchLiveData struct {
+	ID string `json:"id"`
+}
+
+// PartyCreateData is a request of creating the watch party (the requester becomes its host).
+type PartyCreateData struct{}
+
+// PartyJoinData is a request of joining the watch party by the invite token.
+type PartyJoinData struct {
+	Token string `json:"token"`
+}
+
+// PartyLeaveData is a request of leaving the current watch party.
+type PartyLeaveData struct{}
+
+// PartyStateData is a playback state of the host which is broadcast to the members of the party.
+type PartyStateData struct {
+	VideoID  string  `json:"videoID"`
+	Position float64 `json:"position"`
+	Paused   bool    `json:"paused"`
+}
+
+// PartyTransferData is a request of passing the host role to the member of the party.
+type PartyTransferData struct {
+	UserID string `json:"userID"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+
+// PartyMessage is a message of the watch party which is sent to the members. The position is an expected
+// position of the host playhead at the moment of sending.
+type PartyMessage struct {
+	Event    enum.PartyEvent `json:"event"`
+	PartyID  string          `json:"partyID"`
+	Token    string          `json:"token,omitempty"`
+	HostID   string          `json:"hostID"`
+	IsHost   bool            `json:"isHost"`
+	Members  int             `json:"members"`
+	VideoID  string          `json:"videoID"`
+	Position float64         `json:"position"`
+	Paused   bool            `json:"paused"`
+}
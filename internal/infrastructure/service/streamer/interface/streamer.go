@@ -1,7 +1,11 @@
 package streamerinterface
 
-import "github.com/gorilla/websocket"
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/gorilla/websocket"
+)
 
 type Streamer interface {
-	HandleConn(conn *websocket.Conn)
+	// HandleConn will serve the connection which is already authenticated by the given user.
+	HandleConn(conn *websocket.Conn, userID vo.ID)
 }
@@ -0,0 +1,175 @@
+package live
+
+import (
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/reader/model"
+	"os"
+	"sync"
+	"time"
+)
+
+type dvrChunk struct {
+	chunk      dtointerface.Chunk
+	receivedAt time.Time
+}
+
+// broadcast keeps the init segment (the first chunk of the publisher which contains the headers of the media),
+// the chunks of the DVR window and the queues of the viewers.
+type broadcast struct {
+	id        vo.ID
+	userID    vo.ID
+	name      string
+	mimeType  string
+	startedAt time.Time
+	stoppedAt time.Time
+
+	mu      *sync.Mutex
+	init    dtointerface.Chunk
+	dvr     []dvrChunk
+	viewers map[chan dtointerface.Chunk]struct{}
+	lagged  map[<-chan dtointerface.Chunk]struct{}
+	file    *os.File // recording (nil when the broadcast is not recorded)
+	fileErr error    // the recording is broken by the error
+	stopped bool
+	done    chan struct{}
+}
+
+func (b *broadcast) GetID() vo.ID {
+	return b.id
+}
+func (b *broadcast) GetUserID() vo.ID {
+	return b.userID
+}
+func (b *broadcast) GetName() string {
+	return b.name
+}
+func (b *broadcast) GetMimeType() string {
+	return b.mimeType
+}
+func (b *broadcast) IsRecorded() bool {
+	return b.file != nil
+}
+func (b *broadcast) Done() <-chan struct{} {
+	return b.done
+}
+
+// Lagged tells that the viewer channel was closed because the viewer lags behind.
+func (b *broadcast) Lagged(chunks <-chan dtointerface.Chunk) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, lagged := b.lagged[chunks]
+	return lagged
+}
+
+// recordingErr returns the error which has broken the recording.
+func (b *broadcast) recordingErr() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.fileErr
+}
+
+// push will append the data to the DVR window and the recording, and will send it to the viewers. The viewer
+// whose queue is full is dropped (its channel is closed), thus the slow viewer does not stall the others.
+// The returned error is the error of recording (it's returned once, the broken recording is not written anymore).
+func (b *broadcast) push(data []byte, window time.Duration) error {
+	chunk := &model.Chunk{Data: append([]byte(nil), data...)}
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return nil
+	}
+
+	if b.init == nil {
+		b.init = chunk
+	} else {
+		b.dvr = append(b.dvr, dvrChunk{chunk: chunk, receivedAt: now})
+		expired := 0
+		for expired < len(b.dvr) && now.Sub(b.dvr[expired].receivedAt) > window {
+			expired++
+		}
+		b.dvr = b.dvr[expired:]
+	}
+
+	for ch := range b.viewers {
+		select {
+		case ch <- chunk:
+		default:
+			delete(b.viewers, ch)
+			b.lagged[ch] = struct{}{}
+			close(ch)
+		}
+	}
+
+	if b.file != nil && b.fileErr == nil {
+		if _, err := b.file.Write(chunk.Data); err != nil {
+			b.fileErr = err
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subscribe will return the init segment with the DVR window and the channel of the live data.
+func (b *broadcast) subscribe(buffer int) (backlog []dtointerface.Chunk, ch chan dtointerface.Chunk) {
+	ch = make(chan dtointerface.Chunk, buffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		close(ch)
+		return nil, ch
+	}
+
+	if b.init != nil {
+		backlog = append(backlog, b.init)
+	}
+	for _, c := range b.dvr {
+		backlog = append(backlog, c.chunk)
+	}
+	b.viewers[ch] = struct{}{}
+
+	return backlog, ch
+}
+
+func (b *broadcast) unsubscribe(ch chan dtointerface.Chunk) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.lagged, ch)
+
+	if _, ok := b.viewers[ch]; ok {
+		delete(b.viewers, ch)
+		close(ch)
+	}
+}
+
+// stop will close the channels of the viewers and the recording file.
+func (b *broadcast) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stopped {
+		return
+	}
+	b.stopped = true
+	b.stoppedAt = time.Now()
+
+	for ch := range b.viewers {
+		close(ch)
+	}
+	b.viewers = nil
+	b.dvr = nil
+
+	if b.file != nil {
+		_ = b.file.Close()
+	}
+	close(b.done)
+}
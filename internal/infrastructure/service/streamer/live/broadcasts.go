@@ -0,0 +1,330 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	storagerinterface "github.com/Borislavv/video-streaming/internal/domain/service/storager/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	liveinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/live/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recordingTimeout is a max. time of saving the recording of the stopped broadcast.
+const recordingTimeout = time.Minute
+
+var (
+	// supportedMimeTypes is a map of the supported media containers of the MediaRecorder with extensions
+	// of the recording files.
+	supportedMimeTypes = map[string]string{
+		"video/webm": ".webm",
+		"video/mp4":  ".mp4",
+	}
+
+	AlreadyPublishingError = errors.New("the connection is already publishing a broadcast")
+	NotStoppedError        = errors.New("the broadcast is not stopped yet")
+)
+
+// LiveBroadcasts keeps the broadcasts of the instance in memory, thus the viewers must be connected
+// to the same instance as the publisher.
+type LiveBroadcasts struct {
+	ctx                context.Context
+	logger             loggerinterface.Logger
+	storage            storagerinterface.Storage
+	resourceRepository repositoryinterface.Resource
+	videoRepository    repositoryinterface.Video
+	dvrWindow          time.Duration
+	viewerBuffer       int
+
+	mu         *sync.Mutex
+	broadcasts map[primitive.ObjectID]*broadcast
+	publishers map[*websocket.Conn]*broadcast
+	viewers    map[*websocket.Conn]*broadcast
+	viewersChs map[*websocket.Conn]chan dtointerface.Chunk
+}
+
+func NewLiveBroadcasts(serviceContainer diinterface.ServiceContainer) (*LiveBroadcasts, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	fileStorageService, err := serviceContainer.GetFileStorageService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	resourceRepository, err := serviceContainer.GetResourceRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	videoRepository, err := serviceContainer.GetVideoRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	dvrWindow, err := time.ParseDuration(cfg.StreamingLiveDVRWindow)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if cfg.StreamingLiveViewerBuffer <= 0 {
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("live viewer buffer '%d' must be greater than zero", cfg.StreamingLiveViewerBuffer),
+		)
+	}
+
+	return &LiveBroadcasts{
+		ctx:                ctx,
+		logger:             loggerService,
+		storage:            fileStorageService,
+		resourceRepository: resourceRepository,
+		videoRepository:    videoRepository,
+		dvrWindow:          dvrWindow,
+		viewerBuffer:       cfg.StreamingLiveViewerBuffer,
+		mu:                 &sync.Mutex{},
+		broadcasts:         make(map[primitive.ObjectID]*broadcast),
+		publishers:         make(map[*websocket.Conn]*broadcast),
+		viewers:            make(map[*websocket.Conn]*broadcast),
+		viewersChs:         make(map[*websocket.Conn]chan dtointerface.Chunk),
+	}, nil
+}
+
+func (s *LiveBroadcasts) Publish(
+	conn *websocket.Conn,
+	userID vo.ID,
+	name string,
+	mimeType string,
+	record bool,
+) (liveinterface.Broadcast, error) {
+	if name == "" {
+		return nil, s.logger.LogPropagate(errtype.NewFieldCannotBeEmptyError("name"))
+	}
+
+	ext, supported := supportedMimeTypes[container(mimeType)]
+	if !supported {
+		return nil, s.logger.LogPropagate(
+			errtype.NewInternalValidationError(fmt.Sprintf("mime type '%v' is not supported", mimeType)),
+		)
+	}
+
+	b := &broadcast{
+		id:        vo.NewID(primitive.NewObjectID()),
+		userID:    userID,
+		name:      name,
+		mimeType:  mimeType,
+		startedAt: time.Now(),
+		mu:        &sync.Mutex{},
+		viewers:   make(map[chan dtointerface.Chunk]struct{}),
+		lagged:    make(map[<-chan dtointerface.Chunk]struct{}),
+		done:      make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, publishing := s.publishers[conn]; publishing {
+		return nil, s.logger.LogPropagate(AlreadyPublishingError)
+	}
+
+	// the recording is written to the temporary file which is moved to the resources on stop
+	if record {
+		file, err := os.CreateTemp("", "live-*"+ext)
+		if err != nil {
+			return nil, s.logger.LogPropagate(err)
+		}
+		b.file = file
+	}
+
+	s.broadcasts[b.id.Value] = b
+	s.publishers[conn] = b
+
+	return b, nil
+}
+
+func (s *LiveBroadcasts) Push(conn *websocket.Conn, data []byte) bool {
+	s.mu.Lock()
+	b, publishing := s.publishers[conn]
+	s.mu.Unlock()
+
+	if !publishing {
+		return false
+	}
+
+	// the broadcast is continued, but its recording is broken
+	if err := b.push(data, s.dvrWindow); err != nil {
+		s.logger.Error(fmt.Sprintf("[%v]: recording of the broadcast failed: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return true
+}
+
+func (s *LiveBroadcasts) Unpublish(conn *websocket.Conn) {
+	s.mu.Lock()
+	b, publishing := s.publishers[conn]
+	if publishing {
+		delete(s.publishers, conn)
+		delete(s.broadcasts, b.id.Value)
+	}
+	s.mu.Unlock()
+
+	if publishing {
+		b.stop()
+	}
+}
+
+func (s *LiveBroadcasts) Watch(
+	conn *websocket.Conn,
+	id vo.ID,
+) (
+	b liveinterface.Broadcast,
+	backlog []dtointerface.Chunk,
+	chunks <-chan dtointerface.Chunk,
+	err error,
+) {
+	s.Unwatch(conn)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found, ok := s.broadcasts[id.Value]
+	if !ok {
+		return nil, nil, nil, s.logger.InfoPropagate(errtype.NewEntityNotFoundError("broadcast", "id"))
+	}
+
+	backlog, ch := found.subscribe(s.viewerBuffer)
+	s.viewers[conn] = found
+	s.viewersChs[conn] = ch
+
+	return found, backlog, ch, nil
+}
+
+func (s *LiveBroadcasts) Unwatch(conn *websocket.Conn) {
+	s.mu.Lock()
+	b, watching := s.viewers[conn]
+	ch := s.viewersChs[conn]
+	delete(s.viewers, conn)
+	delete(s.viewersChs, conn)
+	s.mu.Unlock()
+
+	if watching {
+		b.unsubscribe(ch)
+	}
+}
+
+func (s *LiveBroadcasts) Leave(conn *websocket.Conn) {
+	s.Unpublish(conn)
+	s.Unwatch(conn)
+}
+
+func (s *LiveBroadcasts) Record(lb liveinterface.Broadcast) (video *agg.Video, err error) {
+	b, ok := lb.(*broadcast)
+	if !ok || !b.IsRecorded() {
+		return nil, s.logger.LogPropagate(fmt.Errorf("broadcast '%v' is not recorded", lb.GetID().Value.Hex()))
+	}
+
+	select {
+	case <-b.done:
+	default:
+		return nil, s.logger.LogPropagate(NotStoppedError)
+	}
+
+	// the temporary file is removed anyway, it's copied by the storage
+	defer func() { _ = os.Remove(b.file.Name()) }()
+
+	if err = b.recordingErr(); err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	file, err := os.Open(b.file.Name())
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	// the broadcast is usually stopped by the shutdown or by the disconnect of the publisher,
+	// thus the recording must be saved even if the app context is already canceled
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(s.ctx), recordingTimeout)
+	defer cancel()
+
+	ext := supportedMimeTypes[container(b.mimeType)]
+	filename := b.id.Hex() + ext
+
+	length, path, err := s.storage.Store(b.userID, filename, file)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+	defer func() {
+		if err != nil {
+			if e := s.storage.Remove(b.userID, filename); e != nil {
+				s.logger.Log(e)
+			}
+		}
+	}()
+
+	resource, err := s.resourceRepository.Insert(ctx, &agg.Resource{
+		Resource: entity.Resource{
+			UserID:   b.userID,
+			Name:     b.name + ext,
+			Filename: filename,
+			Filepath: path,
+			Filetype: b.mimeType,
+			Filesize: length,
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	})
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	// the start time is added to the name, because the names of videos of the user are unique
+	video, err = s.videoRepository.Insert(ctx, &agg.Video{
+		Video: entity.Video{
+			UserID:   b.userID,
+			Name:     fmt.Sprintf("%v (%v)", b.name, b.startedAt.Format(time.DateTime)),
+			Duration: b.stoppedAt.Sub(b.startedAt).Seconds(),
+		},
+		Resource: resource.Resource,
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	})
+	if err != nil {
+		if e := s.resourceRepository.Remove(ctx, resource); e != nil {
+			s.logger.Log(e)
+		}
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return video, nil
+}
+
+// container returns the mime type of the media container (the codecs parameters are omitted).
+func container(mimeType string) string {
+	return strings.TrimSpace(strings.Split(mimeType, ";")[0])
+}
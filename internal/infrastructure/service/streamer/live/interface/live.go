@@ -0,0 +1,41 @@
+package liveinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/gorilla/websocket"
+)
+
+type Broadcasts interface {
+	// Publish will start the broadcast of the publisher connection (one connection may publish one broadcast).
+	Publish(conn *websocket.Conn, userID vo.ID, name string, mimeType string, record bool) (Broadcast, error)
+	// Push will pass the media data of the publisher to the viewers. False is returned when the connection
+	// is not publishing.
+	Push(conn *websocket.Conn, data []byte) bool
+	// Unpublish will stop the broadcast of the publisher connection (the viewers receive the end of the stream).
+	Unpublish(conn *websocket.Conn)
+	// Watch will subscribe the viewer connection to the broadcast (the previous subscription of the connection
+	// is closed). The init segment with the data of the DVR window is returned as backlog, the live data
+	// is received by the chunks channel which is closed when the broadcast is over, or the viewer lags behind.
+	Watch(conn *websocket.Conn, id vo.ID) (b Broadcast, backlog []dtointerface.Chunk, chunks <-chan dtointerface.Chunk, err error)
+	// Unwatch will close the subscription of the viewer connection.
+	Unwatch(conn *websocket.Conn)
+	// Leave will release the connection which is gone: its broadcast is stopped and its subscription is closed.
+	Leave(conn *websocket.Conn)
+	// Record will save the recording of the stopped broadcast as a regular video.
+	Record(b Broadcast) (*agg.Video, error)
+}
+
+type Broadcast interface {
+	GetID() vo.ID
+	GetUserID() vo.ID
+	GetName() string
+	GetMimeType() string
+	// IsRecorded tells that the data of the broadcast is written to the recording.
+	IsRecorded() bool
+	// Lagged tells that the live data channel of the viewer was closed because the viewer lags behind.
+	Lagged(chunks <-chan dtointerface.Chunk) bool
+	// Done is closed when the broadcast is stopped.
+	Done() <-chan struct{}
+}
@@ -0,0 +1,26 @@
+package partyinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/gorilla/websocket"
+)
+
+type Parties interface {
+	// Create will make a new watch party with the connection as a host (the connection leaves its previous party).
+	Create(conn *websocket.Conn, userID vo.ID) error
+	// Join will add the connection to the party by the invite token (the connection leaves its previous party).
+	Join(conn *websocket.Conn, userID vo.ID, token string) error
+	// Leave will remove the connection from its party. The host role is passed to the earliest member
+	// when the host leaves, the party without members is closed.
+	Leave(conn *websocket.Conn)
+	// SetState will broadcast the playback state of the host to the members.
+	SetState(conn *websocket.Conn, videoID string, position float64, paused bool) error
+	// Transfer will pass the host role to the member of the party.
+	Transfer(conn *websocket.Conn, userID vo.ID) error
+	// Host will return the host user of the connection party when the host is playing the given video,
+	// thus the members are able to stream the video which is owned by the host.
+	Host(conn *websocket.Conn, videoID string) (userID vo.ID, ok bool)
+	// Report will take the playhead position of the connection (see enum.BufferStatus), the member which drifts
+	// from the host is synchronized, the position of the host keeps the party clock accurate.
+	Report(conn *websocket.Conn, position float64)
+}
@@ -0,0 +1,375 @@
+package party
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"math"
+	"sync"
+	"time"
+)
+
+// inviteTokenLength is a number of random bytes of the invite token.
+const inviteTokenLength = 16
+
+type member struct {
+	conn       *websocket.Conn
+	userID     vo.ID
+	lastSyncAt time.Time
+}
+
+type party struct {
+	id      vo.ID
+	token   string
+	host    *member
+	members []*member // in order of joining (including the host)
+
+	// playback state of the host
+	videoID   string
+	position  float64
+	paused    bool
+	updatedAt time.Time
+}
+
+// expected returns the position of the host playhead at the given moment.
+func (p *party) expected(now time.Time) float64 {
+	if p.paused || p.videoID == "" {
+		return p.position
+	}
+	return p.position + now.Sub(p.updatedAt).Seconds()
+}
+
+func (p *party) message(event enum.PartyEvent, m *member, now time.Time) *model.PartyMessage {
+	return &model.PartyMessage{
+		Event:    event,
+		PartyID:  p.id.Value.Hex(),
+		Token:    p.token,
+		HostID:   p.host.userID.Value.Hex(),
+		IsHost:   p.host == m,
+		Members:  len(p.members),
+		VideoID:  p.videoID,
+		Position: p.expected(now),
+		Paused:   p.paused,
+	}
+}
+
+type notification struct {
+	conn *websocket.Conn
+	msg  *model.PartyMessage
+}
+
+// WatchParties keeps the watch parties of the instance in memory, thus the members must be connected
+// to the same instance.
+type WatchParties struct {
+	logger        loggerinterface.Logger
+	communicator  protointerface.Communicator
+	maxMembers    int
+	syncTolerance time.Duration
+	syncCooldown  time.Duration
+
+	mu      *sync.Mutex
+	byToken map[string]*party
+	byConn  map[*websocket.Conn]*party
+}
+
+func NewWatchParties(serviceContainer diinterface.ServiceContainer) (*WatchParties, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	syncTolerance, err := time.ParseDuration(cfg.StreamingPartySyncTolerance)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	syncCooldown, err := time.ParseDuration(cfg.StreamingPartySyncCooldown)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if cfg.StreamingPartyMaxMembers < 2 {
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("party max. members '%d' must be at least 2", cfg.StreamingPartyMaxMembers),
+		)
+	}
+
+	return &WatchParties{
+		logger:        loggerService,
+		communicator:  webSocketCommunicator,
+		maxMembers:    cfg.StreamingPartyMaxMembers,
+		syncTolerance: syncTolerance,
+		syncCooldown:  syncCooldown,
+		mu:            &sync.Mutex{},
+		byToken:       make(map[string]*party),
+		byConn:        make(map[*websocket.Conn]*party),
+	}, nil
+}
+
+func (s *WatchParties) Create(conn *websocket.Conn, userID vo.ID) error {
+	token, err := s.token()
+	if err != nil {
+		return s.logger.LogPropagate(err)
+	}
+
+	s.mu.Lock()
+	notifications := s.leave(conn)
+
+	host := &member{conn: conn, userID: userID}
+	p := &party{
+		id:        vo.NewID(primitive.NewObjectID()),
+		token:     token,
+		host:      host,
+		members:   []*member{host},
+		updatedAt: time.Now(),
+	}
+	s.byToken[token] = p
+	s.byConn[conn] = p
+
+	notifications = append(notifications, notification{conn: conn, msg: p.message(enum.PartyCreatedEvent, host, time.Now())})
+	s.mu.Unlock()
+
+	s.notify(notifications)
+
+	return nil
+}
+
+func (s *WatchParties) Join(conn *websocket.Conn, userID vo.ID, token string) error {
+	s.mu.Lock()
+
+	p, found := s.byToken[token]
+	if !found {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewEntityNotFoundError("party", "token"))
+	}
+	if current, ok := s.byConn[conn]; ok && current == p {
+		s.mu.Unlock()
+		return nil
+	}
+	if len(p.members) >= s.maxMembers {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewAccessDeniedError("the party is full"))
+	}
+
+	notifications := s.leave(conn)
+
+	m := &member{conn: conn, userID: userID, lastSyncAt: time.Now()}
+	p.members = append(p.members, m)
+	s.byConn[conn] = p
+
+	now := time.Now()
+	for _, other := range p.members {
+		event := enum.PartyMembersEvent
+		if other == m {
+			event = enum.PartyJoinedEvent
+		}
+		notifications = append(notifications, notification{conn: other.conn, msg: p.message(event, other, now)})
+	}
+	s.mu.Unlock()
+
+	s.notify(notifications)
+
+	return nil
+}
+
+func (s *WatchParties) Leave(conn *websocket.Conn) {
+	s.mu.Lock()
+	notifications := s.leave(conn)
+	s.mu.Unlock()
+
+	s.notify(notifications)
+}
+
+func (s *WatchParties) SetState(conn *websocket.Conn, videoID string, position float64, paused bool) error {
+	s.mu.Lock()
+
+	p, found := s.byConn[conn]
+	if !found {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewEntityNotFoundError("party", "connection"))
+	}
+	if p.host.conn != conn {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewAccessDeniedError("only the host may control the playback"))
+	}
+
+	now := time.Now()
+	p.videoID, p.position, p.paused, p.updatedAt = videoID, position, paused, now
+
+	var notifications []notification
+	for _, m := range p.members {
+		if m != p.host {
+			// the member which has just received the state must not be synchronized while it's seeking
+			m.lastSyncAt = now
+			notifications = append(notifications, notification{conn: m.conn, msg: p.message(enum.PartyStateEvent, m, now)})
+		}
+	}
+	s.mu.Unlock()
+
+	s.notify(notifications)
+
+	return nil
+}
+
+func (s *WatchParties) Transfer(conn *websocket.Conn, userID vo.ID) error {
+	s.mu.Lock()
+
+	p, found := s.byConn[conn]
+	if !found {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewEntityNotFoundError("party", "connection"))
+	}
+	if p.host.conn != conn {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewAccessDeniedError("only the host may transfer the host role"))
+	}
+
+	var target *member
+	for _, m := range p.members {
+		if m != p.host && m.userID.Value == userID.Value {
+			target = m
+			break
+		}
+	}
+	if target == nil {
+		s.mu.Unlock()
+		return s.logger.InfoPropagate(errtype.NewEntityNotFoundError("party member", "userID"))
+	}
+
+	// the clock of the party is fixed at the moment of the transfer, then it's kept by the reports of the new host
+	now := time.Now()
+	p.position, p.updatedAt = p.expected(now), now
+	p.host = target
+
+	notifications := s.broadcast(p, enum.PartyHostEvent, now)
+	s.mu.Unlock()
+
+	s.notify(notifications)
+
+	return nil
+}
+
+func (s *WatchParties) Host(conn *websocket.Conn, videoID string) (userID vo.ID, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, found := s.byConn[conn]
+	if !found || p.videoID == "" || p.videoID != videoID {
+		return vo.ID{}, false
+	}
+
+	return p.host.userID, true
+}
+
+func (s *WatchParties) Report(conn *websocket.Conn, position float64) {
+	s.mu.Lock()
+
+	p, found := s.byConn[conn]
+	if !found || p.videoID == "" {
+		s.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	if p.host.conn == conn {
+		if !p.paused {
+			p.position, p.updatedAt = position, now
+		}
+		s.mu.Unlock()
+		return
+	}
+
+	var notifications []notification
+	for _, m := range p.members {
+		if m.conn != conn {
+			continue
+		}
+		drift := math.Abs(position - p.expected(now))
+		if drift > s.syncTolerance.Seconds() && now.Sub(m.lastSyncAt) > s.syncCooldown {
+			m.lastSyncAt = now
+			notifications = append(notifications, notification{conn: conn, msg: p.message(enum.PartySyncEvent, m, now)})
+		}
+		break
+	}
+	s.mu.Unlock()
+
+	s.notify(notifications)
+}
+
+// leave will remove the connection from its party and will return the notifications of the remaining members.
+// Must be called under the lock.
+func (s *WatchParties) leave(conn *websocket.Conn) []notification {
+	p, found := s.byConn[conn]
+	if !found {
+		return nil
+	}
+	delete(s.byConn, conn)
+
+	for i, m := range p.members {
+		if m.conn == conn {
+			p.members = append(p.members[:i], p.members[i+1:]...)
+			break
+		}
+	}
+
+	if len(p.members) == 0 {
+		delete(s.byToken, p.token)
+		return nil
+	}
+
+	now := time.Now()
+	event := enum.PartyMembersEvent
+	if p.host.conn == conn {
+		p.position, p.updatedAt = p.expected(now), now
+		p.host = p.members[0]
+		event = enum.PartyHostEvent
+	}
+
+	return s.broadcast(p, event, now)
+}
+
+// broadcast will make the notifications of all members of the party. Must be called under the lock.
+func (s *WatchParties) broadcast(p *party, event enum.PartyEvent, now time.Time) []notification {
+	notifications := make([]notification, 0, len(p.members))
+	for _, m := range p.members {
+		notifications = append(notifications, notification{conn: m.conn, msg: p.message(event, m, now)})
+	}
+	return notifications
+}
+
+// notify will send the messages out of the lock, because the write may be awaited up to the write timeout.
+func (s *WatchParties) notify(notifications []notification) {
+	for _, n := range notifications {
+		if err := s.communicator.Party(n.msg, n.conn); err != nil {
+			s.logger.Error(fmt.Sprintf("[%v]: party notification failed: %v", n.conn.RemoteAddr(), err.Error()))
+		}
+	}
+}
+
+// token will generate a random invite token of the party.
+func (s *WatchParties) token() (string, error) {
+	b := make([]byte, inviteTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
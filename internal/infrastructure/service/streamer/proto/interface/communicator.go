@@ -3,13 +3,36 @@ package protointerface
 import (
 	dtointerface "github.com/Borislavv/video-streaming/internal/domain/dto/interface"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"github.com/gorilla/websocket"
 )
 
 type Communicator interface {
-	Start(audioCodec string, videoCodec string, conn *websocket.Conn) error
+	// Start will send the stream initialization message with codecs, id of the playback session and the position
+	// of the media where the stream starts (in seconds), thus the client can report the absolute playhead.
+	Start(audioCodec string, videoCodec string, sessionID string, from float64, conn *websocket.Conn) error
 	Send(chunk dtointerface.Chunk, conn *websocket.Conn) error
 	Parse(bytes []byte) (action enum.Actions, data interface{}, err error)
 	Error(err error, conn *websocket.Conn) error
 	Stop(conn *websocket.Conn) error
+	// Item will notify the client which item of the playlist is streamed next (sent before the start message
+	// of the item, the client switches to the item when the current one is ended, or immediately when it's skipped).
+	Item(index int, videoID string, conn *websocket.Conn) error
+	// Live will send the live broadcast initialization message with the mime type of its data (the publisher receives
+	// it as the acknowledgement of the broadcast start, thus the media data may be sent after it).
+	Live(broadcastID string, mimeType string, conn *websocket.Conn) error
+	// Party will send the message of the watch party as `party::<json>`.
+	Party(msg *model.PartyMessage, conn *websocket.Conn) error
+	// GoAway will notify the client that the server is going away. The resume position of the interrupted stream
+	// is passed, thus the client may request the video from the position from another instance (empty videoID
+	// means there was no active stream).
+	GoAway(videoID string, position float64, conn *websocket.Conn) error
+	// Acquire will register the write lock of the connection (the messages of one connection are written
+	// sequentially, even if they are sent by different goroutines). The messages cannot be written before it.
+	Acquire(conn *websocket.Conn)
+	// Release will drop the write lock of the connection which is gone, the messages are not written
+	// to the connection anymore (the pending write is awaited).
+	Release(conn *websocket.Conn)
+	// Close will send the close frame with given code and reason (the connection itself is closed by the owner).
+	Close(code int, reason string, conn *websocket.Conn) error
 }
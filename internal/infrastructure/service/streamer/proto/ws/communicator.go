@@ -10,7 +10,10 @@ import (
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
 	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
 	"github.com/gorilla/websocket"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -20,10 +23,31 @@ const (
 	startMsgPref string = "start"
 	errMsgPref   string = "error"
 	stopMsgPref  string = "stop"
+	goAwayPref   string = "goaway"
+	itemMsgPref  string = "item"
+	liveMsgPref  string = "live"
+	partyMsgPref string = "party"
+	// max. length of the close frame reason (the control frame payload is limited by 125 bytes including the code)
+	maxCloseReasonLen int = 123
 )
 
+var ConnectionIsReleasedError = errors.New("connection is released")
+
 type Communicator struct {
-	logger loggerinterface.Logger
+	logger       loggerinterface.Logger
+	writeTimeout time.Duration
+	compression  bool
+
+	// the messages of one connection are written by the stream and by the services which notify the connection
+	// (e.g. watch parties), but the websocket connection supports only one concurrent writer
+	mu    *sync.Mutex
+	locks map[*websocket.Conn]*connLock
+}
+
+// connLock is a write lock of the connection, the released connection is not written anymore.
+type connLock struct {
+	mu       sync.Mutex
+	released bool
 }
 
 func NewWebSocketCommunicator(serviceContainer diinterface.ServiceContainer) (*Communicator, error) {
@@ -32,22 +56,46 @@ func NewWebSocketCommunicator(serviceContainer diinterface.ServiceContainer) (*C
 		return nil, err
 	}
 
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	writeTimeout, err := time.ParseDuration(cfg.StreamingWriteTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &Communicator{
-		logger: loggerService,
+		logger:       loggerService,
+		writeTimeout: writeTimeout,
+		compression:  cfg.StreamingCompressionEnabled,
+		mu:           &sync.Mutex{},
+		locks:        make(map[*websocket.Conn]*connLock),
 	}, nil
 }
 
-func (w *Communicator) Start(audioCodec string, videoCodec string, conn *websocket.Conn) error {
+func (w *Communicator) Start(
+	audioCodec string,
+	videoCodec string,
+	sessionID string,
+	from float64,
+	conn *websocket.Conn,
+) error {
 	b := strings.Builder{}
 	b.WriteString(startMsgPref)
 	b.WriteString(protoSeparator)
 	b.WriteString(audioCodec)
 	b.WriteString(protoSeparator)
 	b.WriteString(videoCodec)
+	b.WriteString(protoSeparator)
+	b.WriteString(sessionID)
+	b.WriteString(protoSeparator)
+	b.WriteString(strconv.FormatFloat(from, 'f', 3, 64))
 	initMessage := b.String()
 
 	// writing the stream initialization message in a websocket connection
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(initMessage)); err != nil {
+	if err := w.write(conn, websocket.TextMessage, []byte(initMessage)); err != nil {
 		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
@@ -59,7 +107,7 @@ func (w *Communicator) Send(chunk dtointerface.Chunk, conn *websocket.Conn) erro
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), chunk.GetError().Error()))
 	}
 
-	if err := conn.WriteMessage(websocket.BinaryMessage, chunk.GetData()); err != nil {
+	if err := w.write(conn, websocket.BinaryMessage, chunk.GetData()); err != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 
@@ -88,6 +136,78 @@ func (w *Communicator) Parse(bytes []byte) (action enum.Actions, data interface{
 			return "", nil, w.logger.LogPropagate(err)
 		}
 		return enum.StreamByIDWithOffset, data, nil
+	case enum.BufferStatus:
+		data = &model.BufferStatusData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.BufferStatus, data, nil
+	case enum.ResumeSession:
+		data = &model.ResumeSessionData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.ResumeSession, data, nil
+	case enum.Playlist:
+		data = &model.PlaylistData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.Playlist, data, nil
+	case enum.Next, enum.Prev, enum.Shuffle, enum.Repeat:
+		data = &model.PlaylistControlData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.Actions(strategy), data, nil
+	case enum.Publish:
+		data = &model.PublishData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.Publish, data, nil
+	case enum.Unpublish:
+		data = &model.UnpublishData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.Unpublish, data, nil
+	case enum.WatchLive:
+		data = &model.WatchLiveData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.WatchLive, data, nil
+	case enum.PartyCreate:
+		data = &model.PartyCreateData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.PartyCreate, data, nil
+	case enum.PartyJoin:
+		data = &model.PartyJoinData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.PartyJoin, data, nil
+	case enum.PartyLeave:
+		data = &model.PartyLeaveData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.PartyLeave, data, nil
+	case enum.PartyState:
+		data = &model.PartyStateData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.PartyState, data, nil
+	case enum.PartyTransfer:
+		data = &model.PartyTransferData{}
+		if err = json.Unmarshal(jsonBytes, data); err != nil {
+			return "", nil, w.logger.LogPropagate(err)
+		}
+		return enum.PartyTransfer, data, nil
 	default:
 		return "", nil, fmt.Errorf(
 			"unable to parse message because received unknown strategy '%v'", strategy,
@@ -98,7 +218,7 @@ func (w *Communicator) Parse(bytes []byte) (action enum.Actions, data interface{
 func (w *Communicator) Error(err error, conn *websocket.Conn) error {
 	msg := []byte(fmt.Sprintf("%v:%v", errMsgPref, err.Error()))
 
-	if e := conn.WriteMessage(websocket.TextMessage, msg); e != nil {
+	if e := w.write(conn, websocket.TextMessage, msg); e != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), e.Error()))
 	}
 
@@ -106,8 +226,117 @@ func (w *Communicator) Error(err error, conn *websocket.Conn) error {
 }
 
 func (w *Communicator) Stop(conn *websocket.Conn) error {
-	if err := conn.WriteMessage(websocket.TextMessage, []byte(stopMsgPref)); err != nil {
+	if err := w.write(conn, websocket.TextMessage, []byte(stopMsgPref)); err != nil {
+		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+	return nil
+}
+
+func (w *Communicator) Item(index int, videoID string, conn *websocket.Conn) error {
+	msg := itemMsgPref + protoSeparator + strconv.Itoa(index) + protoSeparator + videoID
+
+	if err := w.write(conn, websocket.TextMessage, []byte(msg)); err != nil {
 		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
 	}
 	return nil
 }
+
+func (w *Communicator) Live(broadcastID string, mimeType string, conn *websocket.Conn) error {
+	msg := liveMsgPref + protoSeparator + broadcastID + protoSeparator + mimeType
+
+	if err := w.write(conn, websocket.TextMessage, []byte(msg)); err != nil {
+		return w.logger.CriticalPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+	return nil
+}
+
+func (w *Communicator) Party(msg *model.PartyMessage, conn *websocket.Conn) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return w.logger.LogPropagate(err)
+	}
+
+	if err = w.write(conn, websocket.TextMessage, append([]byte(partyMsgPref+protoSeparator), b...)); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+	return nil
+}
+
+func (w *Communicator) GoAway(videoID string, position float64, conn *websocket.Conn) error {
+	b := strings.Builder{}
+	b.WriteString(goAwayPref)
+	if videoID != "" {
+		b.WriteString(protoSeparator)
+		b.WriteString(videoID)
+		b.WriteString(protoSeparator)
+		b.WriteString(strconv.FormatFloat(position, 'f', 3, 64))
+	}
+
+	if err := w.write(conn, websocket.TextMessage, []byte(b.String())); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return nil
+}
+
+func (w *Communicator) Close(code int, reason string, conn *websocket.Conn) error {
+	if len(reason) > maxCloseReasonLen {
+		reason = reason[:maxCloseReasonLen]
+	}
+
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(w.writeTimeout)); err != nil {
+		return w.logger.ErrorPropagate(fmt.Sprintf("[%v]: %v", conn.RemoteAddr(), err.Error()))
+	}
+
+	return nil
+}
+
+func (w *Communicator) Acquire(conn *websocket.Conn) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.locks[conn] = &connLock{}
+}
+
+func (w *Communicator) Release(conn *websocket.Conn) {
+	w.mu.Lock()
+	l, ok := w.locks[conn]
+	delete(w.locks, conn)
+	w.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// the writer which has already taken the lock will find the connection released
+	l.mu.Lock()
+	l.released = true
+	l.mu.Unlock()
+}
+
+// write will send the message with the write deadline, thus the stalled client cannot block the stream forever.
+// Only the text messages are compressed (if the compression was negotiated), because the media chunks
+// are already compressed by codecs and deflate of them is a waste of CPU.
+func (w *Communicator) write(conn *websocket.Conn, messageType int, data []byte) error {
+	w.mu.Lock()
+	l, ok := w.locks[conn]
+	w.mu.Unlock()
+	if !ok {
+		return ConnectionIsReleasedError
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ConnectionIsReleasedError
+	}
+
+	if err := conn.SetWriteDeadline(time.Now().Add(w.writeTimeout)); err != nil {
+		return err
+	}
+
+	conn.EnableWriteCompression(w.compression && messageType == websocket.TextMessage)
+
+	return conn.WriteMessage(messageType, data)
+}
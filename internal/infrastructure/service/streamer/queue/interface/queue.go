@@ -0,0 +1,30 @@
+package queueinterface
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	"github.com/gorilla/websocket"
+)
+
+type Queues interface {
+	// Start makes a new queue of the connection from the given videos, starting from the item by index
+	// (the previous queue of the connection is replaced).
+	Start(conn *websocket.Conn, videoIDs []vo.ID, index int, shuffle bool, repeat enum.RepeatMode) Queue
+	// Find returns the current queue of the connection.
+	Find(conn *websocket.Conn) (Queue, bool)
+}
+
+type Queue interface {
+	// Next moves the queue to the following item and returns its index into the playlist, video ID and a context
+	// which is canceled when the item is skipped by the user. False is returned when the queue is over.
+	Next(ctx context.Context) (index int, videoID vo.ID, itemCtx context.Context, ok bool)
+	// Skip will interrupt the current item, thus the next (or previous when backward) item is played.
+	Skip(backward bool)
+	// Shuffle will change the order of the remaining items (the current item is not interrupted).
+	Shuffle(enabled bool)
+	// Repeat will change the repeat mode of the queue.
+	Repeat(mode enum.RepeatMode)
+	// Close will interrupt the current item and release the queue of the connection (Next will return false).
+	Close()
+}
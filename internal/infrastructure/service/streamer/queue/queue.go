@@ -0,0 +1,233 @@
+package queue
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/enum"
+	queueinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/queue/interface"
+	"github.com/gorilla/websocket"
+	"math/rand"
+	"sync"
+)
+
+// PlaybackQueues keeps the current playlist queue of each connection, thus the controls of the client
+// (which are received by the listener) reach the playlist which is streamed by the handler.
+type PlaybackQueues struct {
+	logger loggerinterface.Logger
+
+	mu     *sync.Mutex
+	queues map[*websocket.Conn]*queue
+}
+
+func NewPlaybackQueues(serviceContainer diinterface.ServiceContainer) (*PlaybackQueues, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlaybackQueues{
+		logger: loggerService,
+		mu:     &sync.Mutex{},
+		queues: make(map[*websocket.Conn]*queue),
+	}, nil
+}
+
+func (s *PlaybackQueues) Start(
+	conn *websocket.Conn,
+	videoIDs []vo.ID,
+	index int,
+	shuffle bool,
+	repeat enum.RepeatMode,
+) queueinterface.Queue {
+	q := newQueue(videoIDs, index, shuffle, repeat)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prev, ok := s.queues[conn]; ok {
+		prev.stop()
+	}
+	s.queues[conn] = q
+	q.release = func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.queues[conn] == q {
+			delete(s.queues, conn)
+		}
+	}
+
+	return q
+}
+
+func (s *PlaybackQueues) Find(conn *websocket.Conn) (queueinterface.Queue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.queues[conn]
+	return q, ok
+}
+
+type queue struct {
+	mu       *sync.Mutex
+	videoIDs []vo.ID
+	order    []int // play order of the items (indexes of videoIDs)
+	pos      int   // position of the current item into the order (-1 before the first item)
+	start    int   // position of the first item into the order
+	step     int   // step of the next move which is requested by the user
+	stepped  bool  // the step was requested by the user
+	repeat   enum.RepeatMode
+	closed   bool
+	cancel   context.CancelFunc // cancels the current item
+	release  func()
+}
+
+func newQueue(videoIDs []vo.ID, index int, shuffle bool, repeat enum.RepeatMode) *queue {
+	if index < 0 || index >= len(videoIDs) {
+		index = 0
+	}
+
+	q := &queue{
+		mu:       &sync.Mutex{},
+		videoIDs: videoIDs,
+		order:    make([]int, len(videoIDs)),
+		pos:      -1,
+		start:    index,
+		repeat:   repeat,
+		release:  func() {},
+	}
+	for i := range q.order {
+		q.order[i] = i
+	}
+	if shuffle {
+		q.shuffle(index)
+		q.start = 0
+	}
+
+	return q
+}
+
+func (q *queue) Next(ctx context.Context) (index int, videoID vo.ID, itemCtx context.Context, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || len(q.order) == 0 {
+		return 0, vo.ID{}, nil, false
+	}
+
+	if q.pos < 0 {
+		q.pos = q.start
+	} else {
+		step := 1
+		if q.stepped {
+			step = q.step
+			q.stepped = false
+		} else if q.repeat == enum.RepeatOne {
+			step = 0
+		}
+
+		pos := q.pos + step
+		if pos >= len(q.order) {
+			if q.repeat == enum.RepeatNone {
+				return 0, vo.ID{}, nil, false
+			}
+			pos = 0
+		}
+		if pos < 0 {
+			// the previous item of the first one is the first one itself (it's just restarted)
+			pos = 0
+		}
+		q.pos = pos
+	}
+
+	if q.cancel != nil {
+		q.cancel()
+	}
+	itemCtx, q.cancel = context.WithCancel(ctx)
+
+	index = q.order[q.pos]
+	return index, q.videoIDs[index], itemCtx, true
+}
+
+func (q *queue) Skip(backward bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.step = 1
+	if backward {
+		q.step = -1
+	}
+	q.stepped = true
+
+	if q.cancel != nil {
+		q.cancel()
+	}
+}
+
+func (q *queue) Shuffle(enabled bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	current := q.start
+	if q.pos >= 0 {
+		current = q.order[q.pos]
+	} else if len(q.order) > 0 {
+		current = q.order[q.start]
+	}
+
+	if enabled {
+		q.shuffle(current)
+		q.pos, q.start = min(q.pos, 0), 0
+		return
+	}
+
+	// the original order is restored, the current item keeps its place
+	for i := range q.order {
+		q.order[i] = i
+	}
+	if q.pos >= 0 {
+		q.pos = current
+	} else {
+		q.start = current
+	}
+}
+
+func (q *queue) Repeat(mode enum.RepeatMode) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.repeat = mode
+}
+
+func (q *queue) Close() {
+	q.stop()
+	q.release()
+}
+
+// stop will interrupt the current item, and the queue will not return items anymore.
+func (q *queue) stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	if q.cancel != nil {
+		q.cancel()
+	}
+}
+
+// shuffle will randomize the order of the items, the given item is placed first (it's current or the first one).
+func (q *queue) shuffle(first int) {
+	for i := range q.order {
+		q.order[i] = i
+	}
+	rand.Shuffle(len(q.order), func(i, j int) {
+		q.order[i], q.order[j] = q.order[j], q.order[i]
+	})
+	for i, index := range q.order {
+		if index == first {
+			q.order[0], q.order[i] = q.order[i], q.order[0]
+			break
+		}
+	}
+}
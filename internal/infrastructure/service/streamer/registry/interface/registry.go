@@ -0,0 +1,23 @@
+package registryinterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/model"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"github.com/gorilla/websocket"
+)
+
+type Registry interface {
+	// Register adds the connection of the user. The unregister func must be called when the connection is closed.
+	Register(conn *websocket.Conn, userID vo.ID) (unregister func())
+	// Stream marks the connection as streaming the video through the flow.
+	// The finish func must be called when the stream is finished.
+	Stream(conn *websocket.Conn, videoID vo.ID, flow shaperinterface.Flow) (finish func())
+	// IsDraining tells whether the server is going away, thus new connections and actions must not be accepted.
+	IsDraining() bool
+	// Drain will wait for the active streams during the grace period, then notify the clients with resume positions
+	// of interrupted streams and close all connections with the going away code.
+	Drain()
+	// Connections returns snapshots of the registered connections.
+	Connections() []model.Connection
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Connection is a snapshot of the registered websocket connection.
+type Connection struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"userID"`
+	RemoteAddr  string    `json:"remoteAddr"`
+	ConnectedAt time.Time `json:"connectedAt"`
+	Stream      *Stream   `json:"stream,omitempty"`
+}
+
+// Stream is a snapshot of the active stream of the connection.
+type Stream struct {
+	VideoID   string    `json:"videoID"`
+	StartedAt time.Time `json:"startedAt"`
+	Position  float64   `json:"position"` // playhead of the player in seconds
+}
@@ -0,0 +1,272 @@
+package registry
+
+import (
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/model"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// drainCheckInterval is an interval of checking the active streams while draining.
+	drainCheckInterval = 100 * time.Millisecond
+	// goingAwayReason is a reason of the close frame which is sent while draining.
+	goingAwayReason = "server is going away"
+)
+
+type entry struct {
+	id          string
+	conn        *websocket.Conn
+	userID      vo.ID
+	connectedAt time.Time
+	stream      *stream
+}
+
+type stream struct {
+	videoID   vo.ID
+	flow      shaperinterface.Flow
+	startedAt time.Time
+}
+
+// ConnectionRegistry keeps the connections of the instance (hijacked connections are not tracked by http.Server),
+// thus they can be drained on shutdown and inspected by administrators.
+type ConnectionRegistry struct {
+	logger       loggerinterface.Logger
+	communicator protointerface.Communicator
+	shaper       shaperinterface.Shaper
+	gracePeriod  time.Duration
+	writeTimeout time.Duration
+
+	draining *atomic.Bool
+	mu       *sync.Mutex
+	entries  map[*websocket.Conn]*entry
+}
+
+func NewConnectionRegistry(serviceContainer diinterface.ServiceContainer) (*ConnectionRegistry, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	streamShaper, err := serviceContainer.GetStreamShaperService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	gracePeriod, err := time.ParseDuration(cfg.StreamingDrainGracePeriod)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	writeTimeout, err := time.ParseDuration(cfg.StreamingWriteTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	return &ConnectionRegistry{
+		logger:       loggerService,
+		communicator: webSocketCommunicator,
+		shaper:       streamShaper,
+		gracePeriod:  gracePeriod,
+		writeTimeout: writeTimeout,
+		draining:     &atomic.Bool{},
+		mu:           &sync.Mutex{},
+		entries:      make(map[*websocket.Conn]*entry),
+	}, nil
+}
+
+func (r *ConnectionRegistry) Register(conn *websocket.Conn, userID vo.ID) (unregister func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[conn] = &entry{
+		id:          primitive.NewObjectID().Hex(),
+		conn:        conn,
+		userID:      userID,
+		connectedAt: time.Now(),
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.entries, conn)
+	}
+}
+
+func (r *ConnectionRegistry) Stream(conn *websocket.Conn, videoID vo.ID, flow shaperinterface.Flow) (finish func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[conn]
+	if !ok {
+		return func() {}
+	}
+
+	s := &stream{videoID: videoID, flow: flow, startedAt: time.Now()}
+	e.stream = s
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if e.stream == s {
+			e.stream = nil
+		}
+	}
+}
+
+func (r *ConnectionRegistry) IsDraining() bool {
+	return r.draining.Load()
+}
+
+func (r *ConnectionRegistry) Drain() {
+	r.draining.Store(true)
+
+	r.logger.Info(fmt.Sprintf("draining %d connections (grace period %v)...", r.count(), r.gracePeriod))
+
+	// the active streams may be finished during the grace period
+	if !r.await(r.gracePeriod) {
+		// the remaining streams are interrupted, the positions are captured before, thus the clients can resume them
+		positions := r.positions()
+		for conn := range positions {
+			r.shaper.Stop(conn)
+		}
+		// the interrupted stream is finished after the current chunk is written
+		if !r.await(r.writeTimeout) {
+			r.logger.Error("some streams were not finished in time while draining")
+		}
+
+		r.goAway(positions)
+	} else {
+		r.goAway(nil)
+	}
+
+	r.logger.Info("connections are drained")
+}
+
+func (r *ConnectionRegistry) Connections() []model.Connection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	connections := make([]model.Connection, 0, len(r.entries))
+	for _, e := range r.entries {
+		c := model.Connection{
+			ID:          e.id,
+			UserID:      e.userID.Value.Hex(),
+			RemoteAddr:  e.conn.RemoteAddr().String(),
+			ConnectedAt: e.connectedAt,
+		}
+		if e.stream != nil {
+			c.Stream = &model.Stream{
+				VideoID:   e.stream.videoID.Value.Hex(),
+				StartedAt: e.stream.startedAt,
+				Position:  e.stream.flow.Position(),
+			}
+		}
+		connections = append(connections, c)
+	}
+
+	sort.Slice(connections, func(i, j int) bool {
+		return connections[i].ConnectedAt.Before(connections[j].ConnectedAt)
+	})
+
+	return connections
+}
+
+// await will wait until there are no active streams, returns false if the timeout is reached.
+func (r *ConnectionRegistry) await(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for r.streams() > 0 {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(drainCheckInterval)
+	}
+	return true
+}
+
+// goAway will notify all clients and close the connections with the going away code.
+// The positions are resume positions of the interrupted streams.
+func (r *ConnectionRegistry) goAway(positions map[*websocket.Conn]*model.Stream) {
+	r.mu.Lock()
+	conns := make(map[*websocket.Conn]bool, len(r.entries))
+	for conn, e := range r.entries {
+		conns[conn] = e.stream != nil
+	}
+	r.mu.Unlock()
+
+	for conn, isStreaming := range conns {
+		videoID, position := "", float64(0)
+		if s, ok := positions[conn]; ok {
+			videoID, position = s.VideoID, s.Position
+		}
+
+		// the message cannot be written concurrently with the stalled stream (the close frame can)
+		if !isStreaming {
+			if err := r.communicator.GoAway(videoID, position, conn); err != nil {
+				r.logger.Error(err)
+			}
+		}
+		if err := r.communicator.Close(websocket.CloseGoingAway, goingAwayReason, conn); err != nil {
+			r.logger.Error(err)
+		}
+		// the listener is stopped by the closed connection and unwinds the streamer
+		if err := conn.Close(); err != nil {
+			r.logger.Error(err)
+		}
+	}
+}
+
+func (r *ConnectionRegistry) positions() map[*websocket.Conn]*model.Stream {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	positions := make(map[*websocket.Conn]*model.Stream)
+	for conn, e := range r.entries {
+		if e.stream != nil {
+			positions[conn] = &model.Stream{
+				VideoID:   e.stream.videoID.Value.Hex(),
+				StartedAt: e.stream.startedAt,
+				Position:  e.stream.flow.Position(),
+			}
+		}
+	}
+	return positions
+}
+
+func (r *ConnectionRegistry) streams() (n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range r.entries {
+		if e.stream != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *ConnectionRegistry) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.entries)
+}
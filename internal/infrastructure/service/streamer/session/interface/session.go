@@ -0,0 +1,21 @@
+package sessioninterface
+
+import (
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+)
+
+type Sessions interface {
+	// Start issues a new playback session of the video by the user (the owner of the video differs from the user
+	// when the member of a watch party streams the video of the host).
+	Start(userID vo.ID, ownerID vo.ID, videoID vo.ID) (*agg.PlaybackSession, error)
+	// Find returns the unexpired playback session of the user.
+	Find(userID vo.ID, sessionID string) (*agg.PlaybackSession, error)
+	// Track will save the position of the flow into the session and the watch progress of the user periodically
+	// until the returned func is called (the final position is saved as well). The bitrate (bytes per second)
+	// is used for compute the byte offset, the duration (in seconds) is used for determine the video is completed.
+	Track(session *agg.PlaybackSession, flow shaperinterface.Flow, bytesPerSecond float64, duration float64) (stop func())
+	// Progress returns the watch progress of the video by the user.
+	Progress(userID vo.ID, videoID vo.ID) (*agg.WatchProgress, error)
+}
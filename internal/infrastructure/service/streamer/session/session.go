@@ -0,0 +1,201 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"github.com/Borislavv/video-streaming/internal/domain/agg"
+	"github.com/Borislavv/video-streaming/internal/domain/entity"
+	"github.com/Borislavv/video-streaming/internal/domain/errtype"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sync"
+	"time"
+)
+
+// PlaybackSessions stores the positions of streams in the storage, thus a stream can be resumed
+// after reconnect (even to another instance). The position is stored into the watch progress of the user as well.
+type PlaybackSessions struct {
+	ctx                context.Context
+	logger             loggerinterface.Logger
+	repository         repositoryinterface.PlaybackSession
+	progressRepository repositoryinterface.WatchProgress
+	ttl                time.Duration
+	saveInterval       time.Duration
+	completedThreshold float64
+}
+
+func NewPlaybackSessions(serviceContainer diinterface.ServiceContainer) (*PlaybackSessions, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := serviceContainer.GetCtx()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	playbackSessionRepository, err := serviceContainer.GetPlaybackSessionRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchProgressRepository, err := serviceContainer.GetWatchProgressRepository()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	ttl, err := time.ParseDuration(cfg.StreamingSessionTTL)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	saveInterval, err := time.ParseDuration(cfg.StreamingSessionSaveInterval)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	if cfg.StreamingCompletedThreshold <= 0 || cfg.StreamingCompletedThreshold > 1 {
+		return nil, loggerService.LogPropagate(
+			fmt.Errorf("completed threshold '%v' must be in range (0, 1]", cfg.StreamingCompletedThreshold),
+		)
+	}
+
+	return &PlaybackSessions{
+		ctx:                ctx,
+		logger:             loggerService,
+		repository:         playbackSessionRepository,
+		progressRepository: watchProgressRepository,
+		ttl:                ttl,
+		saveInterval:       saveInterval,
+		completedThreshold: cfg.StreamingCompletedThreshold,
+	}, nil
+}
+
+func (s *PlaybackSessions) Start(userID vo.ID, ownerID vo.ID, videoID vo.ID) (*agg.PlaybackSession, error) {
+	session, err := s.repository.Insert(s.ctx, &agg.PlaybackSession{
+		PlaybackSession: entity.PlaybackSession{
+			UserID:    userID,
+			OwnerID:   ownerID,
+			VideoID:   videoID,
+			ExpiresAt: time.Now().Add(s.ttl),
+		},
+		Timestamp: vo.Timestamp{
+			CreatedAt: time.Now(),
+		},
+	})
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return session, nil
+}
+
+func (s *PlaybackSessions) Find(userID vo.ID, sessionID string) (*agg.PlaybackSession, error) {
+	oid, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return nil, s.logger.LogPropagate(errtype.NewEntityNotFoundError("playback session", "id"))
+	}
+
+	session, err := s.repository.FindOneByID(s.ctx, vo.NewID(oid), userID)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return session, nil
+}
+
+func (s *PlaybackSessions) Track(
+	session *agg.PlaybackSession,
+	flow shaperinterface.Flow,
+	bytesPerSecond float64,
+	duration float64,
+) (stop func()) {
+	stopCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(s.saveInterval)
+		defer ticker.Stop()
+
+		saved := session.Position
+		for {
+			select {
+			case <-stopCh:
+				// the final position is saved on stop (including the closed connection)
+				s.save(session, flow.Position(), bytesPerSecond, duration)
+				return
+			case <-ticker.C:
+				// the paused player does not change the position, nothing to save
+				if position := flow.Position(); position != saved {
+					s.save(session, position, bytesPerSecond, duration)
+					saved = position
+				}
+			}
+		}
+	}()
+
+	once := &sync.Once{}
+	return func() {
+		once.Do(func() {
+			close(stopCh)
+			wg.Wait()
+		})
+	}
+}
+
+// Progress returns the watch progress of the video by the user.
+func (s *PlaybackSessions) Progress(userID vo.ID, videoID vo.ID) (*agg.WatchProgress, error) {
+	progress, err := s.progressRepository.FindOneByVideo(s.ctx, userID, videoID)
+	if err != nil {
+		return nil, s.logger.LogPropagate(err)
+	}
+
+	return progress, nil
+}
+
+// save will store the position of the session and the watch progress of the user,
+// errors are logged only (the stream must not be interrupted by them).
+func (s *PlaybackSessions) save(session *agg.PlaybackSession, position float64, bytesPerSecond float64, duration float64) {
+	offset := int64(position * bytesPerSecond)
+
+	// the context of the app may be already canceled on shutdown, the final position must be saved anyway
+	ctx, cancel := context.WithTimeout(context.Background(), s.saveInterval)
+	defer cancel()
+
+	if err := s.repository.UpdatePosition(ctx, session.ID, position, offset, time.Now().Add(s.ttl)); err != nil {
+		s.logger.Error(err)
+		return
+	}
+
+	session.Position = position
+	session.Offset = offset
+
+	progress := &agg.WatchProgress{
+		WatchProgress: entity.WatchProgress{
+			UserID:    session.UserID,
+			VideoID:   session.VideoID,
+			Position:  position,
+			Duration:  duration,
+			Completed: duration > 0 && position >= duration*s.completedThreshold,
+		},
+		Timestamp: vo.Timestamp{
+			UpdatedAt: time.Now(),
+		},
+	}
+	if err := s.progressRepository.Save(ctx, progress); err != nil {
+		s.logger.Error(err)
+	}
+}
@@ -0,0 +1,159 @@
+package shaper
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxDelay is a max. delay before the next check of the flow (the estimated delay may be wrong
+// when the player has paused or seeked).
+const maxDelay = time.Second
+
+// flow is a token bucket which is filled with the sending rate (the media bitrate multiplied by the rate factor)
+// and has capacity of the lead buffer. Additionally, the sent bytes are capped by the playhead position plus
+// the lead buffer, thus the media is not sent far ahead of what the player has actually consumed.
+type flow struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	release func()
+
+	bytesPerSecond float64 // media bitrate
+	rate           float64 // sending rate (bytes per second)
+	lead           float64 // lead buffer (seconds)
+	from           float64 // position of the media where the stream starts (seconds)
+	startedAt      time.Time
+
+	mu       *sync.Mutex
+	tokens   float64
+	filledAt time.Time
+	sent     float64
+	acked    bool
+	position float64
+	ackCh    chan struct{}
+}
+
+func newFlow(ctx context.Context, bytesPerSecond float64, rateFactor float64, lead float64, from float64) *flow {
+	ctx, cancel := context.WithCancel(ctx)
+	now := time.Now()
+
+	return &flow{
+		ctx:            ctx,
+		cancel:         cancel,
+		release:        func() {},
+		bytesPerSecond: bytesPerSecond,
+		rate:           bytesPerSecond * rateFactor,
+		lead:           lead,
+		from:           from,
+		startedAt:      now,
+		mu:             &sync.Mutex{},
+		tokens:         bytesPerSecond * lead, // the lead buffer is sent immediately
+		filledAt:       now,
+		ackCh:          make(chan struct{}, 1),
+	}
+}
+
+func (f *flow) Wait(n int) error {
+	// the bitrate is unknown, thus the stream cannot be shaped
+	if f.bytesPerSecond <= 0 {
+		return f.ctx.Err()
+	}
+
+	for {
+		delay, ok := f.take(float64(n))
+		if ok {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-f.ctx.Done():
+			timer.Stop()
+			return f.ctx.Err()
+		case <-f.ackCh:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (f *flow) Close() {
+	f.cancel()
+	f.release()
+}
+
+// take will spend the tokens for n bytes if they're available and the lead is not exceeded,
+// otherwise returns the delay before the next try.
+func (f *flow) take(n float64) (delay time.Duration, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+
+	// the capacity must fit at least one chunk
+	capacity := math.Max(f.bytesPerSecond*f.lead, n)
+	f.tokens = math.Min(capacity, f.tokens+now.Sub(f.filledAt).Seconds()*f.rate)
+	f.filledAt = now
+
+	position := f.playhead(now)
+
+	// the first chunk is always allowed, thus the player can start
+	if allowed := (position - f.from + f.lead) * f.bytesPerSecond; f.sent > 0 && f.sent+n > allowed {
+		// the playhead moves by one second per second (the acknowledgement may come earlier)
+		return f.delay((f.sent + n - allowed) / f.bytesPerSecond), false
+	}
+
+	if f.tokens < n {
+		return f.delay((n - f.tokens) / f.rate), false
+	}
+
+	f.tokens -= n
+	f.sent += n
+
+	return 0, true
+}
+
+func (f *flow) Position() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	position := f.playhead(time.Now())
+	if f.bytesPerSecond > 0 {
+		return math.Min(position, f.from+f.sent/f.bytesPerSecond)
+	}
+	if f.acked {
+		return position
+	}
+	// neither the bitrate, nor the playhead are known
+	return f.from
+}
+
+// playhead is estimated by the elapsed time until the first acknowledgement of the client
+// (the acknowledged position cannot be before the start of the stream).
+func (f *flow) playhead(now time.Time) float64 {
+	if f.acked {
+		return math.Max(f.position, f.from)
+	}
+	return f.from + now.Sub(f.startedAt).Seconds()
+}
+
+func (f *flow) ack(position float64) {
+	f.mu.Lock()
+	f.acked = true
+	f.position = position
+	f.mu.Unlock()
+
+	// wake up the waiting stream, the signal is dropped if it's already pending
+	select {
+	case f.ackCh <- struct{}{}:
+	default:
+	}
+}
+
+func (f *flow) delay(seconds float64) time.Duration {
+	if d := time.Duration(seconds * float64(time.Second)); d < maxDelay {
+		return d
+	}
+	return maxDelay
+}
@@ -0,0 +1,26 @@
+package shaperinterface
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+)
+
+type Shaper interface {
+	// Shape makes the flow of the stream through the connection with given bitrate of the media (in bytes per second)
+	// from given position of the media (in seconds). The flow must be closed when the stream is finished.
+	Shape(ctx context.Context, conn *websocket.Conn, bytesPerSecond float64, from float64) Flow
+	// Ack passes the playhead position (in seconds) reported by the client to the current flow of the connection.
+	Ack(conn *websocket.Conn, position float64)
+	// Stop cancels the current flow of the connection (the client is gone, thus nothing should be sent anymore).
+	Stop(conn *websocket.Conn)
+}
+
+type Flow interface {
+	// Wait blocks until n bytes may be sent. Returns an error when the flow is stopped.
+	Wait(n int) error
+	// Position returns the playhead of the player in seconds (acknowledged by the client or estimated),
+	// it is not greater than the position of the media which was already sent.
+	Position() float64
+	// Close releases the flow.
+	Close()
+}
@@ -0,0 +1,100 @@
+package shaper
+
+import (
+	"context"
+	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	shaperinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/shaper/interface"
+	"github.com/gorilla/websocket"
+	"sync"
+	"time"
+)
+
+// StreamShaper keeps the current flow of each connection, thus the acknowledgements of the client
+// (which are received by the listener) reach the stream which is sent by the handler.
+type StreamShaper struct {
+	logger     loggerinterface.Logger
+	leadBuffer time.Duration
+	rateFactor float64
+
+	mu    *sync.Mutex
+	flows map[*websocket.Conn]*flow
+}
+
+func NewStreamShaper(serviceContainer diinterface.ServiceContainer) (*StreamShaper, error) {
+	loggerService, err := serviceContainer.GetLoggerService()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	leadBuffer, err := time.ParseDuration(cfg.StreamingLeadBuffer)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	rateFactor := cfg.StreamingRateFactor
+	if rateFactor < 1 {
+		// the slower sending than the playback will stall the player
+		rateFactor = 1
+	}
+
+	return &StreamShaper{
+		logger:     loggerService,
+		leadBuffer: leadBuffer,
+		rateFactor: rateFactor,
+		mu:         &sync.Mutex{},
+		flows:      make(map[*websocket.Conn]*flow),
+	}, nil
+}
+
+func (s *StreamShaper) Shape(
+	ctx context.Context,
+	conn *websocket.Conn,
+	bytesPerSecond float64,
+	from float64,
+) shaperinterface.Flow {
+	f := newFlow(ctx, bytesPerSecond, s.rateFactor, s.leadBuffer.Seconds(), from)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prev, ok := s.flows[conn]; ok {
+		prev.cancel()
+	}
+	s.flows[conn] = f
+	f.release = func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.flows[conn] == f {
+			delete(s.flows, conn)
+		}
+	}
+
+	return f
+}
+
+func (s *StreamShaper) Ack(conn *websocket.Conn, position float64) {
+	s.mu.Lock()
+	f, ok := s.flows[conn]
+	s.mu.Unlock()
+
+	if ok {
+		f.ack(position)
+	}
+}
+
+func (s *StreamShaper) Stop(conn *websocket.Conn) {
+	s.mu.Lock()
+	f, ok := s.flows[conn]
+	delete(s.flows, conn)
+	s.mu.Unlock()
+
+	if ok {
+		f.cancel()
+	}
+}
@@ -4,16 +4,28 @@ import (
 	"fmt"
 	"github.com/Borislavv/video-streaming/internal/domain/logger/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
+	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	limiterinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/limiter/interface"
 	handlerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/handler/interface"
 	listenerinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/listener/interface"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/action/model"
+	partyinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/party/interface"
+	protointerface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/proto/interface"
+	registryinterface "github.com/Borislavv/video-streaming/internal/infrastructure/service/streamer/registry/interface"
 	"github.com/gorilla/websocket"
 	"sync"
+	"time"
 )
 
 type ResourceStreamer struct {
-	logger   loggerinterface.Logger
-	listener listenerinterface.ActionsListener
-	handler  handlerinterface.ActionsHandler
+	logger       loggerinterface.Logger
+	listener     listenerinterface.ActionsListener
+	handler      handlerinterface.ActionsHandler
+	limiter      limiterinterface.StreamingLimiter
+	communicator protointerface.Communicator
+	registry     registryinterface.Registry
+	parties      partyinterface.Parties
+	idleTimeout  time.Duration
 }
 
 func NewStreamingService(serviceContainer diinterface.ServiceContainer) (*ResourceStreamer, error) {
@@ -32,19 +44,152 @@ func NewStreamingService(serviceContainer diinterface.ServiceContainer) (*Resour
 		return nil, loggerService.LogPropagate(err)
 	}
 
+	streamingLimiter, err := serviceContainer.GetStreamingLimiterService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	webSocketCommunicator, err := serviceContainer.GetWebSocketCommunicatorService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	connectionRegistry, err := serviceContainer.GetConnectionRegistryService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	watchParties, err := serviceContainer.GetWatchPartiesService()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	cfg, err := serviceContainer.GetConfig()
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
+	idleTimeout, err := time.ParseDuration(cfg.StreamingIdleTimeout)
+	if err != nil {
+		return nil, loggerService.LogPropagate(err)
+	}
+
 	return &ResourceStreamer{
-		logger:   loggerService,
-		listener: webSocketListener,
-		handler:  webSocketHandler,
+		logger:       loggerService,
+		listener:     webSocketListener,
+		handler:      webSocketHandler,
+		limiter:      streamingLimiter,
+		communicator: webSocketCommunicator,
+		registry:     connectionRegistry,
+		parties:      watchParties,
+		idleTimeout:  idleTimeout,
 	}, nil
 }
 
-func (s *ResourceStreamer) HandleConn(conn *websocket.Conn) {
+func (s *ResourceStreamer) HandleConn(conn *websocket.Conn, userID vo.ID) {
 	s.logger.Info(fmt.Sprintf("[%v]: start streaming", conn.RemoteAddr()))
 
+	// the messages may be written to the connection until it's released
+	s.communicator.Acquire(conn)
+
 	wg := &sync.WaitGroup{}
-	s.handler.Handle(wg, s.listener.Listen(wg, conn))
+	actionsCh, activityCh := s.listener.Listen(wg, conn, userID)
+	s.handler.Handle(wg, s.dispatch(wg, conn, userID, actionsCh, activityCh))
 	wg.Wait()
 
+	// the gone member leaves its watch party (the host role is passed to another member)
+	s.parties.Leave(conn)
+	s.communicator.Release(conn)
+
 	s.logger.Info(fmt.Sprintf("[%v]: streaming is stopped", conn.RemoteAddr()))
 }
+
+// dispatch will pass the actions to the handler only while the user has a free slot of the stream. The slot is
+// held until the action is handled (actions of one connection are handled sequentially, thus the next action is
+// awaited by the handler anyway). The connection is closed with the policy violation code when the limit is reached,
+// and with the normal closure code when there were no messages of the client and active stream during the idle timeout.
+// The actions are dropped while the server is draining.
+func (s *ResourceStreamer) dispatch(
+	wg *sync.WaitGroup,
+	conn *websocket.Conn,
+	userID vo.ID,
+	actionsCh <-chan model.Action,
+	activityCh <-chan struct{},
+) <-chan model.Action {
+	dispatchedCh := make(chan model.Action)
+
+	wg.Add(1)
+	go func() {
+		defer func() {
+			close(dispatchedCh)
+			wg.Done()
+		}()
+
+		idle := time.NewTimer(s.idleTimeout)
+		defer idle.Stop()
+		resetIdle := func() {
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(s.idleTimeout)
+		}
+
+		for {
+			select {
+			case <-idle.C:
+				s.logger.Info(fmt.Sprintf("[%v]: closing the idle connection", conn.RemoteAddr()))
+				s.close(conn, websocket.CloseNormalClosure, "idle timeout", actionsCh)
+				return
+			case _, ok := <-activityCh:
+				if !ok {
+					// the listener is stopped, the closed actions channel will stop the dispatching
+					activityCh = nil
+					continue
+				}
+				// the party actions, the buffer statuses and the controls are activity too
+				resetIdle()
+			case action, ok := <-actionsCh:
+				if !ok {
+					return
+				}
+
+				// the server is going away, the connection will be closed by the registry
+				if s.registry.IsDraining() {
+					s.logger.Info(fmt.Sprintf("[%v]: action '%v' is dropped while draining", conn.RemoteAddr(), action.Do))
+					continue
+				}
+
+				release, err := s.limiter.AcquireStream(userID)
+				if err != nil {
+					s.close(conn, websocket.ClosePolicyViolation, err.Error(), actionsCh)
+					return
+				}
+
+				done := make(chan struct{})
+				action.Done = func() { close(done) }
+
+				dispatchedCh <- action
+				<-done
+				release()
+
+				// the idle time is counted from the end of the last stream
+				resetIdle()
+			}
+		}
+	}()
+
+	return dispatchedCh
+}
+
+// close will send the close frame and close the connection. The listener will be stopped by the closed connection,
+// its remaining actions must be drained.
+func (s *ResourceStreamer) close(conn *websocket.Conn, code int, reason string, actionsCh <-chan model.Action) {
+	if err := s.communicator.Close(code, reason, conn); err != nil {
+		s.logger.Error(err)
+	}
+	if err := conn.Close(); err != nil {
+		s.logger.Error(err)
+	}
+	for range actionsCh {
+	}
+}
@@ -8,6 +8,7 @@ import (
 	repositoryinterface "github.com/Borislavv/video-streaming/internal/domain/repository/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/service/di/interface"
 	"github.com/Borislavv/video-streaming/internal/domain/vo"
+	"github.com/Borislavv/video-streaming/internal/infrastructure/helper"
 	"github.com/golang-jwt/jwt/v5"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"strings"
@@ -58,12 +59,16 @@ func NewJwtService(serviceContainer diinterface.ServiceContainer) (*JwtService,
 	}, nil
 }
 
-// New will generate a new JWT.
-func (s *JwtService) New(user *agg.User) (token string, err error) {
+// New will generate a new JWT. The amr is a list of methods which were used for authenticate the user,
+// it will be stored into the 'amr' claim along with authentication time ('iat').
+func (s *JwtService) New(user *agg.User, amr ...string) (token string, err error) {
+	now := time.Now()
 	tkn := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub": user.ID.Value.Hex(),
 		"iss": s.jwtTokenIssuer,
-		"exp": &jwt.NumericDate{Time: time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))},
+		"iat": &jwt.NumericDate{Time: now},
+		"exp": &jwt.NumericDate{Time: now.Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))},
+		"amr": amr,
 	})
 
 	if token, err = tkn.SignedString(s.jwtSecretSalt); err != nil {
@@ -76,7 +81,7 @@ func (s *JwtService) New(user *agg.User) (token string, err error) {
 // Verify will decode the token and return a user ID or error, if it was occurred.
 func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
 	// checking that token is not blocked
-	found, err := s.blockedTokenRepository.Has(s.ctx, token)
+	found, err := s.blockedTokenRepository.Has(s.ctx, helper.SHA256([]byte(token)))
 	if err != nil {
 		return vo.ID{}, s.logger.LogPropagate(err)
 	}
@@ -84,18 +89,7 @@ func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
 		return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenWasBlockedError())
 	}
 
-	parsedToken, err := jwt.Parse(token, func(decodedToken *jwt.Token) (interface{}, error) {
-		if decodedToken.Header["alg"] != s.jwtTokenEncryptAlgo {
-			// user must be banned here because the algo wasn't matched
-			return nil, errtype.NewTokenAlgoWasNotMatchedInternalError(token)
-		}
-		// cast to the configured givenToken signature type (stored in `s.jwtTokenEncryptAlgo`)
-		if _, success := decodedToken.Method.(*jwt.SigningMethodHMAC); !success {
-			return nil, errtype.NewTokenUnexpectedSigningMethodInternalError(token, decodedToken.Header["alg"])
-		}
-		// jwtSecretSalt is a string containing your secret, but you need pass the []byte
-		return s.jwtSecretSalt, nil
-	})
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token))
 	if err != nil {
 		// parsing givenToken error occurred
 		s.logger.Log(err)
@@ -117,6 +111,20 @@ func (s *JwtService) Verify(token string) (userID vo.ID, err error) {
 			return vo.ID{}, s.logger.LogPropagate(err)
 		}
 
+		// checking that all tokens of the user were not revoked after the token was issued
+		// (tokens without 'iat' claim are considered as issued at the beginning of time)
+		issuedAt := time.Time{}
+		if iat, ierr := claims.GetIssuedAt(); ierr == nil && iat != nil {
+			issuedAt = iat.Time
+		}
+		revoked, err := s.blockedTokenRepository.HasRevocation(s.ctx, userID, issuedAt)
+		if err != nil {
+			return vo.ID{}, s.logger.LogPropagate(err)
+		}
+		if revoked {
+			return vo.ID{}, s.logger.LogPropagate(errtype.NewAccessTokenWasBlockedError())
+		}
+
 		return userID, nil
 	} else {
 		// error occurred while extracting claims from givenToken or givenToken is not valid
@@ -136,25 +144,40 @@ func (s *JwtService) Block(token string, reason string) error {
 		s.logger.Log(err)
 	}
 
-	if err = s.blockedTokenRepository.Insert(s.ctx, agg.NewBlockedToken(token, reason, userID)); err != nil {
+	blockedToken := agg.NewBlockedToken(helper.SHA256([]byte(token)), reason, userID, s.expiresAt(token))
+	if err = s.blockedTokenRepository.Insert(s.ctx, blockedToken); err != nil {
 		return s.logger.LogPropagate(err)
 	}
 	return nil
 }
 
-func (s *JwtService) parseUserID(token string) (userID vo.ID, err error) {
-	parsedToken, err := jwt.Parse(token, func(decodedToken *jwt.Token) (interface{}, error) {
-		if decodedToken.Header["alg"] != s.jwtTokenEncryptAlgo {
-			// user must be banned here because the algo wasn't matched
-			return nil, errtype.NewTokenAlgoWasNotMatchedInternalError(token)
-		}
-		// cast to the configured givenToken signature type (stored in `s.jwtTokenEncryptAlgo`)
-		if _, success := decodedToken.Method.(*jwt.SigningMethodHMAC); !success {
-			return nil, errtype.NewTokenUnexpectedSigningMethodInternalError(token, decodedToken.Header["alg"])
+// RevokeAll will block all tokens of the user which were issued before this moment.
+func (s *JwtService) RevokeAll(userID vo.ID, reason string) error {
+	// the revocation is kept while the latest issued token is alive
+	expiresAt := time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))
+
+	if err := s.blockedTokenRepository.Insert(s.ctx, agg.NewUserTokensRevocation(userID, reason, expiresAt)); err != nil {
+		return s.logger.LogPropagate(err)
+	}
+	return nil
+}
+
+// expiresAt returns the expiration time of the token. The signature is not verified because the invalid tokens
+// are blocked as well, thus the claim is limited by the max. lifetime of token (which is used if it's absent).
+func (s *JwtService) expiresAt(token string) time.Time {
+	maxExpiresAt := time.Now().Add(time.Second * time.Duration(s.jwtTokenExpiresAfter))
+
+	parsedToken, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err == nil {
+		if exp, eerr := parsedToken.Claims.GetExpirationTime(); eerr == nil && exp != nil && exp.Before(maxExpiresAt) {
+			return exp.Time
 		}
-		// jwtSecretSalt is a string containing your secret, but you need pass the []byte
-		return s.jwtSecretSalt, nil
-	})
+	}
+	return maxExpiresAt
+}
+
+func (s *JwtService) parseUserID(token string) (userID vo.ID, err error) {
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token))
 	if err != nil {
 		// parsing givenToken error occurred
 		s.logger.Log(err)
@@ -173,6 +196,54 @@ func (s *JwtService) parseUserID(token string) (userID vo.ID, err error) {
 	}
 }
 
+// AuthMethods will decode the token and return the authentication methods and time of the authentication.
+// The token must be verified previously, tokens issued without 'iat' claim will have a zero authedAt.
+func (s *JwtService) AuthMethods(token string) (amr []string, authedAt time.Time, err error) {
+	parsedToken, err := jwt.Parse(token, s.keyFunc(token))
+	if err != nil {
+		// parsing givenToken error occurred
+		s.logger.Log(err)
+		// return a token invalid error
+		return nil, time.Time{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+	}
+
+	claims, success := parsedToken.Claims.(jwt.MapClaims)
+	if !success {
+		return nil, time.Time{}, s.logger.LogPropagate(errtype.NewAccessTokenIsInvalidError())
+	}
+
+	if iat, ierr := claims.GetIssuedAt(); ierr == nil && iat != nil {
+		authedAt = iat.Time
+	}
+
+	// the claim is decoded as a slice of interfaces
+	if methods, ok := claims["amr"].([]interface{}); ok {
+		for _, method := range methods {
+			if m, isStr := method.(string); isStr {
+				amr = append(amr, m)
+			}
+		}
+	}
+
+	return amr, authedAt, nil
+}
+
+// keyFunc will check the token header and return the secret for verify its signature.
+func (s *JwtService) keyFunc(token string) jwt.Keyfunc {
+	return func(decodedToken *jwt.Token) (interface{}, error) {
+		if decodedToken.Header["alg"] != s.jwtTokenEncryptAlgo {
+			// user must be banned here because the algo wasn't matched
+			return nil, errtype.NewTokenAlgoWasNotMatchedInternalError(token)
+		}
+		// cast to the configured givenToken signature type (stored in `s.jwtTokenEncryptAlgo`)
+		if _, success := decodedToken.Method.(*jwt.SigningMethodHMAC); !success {
+			return nil, errtype.NewTokenUnexpectedSigningMethodInternalError(token, decodedToken.Header["alg"])
+		}
+		// jwtSecretSalt is a string containing your secret, but you need pass the []byte
+		return s.jwtSecretSalt, nil
+	}
+}
+
 func (s *JwtService) isValidIssuer(token string, claims jwt.Claims) error {
 	// extracting the token issuer
 	iss, err := claims.GetIssuer()
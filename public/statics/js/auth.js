@@ -10,6 +10,11 @@ document.getElementById('main-form').addEventListener('submit', function (event)
         password: password
     };
 
+    const code = document.getElementById('code');
+    if (formTitle === "Login" && code) {
+        userDetails.code = code.value;
+    }
+
     if (formTitle === "Registration") {
         userDetails.username = document.getElementById('username').value;
         userDetails.birthday = document.getElementById('birthday').value;
@@ -65,8 +70,23 @@ function clearErrorMessage() {
     errorClose.style.display = 'none';
 }
 
+function showCodeField() {
+    if (document.getElementById('code')) {
+        return;
+    }
+    document.getElementById('extra-fields').innerHTML = `
+        <label for="code">One-time password or recovery code:</label>
+        <input type="text" id="code" name="code" autocomplete="one-time-code" required>
+    `;
+}
+
 function handleResponse(data) {
-    if (data.error) {
+    if (data.error && data.error.type === "second_factor") {
+        // the second factor is enabled, the form must be submitted again with a code
+        showCodeField();
+        showErrorMessage(data.error.message);
+        setTimeout(clearErrorMessage, 5000);
+    } else if (data.error) {
         showErrorMessage(data.error.message || "A server error occurred");
         setTimeout(clearErrorMessage, 5000); // Автоматическое скрытие сообщения об ошибке через 5 секунд
     } else {
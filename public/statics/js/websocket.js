@@ -1,37 +1,90 @@
-const websocket = new WebSocket('ws://0.0.0.0:9988/');
+// the access token is passed through the subprotocols, because browsers cannot set headers of the handshake
+const token = getCookie('x-access-token');
+if (!token) {
+    throw "token is not provided";
+}
+const websocketURL = 'ws://0.0.0.0:9988/';
+// close codes which mean that reconnect is useless (normal closure and policy violation)
+const finalCloseCodes = [1000, 1008];
+const reconnectDelay = 1000;
+
+let websocket;
+// the playback session is kept in the session storage, thus the stream can be resumed after reconnect
+let sessionID = sessionStorage.getItem('playback-session-id') || '';
+// the position of the media where the current stream starts (the player timeline starts from zero)
+let positionBase = 0;
 
 const videoPlayer = document.getElementById('videoPlayer');
 const nextBtn = document.getElementById('next-btn');
 const prevBtn = document.getElementById('prev-btn');
-
-websocket.binaryType = 'arraybuffer';
+const liveBtn = document.getElementById('live-btn');
+const partyBtn = document.getElementById('party-btn');
 
 let buffer;
 let mediaSource;
 let chunks;
 let mediaSourceReady;
-let token;
 
-// ws event: open
-websocket.onopen = (event) => {
-    console.log('WebSocket connection opened');
+// the playlist is played when its ID is passed through the page query (?playlist=<id>&shuffle=1&repeat=all|one)
+const pageQuery = new URLSearchParams(window.location.search);
+const playlistID = pageQuery.get('playlist') || '';
+// the next item of the playlist is received while the current one is still playing, thus it's held
+// until the end of the current one (gapless transition)
+let pending = null;
+// the current stream is finished by the server (the next received stream is the next item of the playlist)
+let streamStopped = false;
+// the next received stream is requested by the user, thus it must be played immediately
+let switchOnStart = true;
+
+// the live broadcast is watched when its ID is passed through the page query (?live=<id>)
+const liveID = pageQuery.get('live') || '';
+// the camera is recorded by chunks which are sent to the server as binary messages
+const liveTimeslice = 1000;
+let liveRecorder = null;
+let liveStream = null;
+
+// the watch party is joined when its invite token is passed through the page query (?party=<token>)
+const partyToken = pageQuery.get('party') || '';
+// the current watch party (the last received party message), the host broadcasts its playback state
+let party = null;
+
+function connect(resume) {
+    websocket = new WebSocket(websocketURL, ['access-token', token]);
+    websocket.binaryType = 'arraybuffer';
+
+    // ws event: open
+    websocket.onopen = (event) => {
+        console.log('WebSocket connection opened');
+
+        if (resume && sessionID !== '') {
+            console.log('Resuming the playback session ' + sessionID)
+            websocket.send(`RESUME_SESSION::{ "sessionID": "${sessionID}" }`)
+        } else if (!resume && playlistID !== '') {
+            requestPlaylist(playlistID)
+        } else if (liveID !== '') {
+            requestLive(liveID)
+        }
+        if (partyToken !== '') {
+            websocket.send(`PARTY_JOIN::{ "token": "${partyToken}" }`)
+        }
+    };
+    // ws event: close
+    websocket.onclose = (event) => {
+        console.log('WebSocket connection closed', event.code, event.reason);
 
-    token = getCookie('x-access-token')
-    if (token === "") {
-        throw "token is not provided";
-    }
-};
-// ws event: close
-websocket.onclose = (event) => {
-    console.log('WebSocket connection closed');
-};
-// ws event: error
-websocket.onerror = (event) => {
-    console.error('WebSocket error: ', event);
-};
-
-// ws event: on message
-websocket.onmessage = (event) => {
+        if (!finalCloseCodes.includes(event.code)) {
+            setTimeout(() => connect(true), reconnectDelay)
+        }
+    };
+    // ws event: error
+    websocket.onerror = (event) => {
+        console.error('WebSocket error: ', event);
+    };
+    // ws event: on message
+    websocket.onmessage = onMessage;
+}
+
+function onMessage(event) {
     const data = event.data;
 
     console.log("Some data received...", data)
@@ -39,7 +92,11 @@ websocket.onmessage = (event) => {
     if (typeof data === 'string' && (
         data.startsWith('start') ||
         data.startsWith('error') ||
-        data.startsWith('stop')
+        data.startsWith('stop') ||
+        data.startsWith('item') ||
+        data.startsWith('live') ||
+        data.startsWith('party') ||
+        data.startsWith('goaway')
     )) {
         console.log('Data is action: ' + data)
 
@@ -47,14 +104,48 @@ websocket.onmessage = (event) => {
             console.log("Starting new video...")
             let dataParts = data.split('::')
             console.log(dataParts)
-            makeMediaResource(dataParts[1], dataParts[2])
+            if (!switchOnStart && streamStopped && !videoPlayer.ended) {
+                console.log("Holding the next item until the end of the current one...")
+                pending = {dataParts: dataParts, chunks: [], stopped: false}
+                return;
+            }
+            switchOnStart = false
+            streamStopped = false
+            startMediaResource(dataParts)
         } else if (data.startsWith('error')) {
             let dataParts = data.split('::')
             console.log("Server error occurred: " + dataParts[1])
             showAlert(dataParts[1])
         } else if (data === 'stop') {
+            if (pending !== null) {
+                pending.stopped = true
+                return;
+            }
             console.log("Stopping playing...")
+            streamStopped = true
             closeMediaResource()
+        } else if (data.startsWith('live')) {
+            let dataParts = data.split('::')
+            if (liveRecorder !== null) {
+                // the broadcast is published, thus the recording may be sent
+                console.log("Broadcast is published: " + dataParts[1])
+                showAlert(`You are live: ${window.location.origin}${window.location.pathname}?live=${dataParts[1]}`)
+                liveRecorder.start(liveTimeslice)
+            } else {
+                console.log("Watching the broadcast " + dataParts[1] + " of type " + dataParts[2])
+                makeMediaResource('', '', dataParts.slice(2).join('::'))
+            }
+        } else if (data.startsWith('party')) {
+            onPartyMessage(JSON.parse(data.substring('party::'.length)))
+        } else if (data.startsWith('item')) {
+            // the item of the playlist which is streamed (index and video ID)
+            let dataParts = data.split('::')
+            console.log("Playlist item: ", dataParts[1], dataParts[2])
+            currentVideoID = dataParts[2]
+        } else if (data.startsWith('goaway')) {
+            // the server is going away, the interrupted stream will be resumed after reconnect
+            let dataParts = data.split('::')
+            console.log("Server is going away, resume position: ", dataParts[1], dataParts[2])
         }
 
         return;
@@ -62,10 +153,45 @@ websocket.onmessage = (event) => {
 
     if (data instanceof ArrayBuffer) {
         console.log('Data is chunk, adding to buffer...')
+        if (pending !== null) {
+            pending.chunks.push(data)
+            return;
+        }
         chunks.push(data)
         addNextChunk()
     }
-};
+}
+
+connect(false);
+
+function startMediaResource(dataParts) {
+    sessionID = dataParts[3] || ''
+    sessionStorage.setItem('playback-session-id', sessionID)
+    positionBase = parseFloat(dataParts[4]) || 0
+    makeMediaResource(dataParts[1], dataParts[2])
+}
+
+// the held item of the playlist is played right after the end of the current one
+videoPlayer.addEventListener('ended', function () {
+    if (pending === null) {
+        return;
+    }
+    console.log("Switching to the next item of the playlist...")
+    const next = pending
+    pending = null
+    streamStopped = next.stopped
+    startMediaResource(next.dataParts)
+    next.chunks.forEach(function (chunk) {
+        chunks.push(chunk)
+        addNextChunk()
+    })
+    if (next.stopped) {
+        closeMediaResource()
+    }
+    videoPlayer.play().catch(function (e) {
+        console.error("unable to play the next item", e)
+    })
+});
 
 videoPlayer.addEventListener('seeking', function (event) {
     console.log("---> REQUEST FROM: ", event.currentTarget.currentTime, event.currentTarget.duration)
@@ -81,6 +207,21 @@ videoPlayer.addEventListener('waiting', function() {
     // socket.send("decrBuff") // not implemented yet
 });
 
+// reports the playhead to the server, thus the stream is not sent far ahead of the playback
+let lastBufferStatusAt = 0;
+videoPlayer.addEventListener('timeupdate', function () {
+    const now = Date.now();
+    // the position of the current item would be taken as the position of the held one
+    if (pending !== null) {
+        return;
+    }
+    if (websocket.readyState !== WebSocket.OPEN || now - lastBufferStatusAt < 1000) {
+        return;
+    }
+    lastBufferStatusAt = now;
+    websocket.send(`BUFFER_STATUS::{ "position": ${positionBase + videoPlayer.currentTime} }`)
+});
+
 let currentVideoID = ''
 // initialization function
 function waitForVideoListWillBeRendered(selector, callback) {
@@ -103,6 +244,9 @@ function waitForVideoListWillBeRendered(selector, callback) {
 
 // called init. function
 waitForVideoListWillBeRendered('.video-list', function () {
+    if (playlistID !== '') {
+        return; // the playlist is requested on connect
+    }
     let UL = document.querySelector('.video-list');
     if (UL !== null) {
         let LIs = UL.getElementsByTagName('li');
@@ -120,6 +264,10 @@ waitForVideoListWillBeRendered('.video-list', function () {
 
 // next video handler
 nextBtn.addEventListener('click', function() {
+    if (playlistID !== '') {
+        requestControl('NEXT')
+        return;
+    }
     let UL = document.querySelector('.video-list');
     let found = false
     if (UL !== null) {
@@ -144,6 +292,10 @@ nextBtn.addEventListener('click', function() {
 
 // previous video handler
 prevBtn.addEventListener('click', function(event) {
+    if (playlistID !== '') {
+        requestControl('PREV')
+        return;
+    }
     let UL = document.querySelector('.video-list');
     if (UL !== null) {
         let LIs             = UL.getElementsByTagName('li');
@@ -190,12 +342,181 @@ document.addEventListener('click', function (event) {
     }
 });
 
+// the unfinished video is continued from the saved position of the user
 function requestByID(strategy, id) {
-    let data = `${strategy}::{ "id": "${id}", "token": "${token}" }`
+    let data = `${strategy}::{ "id": "${id}", "resume": true }`
+    console.log("websocket request: " + data);
+    switchUserRequested()
+    websocket.send(data)
+}
+
+// the items of the playlist are streamed back to back by the server
+function requestPlaylist(id) {
+    let shuffle = pageQuery.get('shuffle') === '1'
+    let repeat = pageQuery.get('repeat') || ''
+    let data = `PLAYLIST::{ "id": "${id}", "shuffle": ${shuffle}, "repeat": "${repeat}" }`
+    console.log("websocket request: " + data);
+    switchUserRequested()
+    websocket.send(data)
+}
+
+// the controls of the playing playlist (NEXT, PREV)
+function requestControl(action) {
+    let data = `${action}::{}`
+    console.log("websocket request: " + data);
+    switchUserRequested()
+    websocket.send(data)
+}
+
+// the live broadcast of another user is watched from the init segment and the recent data
+function requestLive(id) {
+    let data = `WATCH_LIVE::{ "id": "${id}" }`
     console.log("websocket request: " + data);
+    switchUserRequested()
     websocket.send(data)
 }
 
+// go live handler (the second click stops the broadcast)
+liveBtn.addEventListener('click', function () {
+    if (liveRecorder !== null) {
+        stopPublishing()
+        return;
+    }
+    startPublishing()
+});
+
+// the recording starts after the acknowledgement of the server (the live message), because the first chunk
+// contains the headers of the media which are sent to the late joiners
+function startPublishing() {
+    const mimeType = ['video/webm; codecs="vp8, opus"', 'video/webm', 'video/mp4']
+        .find((type) => MediaRecorder.isTypeSupported(type));
+    if (!mimeType) {
+        showAlert('Live streaming is not supported by the browser')
+        return;
+    }
+
+    navigator.mediaDevices.getUserMedia({video: true, audio: true})
+        .then(function (stream) {
+            liveStream = stream
+            liveRecorder = new MediaRecorder(stream, {mimeType: mimeType})
+            liveRecorder.ondataavailable = function (event) {
+                if (event.data.size > 0 && websocket.readyState === WebSocket.OPEN) {
+                    websocket.send(event.data)
+                }
+            }
+            liveRecorder.onstop = function () {
+                websocket.send('UNPUBLISH::{}')
+            }
+            liveBtn.textContent = 'Stop live'
+
+            const name = prompt('Broadcast name', 'Live') || 'Live'
+            const record = confirm('Save the broadcast as a video?')
+            let data = `PUBLISH::${JSON.stringify({name: name, mimeType: mimeType, record: record})}`
+            console.log("websocket request: " + data);
+            websocket.send(data)
+        })
+        .catch(function (e) {
+            console.error("unable to access the camera", e)
+            showAlert('Unable to access the camera')
+        })
+}
+
+function stopPublishing() {
+    if (liveRecorder.state !== 'inactive') {
+        liveRecorder.stop() // the last chunk is sent before the stop event
+    } else {
+        websocket.send('UNPUBLISH::{}')
+    }
+    liveStream.getTracks().forEach((track) => track.stop())
+    liveRecorder = null
+    liveStream = null
+    liveBtn.textContent = 'Go live'
+}
+
+// watch party handler (the second click leaves the party)
+partyBtn.addEventListener('click', function () {
+    if (party !== null) {
+        websocket.send('PARTY_LEAVE::{}')
+        party = null
+        partyBtn.textContent = 'Watch party'
+        return;
+    }
+    websocket.send('PARTY_CREATE::{}')
+});
+
+function onPartyMessage(msg) {
+    console.log("Party message: ", msg)
+    party = msg
+    partyBtn.textContent = msg.isHost ? 'Leave party (host)' : 'Leave party'
+
+    if (msg.event === 'created') {
+        showAlert(`Invite link: ${window.location.origin}${window.location.pathname}?party=${msg.token}`)
+        return;
+    }
+    if (msg.isHost) {
+        if (msg.event === 'host') {
+            sendPartyState() // the new host continues from its own playhead
+        }
+        return;
+    }
+    if (msg.event === 'joined' || msg.event === 'state' || msg.event === 'sync') {
+        followHost(msg)
+    }
+}
+
+// the member follows the playback state of the host
+function followHost(msg) {
+    if (msg.videoID === '') {
+        return;
+    }
+    if (msg.videoID !== currentVideoID) {
+        currentVideoID = msg.videoID
+        requestFrom(msg.videoID, msg.position)
+    } else {
+        const target = msg.position - positionBase
+        const buffered = videoPlayer.buffered
+        let isBuffered = false
+        for (let i = 0; i < buffered.length; i++) {
+            if (target >= buffered.start(i) && target <= buffered.end(i)) {
+                isBuffered = true
+            }
+        }
+        if (isBuffered) {
+            videoPlayer.currentTime = target
+        } else {
+            requestFrom(msg.videoID, msg.position)
+        }
+    }
+    if (msg.paused) {
+        videoPlayer.pause()
+    } else {
+        videoPlayer.play().catch((e) => console.error("unable to play", e))
+    }
+}
+
+// the host broadcasts its playback state on each change
+function sendPartyState() {
+    if (party === null || !party.isHost || websocket.readyState !== WebSocket.OPEN) {
+        return;
+    }
+    const state = {videoID: currentVideoID, position: positionBase + videoPlayer.currentTime, paused: videoPlayer.paused}
+    websocket.send(`PARTY_STATE::${JSON.stringify(state)}`)
+}
+['play', 'pause', 'seeked'].forEach((event) => videoPlayer.addEventListener(event, sendPartyState));
+
+function requestFrom(id, position) {
+    let data = `ID::{ "id": "${id}", "from": ${position} }`
+    console.log("websocket request: " + data);
+    switchUserRequested()
+    websocket.send(data)
+}
+
+// the requested stream replaces the current one immediately, the held item is dropped
+function switchUserRequested() {
+    pending = null
+    switchOnStart = true
+}
+
 function addNextChunk() {
     awaiting()
         .then(
@@ -257,7 +578,8 @@ async function awaitingClose() {
     }
 }
 
-function makeMediaResource(audioCodec, videoCodec) {
+// the mime type is passed as is for the live broadcasts, otherwise it's built by the codecs
+function makeMediaResource(audioCodec, videoCodec, mimeType) {
     mediaSource = new MediaSource();
     mediaSourceReady = false;
     videoPlayer.src = URL.createObjectURL(mediaSource);
@@ -285,11 +607,11 @@ function makeMediaResource(audioCodec, videoCodec) {
                 }
                 codecsStr += codecsMap[audioCodec]
             }
-            if (codecsStr === '') {
+            if (codecsStr === '' && !mimeType) {
                 console.error('Codecs string a empty! Unable to play video!')
             }
 
-            const codec = 'video/mp4; codecs="' + codecsStr +'"';
+            const codec = mimeType || 'video/mp4; codecs="' + codecsStr +'"';
 
             console.log("CODEC: ", codec)
 